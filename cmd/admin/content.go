@@ -0,0 +1,301 @@
+package main
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+
+	// csvCategorySeparator memisahkan beberapa nama kategori dalam satu kolom CSV video
+	csvCategorySeparator = ";"
+)
+
+var (
+	videoCSVHeader    = []string{"video_title", "video_url", "categories"}
+	categoryCSVHeader = []string{"kategori"}
+)
+
+func runExportVideos(a *app, args []string) error {
+	fs := newFlagSet("export-videos")
+	file := fs.String("file", "", "File tujuan (default stdout)")
+	format := fs.String("format", "", "Format file: csv atau json (default dari ekstensi file, json untuk stdout)")
+	fs.Parse(args)
+
+	fileFormat, err := resolveFormat(*format, *file)
+	if err != nil {
+		return err
+	}
+
+	items, err := a.educationalVideoService.ExportEducationalVideos()
+	if err != nil {
+		return err
+	}
+
+	err = writeOutput(*file, func(w io.Writer) error {
+		if fileFormat == formatJSON {
+			return writeJSON(w, items)
+		}
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			rows = append(rows, []string{item.VideoTitle, item.VideoURL, strings.Join(item.Categories, csvCategorySeparator)})
+		}
+		return writeCSV(w, videoCSVHeader, rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	reportExport(*file, len(items), "video edukasi")
+	return nil
+}
+
+func runImportVideos(a *app, args []string) error {
+	fs := newFlagSet("import-videos")
+	file := fs.String("file", "", "File sumber (wajib)")
+	format := fs.String("format", "", "Format file: csv atau json (default dari ekstensi file)")
+	fs.Parse(args)
+
+	if *file == "" {
+		fs.Usage()
+		return errors.New("file harus diisi")
+	}
+	fileFormat, err := resolveFormat(*format, *file)
+	if err != nil {
+		return err
+	}
+
+	var items []request.EducationalVideoImportRequest
+	if fileFormat == formatJSON {
+		err = readJSON(*file, &items)
+	} else {
+		err = readCSV(*file, videoCSVHeader, func(row map[string]string) {
+			var categories []string
+			for _, name := range strings.Split(row["categories"], csvCategorySeparator) {
+				if name = strings.TrimSpace(name); name != "" {
+					categories = append(categories, name)
+				}
+			}
+			items = append(items, request.EducationalVideoImportRequest{
+				VideoTitle: row["video_title"],
+				VideoURL:   row["video_url"],
+				Categories: categories,
+			})
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := a.educationalVideoService.ImportEducationalVideos(items)
+	if err != nil {
+		return err
+	}
+	return reportImport(result, "video edukasi")
+}
+
+func runExportCategories(a *app, args []string) error {
+	fs := newFlagSet("export-categories")
+	file := fs.String("file", "", "File tujuan (default stdout)")
+	format := fs.String("format", "", "Format file: csv atau json (default dari ekstensi file, json untuk stdout)")
+	fs.Parse(args)
+
+	fileFormat, err := resolveFormat(*format, *file)
+	if err != nil {
+		return err
+	}
+
+	items, err := a.categoryService.ExportCategories()
+	if err != nil {
+		return err
+	}
+
+	err = writeOutput(*file, func(w io.Writer) error {
+		if fileFormat == formatJSON {
+			return writeJSON(w, items)
+		}
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			rows = append(rows, []string{item.Kategori})
+		}
+		return writeCSV(w, categoryCSVHeader, rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	reportExport(*file, len(items), "kategori")
+	return nil
+}
+
+func runImportCategories(a *app, args []string) error {
+	fs := newFlagSet("import-categories")
+	file := fs.String("file", "", "File sumber (wajib)")
+	format := fs.String("format", "", "Format file: csv atau json (default dari ekstensi file)")
+	fs.Parse(args)
+
+	if *file == "" {
+		fs.Usage()
+		return errors.New("file harus diisi")
+	}
+	fileFormat, err := resolveFormat(*format, *file)
+	if err != nil {
+		return err
+	}
+
+	var items []request.CategoryRequest
+	if fileFormat == formatJSON {
+		err = readJSON(*file, &items)
+	} else {
+		err = readCSV(*file, categoryCSVHeader, func(row map[string]string) {
+			items = append(items, request.CategoryRequest{Kategori: row["kategori"]})
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := a.categoryService.ImportCategories(items)
+	if err != nil {
+		return err
+	}
+	return reportImport(result, "kategori")
+}
+
+// resolveFormat menentukan format file dari flag -format, lalu dari ekstensi file
+// Tanpa file (stdout) format default adalah JSON
+func resolveFormat(format, file string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		if file == "" {
+			return formatJSON, nil
+		}
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+	}
+
+	if format != formatCSV && format != formatJSON {
+		return "", fmt.Errorf("format harus csv atau json (didapat %q)", format)
+	}
+	return format, nil
+}
+
+// writeOutput menulis ke file (dibuat atau ditimpa) atau ke stdout jika file kosong
+func writeOutput(file string, write func(w io.Writer) error) error {
+	if file == "" {
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("gagal membuat file %s: %w", file, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeJSON menulis data sebagai JSON dengan indentasi
+func writeJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// writeCSV menulis header dan baris CSV
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// readJSON membaca file JSON berisi array data impor
+func readJSON(file string, target interface{}) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("gagal membaca file %s: %w", file, err)
+	}
+	if err := json.Unmarshal(content, target); err != nil {
+		return fmt.Errorf("file %s bukan JSON array yang valid: %w", file, err)
+	}
+	return nil
+}
+
+// readCSV membaca file CSV dengan baris header dan memanggil onRow untuk setiap baris data
+// Kolom dicocokkan berdasarkan nama header (case-insensitive); semua kolom pada columns wajib ada
+func readCSV(file string, columns []string, onRow func(row map[string]string)) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("gagal membaca file %s: %w", file, err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("file %s bukan CSV yang valid: %w", file, err)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("file %s kosong", file)
+	}
+
+	index := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		// Hapus BOM UTF-8 yang sering ditambahkan spreadsheet pada kolom pertama
+		name = strings.TrimPrefix(name, "\ufeff")
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range columns {
+		if _, ok := index[column]; !ok {
+			return fmt.Errorf("file %s harus memiliki kolom %s", file, strings.Join(columns, ", "))
+		}
+	}
+
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(columns))
+		for _, column := range columns {
+			if i := index[column]; i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		onRow(row)
+	}
+	return nil
+}
+
+// reportExport menampilkan ringkasan ekspor ke stderr agar tidak tercampur data di stdout
+func reportExport(file string, count int, label string) {
+	if file == "" {
+		file = "stdout"
+	}
+	fmt.Fprintf(os.Stderr, "%d %s diekspor ke %s\n", count, label, file)
+}
+
+// reportImport menampilkan hasil impor; mengembalikan error jika ada baris yang gagal
+func reportImport(result *response.ContentImportResponse, label string) error {
+	fmt.Printf("Impor %s: %d dibuat, %d dilewati (sudah ada), %d gagal\n", label, result.Created, result.Skipped, len(result.Errors))
+	for _, message := range result.Errors {
+		fmt.Printf("  - %s\n", message)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d baris %s gagal diimpor", len(result.Errors), label)
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"BE-PeriksaKesehatan/config"
+	"BE-PeriksaKesehatan/internal/migration"
+	"BE-PeriksaKesehatan/internal/repository"
+	"BE-PeriksaKesehatan/internal/service"
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// command adalah satu perintah cmd/admin
+type command struct {
+	usage string
+	run   func(a *app, args []string) error
+}
+
+// commands diisi di init karena fungsi perintah memakai newFlagSet yang membaca commands
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"create-admin":      {"-email <email> [-username <username>] [-nama <nama>] [-password <password>]", runCreateAdmin},
+		"reset-password":    {"-user <id|email|username> [-password <password>]", runResetPassword},
+		"disable-user":      {"-user <id|email|username>", runDisableUser},
+		"enable-user":       {"-user <id|email|username>", runEnableUser},
+		"user-stats":        {"-user <id|email|username>", runUserStats},
+		"export-videos":     {"[-file <path>] [-format csv|json]", runExportVideos},
+		"import-videos":     {"-file <path> [-format csv|json]", runImportVideos},
+		"export-categories": {"[-file <path>] [-format csv|json]", runExportCategories},
+		"import-categories": {"-file <path> [-format csv|json]", runImportCategories},
+		"purge-tokens":      {"", runPurgeTokens},
+	}
+}
+
+// app menampung repository dan service yang dipakai perintah cmd/admin
+type app struct {
+	authRepo                *repository.AuthRepository
+	adminService            *service.AdminService
+	educationalVideoService *service.EducationalVideoService
+	categoryService         *service.CategoryService
+}
+
+func main() {
+	flag.Usage = printUsage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Perintah tidak dikenal: %s\n\n", args[0])
+		printUsage()
+		os.Exit(2)
+	}
+
+	a, err := newApp()
+	if err != nil {
+		log.Fatalf("Fatal: %v", err)
+	}
+
+	if err := cmd.run(a, args[1:]); err != nil {
+		log.Fatalf("Fatal: %v", err)
+	}
+}
+
+// newApp membuka koneksi database dan menyiapkan repository serta service
+// Perintah ditolak selama masih ada migration pending agar tidak berjalan di atas skema lama
+func newApp() (*app, error) {
+	db, err := repository.OpenDB(config.LoadDatabaseURL())
+	if err != nil {
+		return nil, err
+	}
+
+	// Log SQL ditulis ke stderr (hanya peringatan) agar output perintah, termasuk ekspor ke stdout, tetap bersih
+	db = db.Session(&gorm.Session{
+		Logger: logger.New(log.New(os.Stderr, "", log.LstdFlags), logger.Config{
+			SlowThreshold: 200 * time.Millisecond,
+			LogLevel:      logger.Warn,
+		}),
+	})
+
+	pending, err := migration.NewRunner(db).Pending()
+	if err != nil {
+		return nil, fmt.Errorf("gagal memeriksa status migration: %w", err)
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("%d migration belum diterapkan, jalankan `go run ./cmd/migrate up` terlebih dahulu", len(pending))
+	}
+
+	userRepo := repository.NewUserRepository(db)
+	authRepo := repository.NewAuthRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+
+	return &app{
+		authRepo:                authRepo,
+		adminService:            service.NewAdminService(userRepo, authRepo),
+		educationalVideoService: service.NewEducationalVideoService(repository.NewEducationalVideoRepository(db), categoryRepo),
+		categoryService:         service.NewCategoryService(categoryRepo),
+	}, nil
+}
+
+// printUsage menampilkan daftar perintah cmd/admin
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Penggunaan: go run ./cmd/admin <perintah> [flag]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Perintah:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-18s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Jika -password tidak diisi, password dibaca dari baris pertama stdin.")
+}
+
+// newFlagSet membuat FlagSet untuk satu perintah
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Penggunaan: go run ./cmd/admin %s %s\n", name, commands[name].usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// readPassword mengembalikan password dari flag, atau membaca baris pertama stdin jika flag kosong
+// sehingga password tidak perlu muncul di riwayat shell (mis. `cat secret.txt | go run ./cmd/admin ...`)
+func readPassword(password string) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("password harus diisi lewat -password atau stdin")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func runCreateAdmin(a *app, args []string) error {
+	fs := newFlagSet("create-admin")
+	email := fs.String("email", "", "Email akun admin (wajib)")
+	username := fs.String("username", "", "Username akun admin (default bagian depan email)")
+	nama := fs.String("nama", "Administrator", "Nama akun admin")
+	password := fs.String("password", "", "Password akun admin (minimal 6 karakter)")
+	fs.Parse(args)
+
+	if *email == "" {
+		fs.Usage()
+		return errors.New("email harus diisi")
+	}
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	admin, err := a.adminService.CreateAdmin(*nama, *username, *email, pass)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Akun admin dibuat: id=%d username=%s email=%s\n", admin.ID, admin.Username, admin.Email)
+	return nil
+}
+
+func runResetPassword(a *app, args []string) error {
+	fs := newFlagSet("reset-password")
+	account := fs.String("user", "", "ID, email, atau username user (wajib)")
+	password := fs.String("password", "", "Password baru (minimal 6 karakter)")
+	fs.Parse(args)
+
+	if *account == "" {
+		fs.Usage()
+		return errors.New("user harus diisi")
+	}
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+
+	user, err := a.adminService.ResetUserPassword(*account, pass)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Password user %s (id=%d) berhasil direset, semua sesi dicabut\n", user.Username, user.ID)
+	return nil
+}
+
+func runDisableUser(a *app, args []string) error {
+	return setUserDisabled(a, "disable-user", args, true)
+}
+
+func runEnableUser(a *app, args []string) error {
+	return setUserDisabled(a, "enable-user", args, false)
+}
+
+// setUserDisabled menjalankan perintah disable-user dan enable-user
+func setUserDisabled(a *app, name string, args []string, disabled bool) error {
+	fs := newFlagSet(name)
+	account := fs.String("user", "", "ID, email, atau username user (wajib)")
+	fs.Parse(args)
+
+	if *account == "" {
+		fs.Usage()
+		return errors.New("user harus diisi")
+	}
+
+	user, err := a.adminService.SetUserDisabled(*account, disabled)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		fmt.Printf("Akun %s (id=%d) dinonaktifkan, semua sesi dicabut\n", user.Username, user.ID)
+	} else {
+		fmt.Printf("Akun %s (id=%d) diaktifkan kembali\n", user.Username, user.ID)
+	}
+	return nil
+}
+
+func runPurgeTokens(a *app, args []string) error {
+	fs := newFlagSet("purge-tokens")
+	fs.Parse(args)
+
+	deleted, err := a.authRepo.CleanupExpiredTokens()
+	if err != nil {
+		return fmt.Errorf("gagal menghapus token kadaluarsa: %w", err)
+	}
+	fmt.Printf("%d token blacklist yang sudah kadaluarsa berhasil dihapus\n", deleted)
+	return nil
+}
+
+func runUserStats(a *app, args []string) error {
+	fs := newFlagSet("user-stats")
+	account := fs.String("user", "", "ID, email, atau username user (wajib)")
+	fs.Parse(args)
+
+	if *account == "" {
+		fs.Usage()
+		return errors.New("user harus diisi")
+	}
+
+	stats, err := a.adminService.GetUserStats(*account)
+	if err != nil {
+		return err
+	}
+
+	status := "aktif"
+	if stats.User.DisabledAt != nil {
+		status = "nonaktif sejak " + stats.User.DisabledAt.Format("2006-01-02 15:04")
+	}
+	recordRange := "-"
+	if stats.FirstRecordDate != nil && stats.LastRecordDate != nil {
+		recordRange = *stats.FirstRecordDate + " s/d " + *stats.LastRecordDate
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "User\t%s (id=%d, %s)\n", stats.User.Username, stats.User.ID, stats.User.Email)
+	fmt.Fprintf(w, "Role\t%s\n", stats.User.Role)
+	fmt.Fprintf(w, "Status akun\t%s\n", status)
+	fmt.Fprintf(w, "Email terverifikasi\t%t\n", stats.User.EmailVerified)
+	fmt.Fprintf(w, "Terdaftar\t%s\n", stats.User.CreatedAt.Format("2006-01-02 15:04"))
+	fmt.Fprintf(w, "Data kesehatan\t%d (%s)\n", stats.HealthDataCount, recordRange)
+	fmt.Fprintf(w, "Alert kesehatan\t%d\n", stats.HealthAlertCount)
+	fmt.Fprintf(w, "Hasil lab\t%d\n", stats.LabResultCount)
+	fmt.Fprintf(w, "Obat\t%d (%d aktif)\n", stats.MedicationCount, stats.ActiveMedicationCount)
+	fmt.Fprintf(w, "Catatan minum obat\t%d\n", stats.MedicationLogCount)
+	fmt.Fprintf(w, "Notifikasi\t%d (%d belum dibaca)\n", stats.NotificationCount, stats.UnreadNotificationCount)
+	fmt.Fprintf(w, "Sesi aktif\t%d\n", stats.ActiveSessionCount)
+	fmt.Fprintf(w, "Akses caregiver aktif\t%d\n", stats.ActiveCareGrantCount)
+	return w.Flush()
+}
@@ -4,7 +4,16 @@ import (
 	"BE-PeriksaKesehatan/config"
 	"BE-PeriksaKesehatan/internal/handler"
 	"BE-PeriksaKesehatan/internal/repository"
+	"BE-PeriksaKesehatan/internal/service"
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
 func main() {
@@ -17,12 +26,107 @@ func main() {
 	}
 
 	userRepo := repository.NewUserRepository(db)
-	router := handler.SetupRouter(cfg, userRepo)
 
-	port := cfg.Port
-	log.Printf("Info: Server berjalan di port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Fatal: Gagal menjalankan server: %v", err)
+	// Bootstrap akun admin dari environment (ADMIN_EMAIL, ADMIN_USERNAME, ADMIN_PASSWORD)
+	adminService := service.NewAdminService(userRepo, repository.NewAuthRepository(db))
+	if err := adminService.BootstrapAdmin(cfg.AdminEmail, cfg.AdminUsername, cfg.AdminPassword); err != nil {
+		log.Printf("Warning: Gagal bootstrap akun admin: %v", err)
 	}
+
+	// Context dibatalkan saat SIGINT/SIGTERM diterima; semua proses background berhenti mengikutinya
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var background sync.WaitGroup
+
+	// Scheduler pengingat (minum obat, ukur tekanan darah, ajakan mencatat data) berjalan di background
+	if cfg.ReminderEnabled {
+		notificationRepo := repository.NewNotificationRepository(db)
+		notifier, err := service.NewNotifier(cfg.NotifierDriver, notificationRepo)
+		if err != nil {
+			log.Printf("Warning: Gagal menginisialisasi notifier (%v), notifikasi hanya ditulis ke log", err)
+			notifier = service.NewLogNotifier()
+		}
+		// Pengingat dikirim lewat NotificationService agar teks sesuai bahasa dan pengaturan notifikasi user
+		reminderScheduler := service.NewReminderScheduler(
+			repository.NewMedicationRepository(db),
+			repository.NewHealthDataRepository(db),
+			service.NewNotificationService(notificationRepo, userRepo, notifier),
+			cfg.ReminderInterval,
+			cfg.ReminderMeasurementTime,
+			cfg.ReminderNudgeTime,
+		)
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			reminderScheduler.Start(ctx)
+		}()
+	}
+
+	// Job maintenance (pembersihan token, sesi, dan data kadaluarsa) selalu didaftarkan agar statusnya
+	// tampil di endpoint health, tetapi hanya dijalankan jika JOBS_ENABLED
+	jobRunRepo := repository.NewJobRunRepository(db)
+	jobRunner := service.NewJobRunner(jobRunRepo)
+	maintenanceJobs := service.MaintenanceJobs(
+		repository.NewAuthRepository(db),
+		service.NewThrottleService(repository.NewThrottleRepository(db)),
+		jobRunRepo,
+		cfg.JobCleanupInterval,
+		cfg.JobRunRetention,
+	)
+	for _, job := range maintenanceJobs {
+		jobRunner.Register(job)
+	}
+	if cfg.JobsEnabled {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			jobRunner.Start(ctx)
+		}()
+	}
+
+	router := handler.SetupRouter(cfg, userRepo, jobRunner)
+
+	server := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Printf("Info: Server berjalan di port %s", cfg.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Fatal: Gagal menjalankan server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Printf("Info: Sinyal berhenti diterima, menunggu request dan job yang sedang berjalan (maksimal %s)", cfg.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Server berhenti menerima koneksi baru lalu menunggu request yang sedang diproses selesai
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Warning: Gagal mematikan server dengan bersih: %v", err)
+	}
+
+	// Scheduler pengingat dan job runner menyelesaikan eksekusi yang sedang berjalan
+	backgroundDone := make(chan struct{})
+	go func() {
+		background.Wait()
+		close(backgroundDone)
+	}()
+	select {
+	case <-backgroundDone:
+	case <-shutdownCtx.Done():
+		log.Println("Warning: Batas waktu shutdown tercapai sebelum semua proses background selesai")
+	}
+
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			log.Printf("Warning: Gagal menutup koneksi database: %v", err)
+		}
+	}
+	log.Println("Info: Server berhenti")
 }
 
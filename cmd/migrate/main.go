@@ -0,0 +1,92 @@
+package main
+
+import (
+	"BE-PeriksaKesehatan/config"
+	"BE-PeriksaKesehatan/internal/migration"
+	"BE-PeriksaKesehatan/internal/repository"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+const usage = `Penggunaan: go run ./cmd/migrate <perintah>
+
+Perintah:
+  up          Menerapkan semua migration yang belum diterapkan
+  down [n]    Me-rollback n migration terakhir (default 1)
+  status      Menampilkan status setiap migration
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	db, err := repository.OpenDB(config.LoadDatabaseURL())
+	if err != nil {
+		log.Fatalf("Fatal: %v", err)
+	}
+	runner := migration.NewRunner(db)
+
+	switch args[0] {
+	case "up":
+		if _, err := runner.Up(); err != nil {
+			log.Fatalf("Fatal: %v", err)
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Fatalf("Fatal: Jumlah migration tidak valid: %s", args[1])
+			}
+		}
+		if _, err := runner.Down(steps); err != nil {
+			log.Fatalf("Fatal: %v", err)
+		}
+
+	case "status":
+		statuses, err := runner.Status()
+		if err != nil {
+			log.Fatalf("Fatal: %v", err)
+		}
+		printStatus(statuses)
+
+	default:
+		fmt.Fprintf(os.Stderr, "Perintah tidak dikenal: %s\n\n", args[0])
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// printStatus menampilkan status migration dalam bentuk tabel
+func printStatus(statuses []migration.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSI\tNAMA\tSTATUS")
+	pending := 0
+	for _, status := range statuses {
+		state := "pending"
+		if status.AppliedAt != nil {
+			state = "diterapkan " + timezoneUtils.ToJakarta(*status.AppliedAt).Format("2006-01-02 15:04:05 MST")
+		} else {
+			pending++
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, state)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d migration, %d pending\n", len(statuses), pending)
+}
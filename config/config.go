@@ -3,6 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +15,62 @@ type Config struct {
 	DBURL     string
 	Port      string
 	JWTSecret string
+
+	// Proxy tepercaya: IP/CIDR reverse proxy yang boleh menentukan IP client lewat X-Forwarded-For/X-Real-IP.
+	// Kosong berarti tidak ada proxy yang dipercaya (IP client = alamat koneksi), sehingga header tersebut
+	// tidak bisa dipalsukan untuk menghindari atau memicu pembatasan percobaan per IP.
+	// TrustedPlatform (opsional) memakai header IP client dari platform: cloudflare, google_app_engine, flyio
+	TrustedProxies  []string
+	TrustedPlatform string
+
+	// Migration: jalankan migration yang belum diterapkan saat API start (dengan advisory lock).
+	// Jika false, migration dijalankan terpisah lewat cmd/migrate dan API menolak start selama ada migration pending
+	DBAutoMigrate bool
+
+	// Masa berlaku token: access token dibuat singkat, refresh token dirotasi setiap dipakai
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	// Reset password: masa berlaku token dan URL halaman reset di aplikasi (token ditambahkan sebagai query ?token=)
+	PasswordResetTTL time.Duration
+	PasswordResetURL string
+
+	// Verifikasi email: masa berlaku token, URL halaman verifikasi di aplikasi (token ditambahkan sebagai query ?token=),
+	// dan apakah route yang dilindungi diblokir sampai email diverifikasi
+	EmailVerificationTTL     time.Duration
+	EmailVerificationURL     string
+	RequireEmailVerification bool
+
+	// Pengiriman email (driver: log, file, smtp)
+	MailDriver   string
+	MailFrom     string
+	MailFileDir  string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// Notifikasi & pengingat: driver notifier (inbox, log), interval scheduler,
+	// dan jam pengingat harian (HH:MM, Asia/Jakarta)
+	NotifierDriver          string
+	ReminderEnabled         bool
+	ReminderInterval        time.Duration
+	ReminderMeasurementTime string
+	ReminderNudgeTime       string
+
+	// Job maintenance background (pembersihan token/sesi kadaluarsa): aktif/tidak, interval antar eksekusi,
+	// dan lama riwayat eksekusi job disimpan di tabel job_runs
+	JobsEnabled        bool
+	JobCleanupInterval time.Duration
+	JobRunRetention    time.Duration
+
+	// Batas waktu graceful shutdown: menunggu request dan job yang sedang berjalan selesai
+	ShutdownTimeout time.Duration
+
+	// Bootstrap admin (opsional): akun admin dibuat/dipromosikan saat aplikasi start
+	AdminEmail    string
+	AdminUsername string
+	AdminPassword string
 }
 
 // LoadConfig akan membaca file .env dan memasukkannya ke struct Config
@@ -46,5 +105,110 @@ func LoadConfig() *Config {
 		DBURL:     dbURL,
 		Port:      port,
 		JWTSecret: jwtSecret,
+
+		TrustedProxies:  getListEnv("TRUSTED_PROXIES"),
+		TrustedPlatform: os.Getenv("TRUSTED_PLATFORM"),
+
+		DBAutoMigrate: getBoolEnv("DB_AUTO_MIGRATE", true),
+
+		AccessTokenTTL:  getDurationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: getDurationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+
+		PasswordResetTTL: getDurationEnv("PASSWORD_RESET_TTL", time.Hour),
+		PasswordResetURL: os.Getenv("PASSWORD_RESET_URL"),
+
+		EmailVerificationTTL:     getDurationEnv("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+		EmailVerificationURL:     os.Getenv("EMAIL_VERIFICATION_URL"),
+		RequireEmailVerification: getBoolEnv("REQUIRE_EMAIL_VERIFICATION", false),
+
+		MailDriver:   getEnv("MAIL_DRIVER", "log"),
+		MailFrom:     getEnv("MAIL_FROM", "no-reply@periksakesehatan.local"),
+		MailFileDir:  getEnv("MAIL_FILE_DIR", "tmp/mail"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+
+		NotifierDriver:          getEnv("NOTIFIER_DRIVER", "inbox"),
+		ReminderEnabled:         getBoolEnv("REMINDER_ENABLED", true),
+		ReminderInterval:        getDurationEnv("REMINDER_INTERVAL", time.Minute),
+		ReminderMeasurementTime: getEnv("REMINDER_MEASUREMENT_TIME", "08:00"),
+		ReminderNudgeTime:       getEnv("REMINDER_NUDGE_TIME", "19:00"),
+
+		JobsEnabled:        getBoolEnv("JOBS_ENABLED", true),
+		JobCleanupInterval: getDurationEnv("JOB_CLEANUP_INTERVAL", time.Hour),
+		JobRunRetention:    getDurationEnv("JOB_RUN_RETENTION", 30*24*time.Hour),
+
+		ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", 15*time.Second),
+
+		AdminEmail:    os.Getenv("ADMIN_EMAIL"),
+		AdminUsername: os.Getenv("ADMIN_USERNAME"),
+		AdminPassword: os.Getenv("ADMIN_PASSWORD"),
+	}
+}
+
+// LoadDatabaseURL membaca file .env dan mengembalikan DATABASE_URL
+// Dipakai command yang hanya membutuhkan koneksi database (mis. cmd/migrate) tanpa variabel wajib lain seperti JWT_SECRET
+func LoadDatabaseURL() string {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Info: File .env tidak ditemukan, menggunakan environment variable sistem")
 	}
-}
\ No newline at end of file
+
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("Fatal: DATABASE_URL tidak ditemukan di .env atau environment variable")
+	}
+	return dbURL
+}
+
+// getEnv membaca environment variable dengan nilai default jika kosong
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getListEnv membaca daftar nilai yang dipisahkan koma dari environment variable
+// Nilai kosong diabaikan; mengembalikan nil jika environment variable kosong
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getBoolEnv membaca boolean dari environment variable (true/false, 1/0)
+// Mengembalikan nilai default jika kosong atau tidak valid
+func getBoolEnv(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: %s tidak valid (%s), menggunakan default %t", key, value, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getDurationEnv membaca durasi dari environment variable (format time.ParseDuration, contoh "15m", "720h")
+// Mengembalikan nilai default jika kosong atau tidak valid
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Warning: %s tidak valid (%s), menggunakan default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
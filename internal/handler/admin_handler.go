@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/middleware"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler menangani semua request terkait manajemen user oleh admin
+type AdminHandler struct {
+	adminService     *service.AdminService
+	throttleService  *service.ThrottleService
+	clinicianService *service.ClinicianService
+}
+
+// NewAdminHandler membuat instance baru dari AdminHandler
+func NewAdminHandler(adminService *service.AdminService, throttleService *service.ThrottleService, clinicianService *service.ClinicianService) *AdminHandler {
+	return &AdminHandler{
+		adminService:     adminService,
+		throttleService:  throttleService,
+		clinicianService: clinicianService,
+	}
+}
+
+// GetUsers menangani request untuk mengambil daftar user
+func (h *AdminHandler) GetUsers(c *gin.Context) {
+	var req request.AdminUserListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.adminService.GetUsers(&req)
+	if err != nil {
+		if strings.Contains(err.Error(), "harus salah satu") {
+			utils.BadRequest(c, "Validasi gagal", err.Error())
+			return
+		}
+		utils.InternalServerError(c, "Gagal mengambil daftar user", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Daftar user berhasil diambil", resp)
+}
+
+// UpdateUserRole menangani request untuk mengubah role user
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	adminID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	targetUserID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || targetUserID == 0 {
+		utils.BadRequest(c, "ID user tidak valid", nil)
+		return
+	}
+
+	var req request.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.adminService.UpdateUserRole(adminID, uint(targetUserID), &req)
+	if err != nil {
+		errMsg := err.Error()
+		switch {
+		case strings.Contains(errMsg, "tidak ditemukan"):
+			utils.NotFound(c, "User tidak ditemukan")
+		case strings.Contains(errMsg, "harus salah satu"), strings.Contains(errMsg, "role sendiri"):
+			utils.BadRequest(c, "Validasi gagal", errMsg)
+		default:
+			utils.InternalServerError(c, "Gagal mengubah role user", errMsg)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role user berhasil diubah", resp)
+}
+
+// GetLockouts menangani request untuk mengambil daftar penguncian login/register/reset password yang aktif
+func (h *AdminHandler) GetLockouts(c *gin.Context) {
+	var req request.AdminLockoutListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.throttleService.GetActiveLockouts(&req)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil daftar penguncian", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Daftar penguncian berhasil diambil", resp)
+}
+
+// UnlockLockout menangani request untuk membuka penguncian
+func (h *AdminHandler) UnlockLockout(c *gin.Context) {
+	adminID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	lockoutID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || lockoutID == 0 {
+		utils.BadRequest(c, "ID penguncian tidak valid", nil)
+		return
+	}
+
+	if err := h.throttleService.UnlockByID(uint(lockoutID), adminID); err != nil {
+		if strings.Contains(err.Error(), "tidak ditemukan") {
+			utils.NotFound(c, "Penguncian tidak ditemukan")
+			return
+		}
+		utils.InternalServerError(c, "Gagal membuka penguncian", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Penguncian berhasil dibuka", nil)
+}
+
+// GetAuthAuditLogs menangani request untuk mengambil audit keamanan autentikasi
+func (h *AdminHandler) GetAuthAuditLogs(c *gin.Context) {
+	var req request.AdminAuthAuditLogListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.throttleService.GetAuditLogs(&req)
+	if err != nil {
+		if strings.Contains(err.Error(), "harus salah satu") {
+			utils.BadRequest(c, "Validasi gagal", err.Error())
+			return
+		}
+		utils.InternalServerError(c, "Gagal mengambil audit autentikasi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Audit autentikasi berhasil diambil", resp)
+}
+
+// GetClinicianPatients menangani request untuk mengambil daftar pasien yang terhubung dengan clinician
+func (h *AdminHandler) GetClinicianPatients(c *gin.Context) {
+	clinicianID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || clinicianID == 0 {
+		utils.BadRequest(c, "ID clinician tidak valid", nil)
+		return
+	}
+
+	resp, err := h.clinicianService.GetAssignedPatients(uint(clinicianID))
+	if err != nil {
+		if strings.Contains(err.Error(), "tidak ditemukan") {
+			utils.NotFound(c, "Clinician tidak ditemukan")
+			return
+		}
+		utils.InternalServerError(c, "Gagal mengambil daftar pasien clinician", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Daftar pasien clinician berhasil diambil", resp)
+}
+
+// AssignPatientToClinician menangani request untuk menghubungkan pasien ke clinician
+func (h *AdminHandler) AssignPatientToClinician(c *gin.Context) {
+	adminID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	clinicianID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || clinicianID == 0 {
+		utils.BadRequest(c, "ID clinician tidak valid", nil)
+		return
+	}
+
+	var req request.AssignPatientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	if err := h.clinicianService.AssignPatient(adminID, uint(clinicianID), &req); err != nil {
+		errMsg := err.Error()
+		switch {
+		case strings.Contains(errMsg, "tidak ditemukan"):
+			utils.NotFound(c, "User tidak ditemukan")
+		case strings.Contains(errMsg, "sudah terhubung"):
+			utils.ErrorResponse(c, http.StatusConflict, errMsg, nil)
+		case strings.Contains(errMsg, "bukan clinician"), strings.Contains(errMsg, "bukan pasien"):
+			utils.BadRequest(c, "Validasi gagal", errMsg)
+		default:
+			utils.InternalServerError(c, "Gagal menghubungkan pasien ke clinician", errMsg)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Pasien berhasil dihubungkan ke clinician", nil)
+}
+
+// UnassignPatientFromClinician menangani request untuk melepas pasien dari clinician
+func (h *AdminHandler) UnassignPatientFromClinician(c *gin.Context) {
+	clinicianID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || clinicianID == 0 {
+		utils.BadRequest(c, "ID clinician tidak valid", nil)
+		return
+	}
+
+	patientID, err := strconv.ParseUint(c.Param("patientId"), 10, 32)
+	if err != nil || patientID == 0 {
+		utils.BadRequest(c, "ID pasien tidak valid", nil)
+		return
+	}
+
+	if err := h.clinicianService.UnassignPatient(uint(clinicianID), uint(patientID)); err != nil {
+		if strings.Contains(err.Error(), "tidak ditemukan") {
+			utils.NotFound(c, err.Error())
+			return
+		}
+		utils.InternalServerError(c, "Gagal melepas pasien dari clinician", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pasien berhasil dilepas dari clinician", nil)
+}
@@ -5,7 +5,11 @@ import (
 	"BE-PeriksaKesehatan/internal/model/dto/request"
 	"BE-PeriksaKesehatan/internal/model/dto/response"
 	"BE-PeriksaKesehatan/internal/repository"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/middleware"
 	"BE-PeriksaKesehatan/pkg/utils"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -17,19 +21,31 @@ import (
 	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
 )
 
+// dummyPasswordHash dibandingkan saat identifier tidak terdaftar agar waktu respons login
+// sama dengan akun yang ada (cost sama dengan bcrypt.DefaultCost yang dipakai saat registrasi)
+const dummyPasswordHash = "$2a$10$sMm6Srarq/wOH0Fbq2fbt.nV1SpumCW0/6T8fjFDgA3.S/OHrQYHC"
+
 type AuthHandler struct {
-	userRepo  *repository.UserRepository
-	authRepo  *repository.AuthRepository
-	jwtSecret string
+	userRepo        *repository.UserRepository
+	authRepo        *repository.AuthRepository
+	sessionService  *service.SessionService
+	passwordService *service.PasswordService
+	emailService    *service.EmailVerificationService
+	throttleService *service.ThrottleService
+	jwtSecret       string
 }
 
-func NewAuthHandler(userRepo *repository.UserRepository, jwtSecret string) *AuthHandler {
+func NewAuthHandler(userRepo *repository.UserRepository, sessionService *service.SessionService, passwordService *service.PasswordService, emailService *service.EmailVerificationService, throttleService *service.ThrottleService, jwtSecret string) *AuthHandler {
 	authRepo := repository.NewAuthRepository(userRepo.GetDB())
 
 	return &AuthHandler{
-		userRepo:  userRepo,
-		authRepo:  authRepo,
-		jwtSecret: jwtSecret,
+		userRepo:        userRepo,
+		authRepo:        authRepo,
+		sessionService:  sessionService,
+		passwordService: passwordService,
+		emailService:    emailService,
+		throttleService: throttleService,
+		jwtSecret:       jwtSecret,
 	}
 }
 
@@ -83,6 +99,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Username: username,
 		Email:    req.Email,
 		Password: string(hashedPassword),
+		Role:     entity.RolePatient,
 	}
 
 	if err := h.userRepo.CreateUser(user); err != nil {
@@ -90,10 +107,16 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	// Kegagalan kirim email tidak menggagalkan registrasi, user bisa meminta kirim ulang
+	if err := h.emailService.SendVerificationEmail(user); err != nil {
+		log.Printf("[Auth] Warning: gagal mengirim email verifikasi ke user %d: %v", user.ID, err)
+	}
+
 	resp := response.RegisterResponse{
-		Message: "Pendaftaran berhasil",
-		Nama:    user.Nama,
-		Email:   user.Email,
+		Message:       "Pendaftaran berhasil, silakan cek email untuk verifikasi",
+		Nama:          user.Nama,
+		Email:         user.Email,
+		EmailVerified: false,
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, resp.Message, resp)
@@ -108,41 +131,66 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	user, err := h.userRepo.GetUserByEmailOrUsername(req.Identifier)
-	if err != nil {
-		if err.Error() == "user tidak ditemukan" {
-			utils.Unauthorized(c, "Email/Username atau password salah")
-			return
-		}
+	if err != nil && err.Error() != "user tidak ditemukan" {
 		utils.InternalServerError(c, "Gagal memproses login", err.Error())
 		return
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+	// Percobaan per akun dihitung berdasarkan user ID (email dan username berbagi hitungan yang sama).
+	// Identifier yang tidak terdaftar tetap dibatasi agar respons tidak membedakan akun yang ada.
+	accountKey := service.IdentifierThrottleKey(req.Identifier)
+	var userID *uint
+	if user != nil {
+		accountKey = service.UserThrottleKey(user.ID)
+		userID = &user.ID
+	}
+
+	if err := h.throttleService.Check(service.ThrottleActionLogin, entity.ThrottleScopeAccount, accountKey); err != nil {
+		h.handleThrottleError(c, err)
+		return
+	}
+
+	// bcrypt tetap dijalankan untuk identifier yang tidak terdaftar agar waktu respons tidak membedakan akun yang ada
+	passwordHash := dummyPasswordHash
+	if user != nil {
+		passwordHash = user.Password
+	}
+	passwordErr := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password))
+
+	if user == nil || passwordErr != nil {
+		if err := h.throttleService.RecordFailure(service.ThrottleActionLogin, entity.ThrottleScopeAccount, accountKey, userID, c.ClientIP()); err != nil {
+			log.Printf("[Auth] Warning: gagal mencatat percobaan login gagal: %v", err)
+		}
 		utils.Unauthorized(c, "Email/Username atau password salah")
 		return
 	}
 
-	now := timezoneUtils.NowInJakarta()
-	claims := jwt.MapClaims{
-		"sub":   user.ID,
-		"email": user.Email,
-		"name":  user.Nama,
-		"exp":   now.Add(24 * time.Hour).Unix(),
-		"iat":   now.Unix(),
+	if err := h.throttleService.Reset(service.ThrottleActionLogin, entity.ThrottleScopeAccount, accountKey); err != nil {
+		log.Printf("[Auth] Warning: gagal mereset percobaan login user %d: %v", user.ID, err)
+	}
+
+	// Status nonaktif baru diperiksa setelah password benar agar status akun tidak terbuka untuk pihak lain
+	if user.IsDisabled() {
+		utils.Forbidden(c, "Akun dinonaktifkan, silakan hubungi administrator")
+		return
 	}
 
-	tokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	token, err := tokenObj.SignedString([]byte(h.jwtSecret))
+	tokens, err := h.sessionService.CreateSession(user, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		utils.InternalServerError(c, "Gagal membuat token", err.Error())
 		return
 	}
 
 	resp := response.LoginResponse{
-		Token:    token,
-		Nama:     user.Nama,
-		Username: user.Username,
-		Email:    user.Email,
+		Token:            tokens.Token,
+		RefreshToken:     tokens.RefreshToken,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+		Nama:             user.Nama,
+		Username:         user.Username,
+		Email:            user.Email,
+		Role:             string(user.Role),
+		EmailVerified:    user.IsEmailVerified(),
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Login berhasil", resp)
@@ -232,6 +280,233 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
+	// Cabut sesi agar refresh token ikut tidak berlaku
+	if sid, ok := claims["sid"].(float64); ok {
+		if err := h.sessionService.RevokeSession(userID, uint(sid)); err != nil && err.Error() != "sesi tidak ditemukan" {
+			utils.InternalServerError(c, "Gagal melakukan logout", err.Error())
+			return
+		}
+	}
+
 	utils.SuccessResponse(c, http.StatusOK, "Logout berhasil", nil)
 }
 
+// RefreshToken menukar refresh token dengan access token dan refresh token baru.
+// Refresh token yang dipakai langsung tidak berlaku (rotasi).
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req request.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.sessionService.RefreshSession(req.RefreshToken, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		if err.Error() == "refresh token tidak valid" {
+			utils.Unauthorized(c, "Refresh token tidak valid atau sudah expired")
+			return
+		}
+		utils.InternalServerError(c, "Gagal memperbarui token", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Token berhasil diperbarui", resp)
+}
+
+// GetSessions menampilkan daftar sesi login aktif milik user
+func (h *AuthHandler) GetSessions(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+	currentSessionID, _ := middleware.GetSessionIDFromContext(c)
+
+	resp, err := h.sessionService.GetActiveSessions(userID, currentSessionID)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil daftar sesi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Daftar sesi berhasil diambil", resp)
+}
+
+// RevokeSession mencabut satu sesi login milik user
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || sessionID == 0 {
+		utils.BadRequest(c, "ID sesi tidak valid", nil)
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(userID, uint(sessionID)); err != nil {
+		if err.Error() == "sesi tidak ditemukan" {
+			utils.NotFound(c, "Sesi tidak ditemukan")
+			return
+		}
+		utils.InternalServerError(c, "Gagal mencabut sesi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Sesi berhasil dicabut", nil)
+}
+
+// RevokeAllSessions mencabut semua sesi login milik user (logout dari semua perangkat)
+func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.sessionService.RevokeAllSessions(userID)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mencabut semua sesi", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Semua sesi berhasil dicabut", resp)
+}
+
+// ChangePassword mengganti password user yang sedang login.
+// Sesi lain dicabut, sesi saat ini tetap aktif.
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+	currentSessionID, _ := middleware.GetSessionIDFromContext(c)
+
+	var req request.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	if err := h.passwordService.ChangePassword(userID, currentSessionID, &req); err != nil {
+		switch err.Error() {
+		case "password baru dan konfirmasi password tidak sama",
+			"password baru tidak boleh sama dengan password saat ini",
+			"password saat ini salah":
+			utils.BadRequest(c, "Validasi gagal", err.Error())
+		case "user tidak ditemukan":
+			utils.NotFound(c, "User tidak ditemukan")
+		default:
+			utils.InternalServerError(c, "Gagal mengganti password", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password berhasil diganti", nil)
+}
+
+// ForgotPassword mengirim token reset password ke email user.
+// Response selalu sama untuk email terdaftar maupun tidak.
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req request.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	// Permintaan per email dibatasi agar inbox user tidak dibanjiri email reset
+	emailKey := service.IdentifierThrottleKey(req.Email)
+	if err := h.throttleService.Check(service.ThrottleActionPasswordReset, entity.ThrottleScopeAccount, emailKey); err != nil {
+		h.handleThrottleError(c, err)
+		return
+	}
+	if err := h.throttleService.RecordFailure(service.ThrottleActionPasswordReset, entity.ThrottleScopeAccount, emailKey, nil, c.ClientIP()); err != nil {
+		log.Printf("[Auth] Warning: gagal mencatat permintaan reset password: %v", err)
+	}
+
+	if err := h.passwordService.ForgotPassword(&req); err != nil {
+		utils.InternalServerError(c, "Gagal memproses permintaan reset password", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Jika email terdaftar, instruksi reset password telah dikirim", nil)
+}
+
+// ResetPassword mengatur ulang password menggunakan token dari email
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req request.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	if err := h.passwordService.ResetPassword(&req); err != nil {
+		switch err.Error() {
+		case "password baru dan konfirmasi password tidak sama":
+			utils.BadRequest(c, "Validasi gagal", err.Error())
+		case "token reset password tidak valid":
+			utils.BadRequest(c, "Token reset password tidak valid atau sudah expired", nil)
+		default:
+			utils.InternalServerError(c, "Gagal mengatur ulang password", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password berhasil diatur ulang, silakan login kembali", nil)
+}
+
+// VerifyEmail memverifikasi email user menggunakan token dari email
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req request.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	if err := h.emailService.VerifyEmail(req.Token); err != nil {
+		if err.Error() == "token verifikasi email tidak valid" {
+			utils.BadRequest(c, "Token verifikasi email tidak valid atau sudah expired", nil)
+			return
+		}
+		utils.InternalServerError(c, "Gagal memverifikasi email", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Email berhasil diverifikasi", nil)
+}
+
+// ResendVerificationEmail mengirim ulang email verifikasi untuk user yang sedang login
+func (h *AuthHandler) ResendVerificationEmail(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	if err := h.emailService.ResendVerificationEmail(userID); err != nil {
+		switch err.Error() {
+		case "email sudah diverifikasi":
+			utils.ErrorResponse(c, http.StatusConflict, "Email sudah diverifikasi", nil)
+		case "user tidak ditemukan":
+			utils.NotFound(c, "User tidak ditemukan")
+		default:
+			h.handleThrottleError(c, err)
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Email verifikasi berhasil dikirim", nil)
+}
+
+// handleThrottleError mengirim 429 untuk error rate limit, selain itu 500
+func (h *AuthHandler) handleThrottleError(c *gin.Context, err error) {
+	var rateLimitErr *service.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		utils.TooManyRequests(c, rateLimitErr.Message, rateLimitErr.RetryAfter)
+		return
+	}
+	utils.InternalServerError(c, "Gagal memproses permintaan", err.Error())
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/middleware"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CareHandler menangani semua request terkait akses caregiver/keluarga
+type CareHandler struct {
+	careService *service.CareService
+}
+
+// NewCareHandler membuat instance baru dari CareHandler
+func NewCareHandler(careService *service.CareService) *CareHandler {
+	return &CareHandler{
+		careService: careService,
+	}
+}
+
+// InviteCaregiver menangani request pasien untuk mengundang caregiver
+func (h *CareHandler) InviteCaregiver(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.InviteCaregiverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	if err := h.careService.InviteCaregiver(userID, &req); err != nil {
+		h.handleCareError(c, err, "Gagal mengundang caregiver")
+		return
+	}
+
+	// Response sama untuk akun yang terdaftar maupun tidak agar keberadaan akun tidak terbuka
+	utils.SuccessResponse(c, http.StatusAccepted, "Undangan dikirim jika akun terdaftar", nil)
+}
+
+// GetGrantedAccess menangani request pasien untuk melihat daftar caregiver yang diberi akses
+func (h *CareHandler) GetGrantedAccess(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.careService.GetGrantedAccess(userID)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil daftar caregiver", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Daftar caregiver berhasil diambil", resp)
+}
+
+// UpdateGrantScope menangani request pasien untuk mengubah cakupan akses caregiver
+func (h *CareHandler) UpdateGrantScope(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	grantID, ok := h.parseGrantID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateCareGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.careService.UpdateGrantScope(userID, grantID, &req)
+	if err != nil {
+		h.handleCareError(c, err, "Gagal mengubah akses caregiver")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Akses caregiver berhasil diubah", resp)
+}
+
+// RevokeGrant menangani request untuk mencabut akses caregiver (oleh pasien maupun caregiver)
+func (h *CareHandler) RevokeGrant(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	grantID, ok := h.parseGrantID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.careService.RevokeGrant(userID, grantID); err != nil {
+		h.handleCareError(c, err, "Gagal mencabut akses caregiver")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Akses caregiver berhasil dicabut", nil)
+}
+
+// GetReceivedAccess menangani request caregiver untuk melihat undangan dan akses pasien yang diterima
+func (h *CareHandler) GetReceivedAccess(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.careService.GetReceivedAccess(userID)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil daftar pasien", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Daftar pasien berhasil diambil", resp)
+}
+
+// AcceptInvitation menangani request caregiver untuk menerima undangan
+func (h *CareHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	grantID, ok := h.parseGrantID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.careService.AcceptInvitation(userID, grantID)
+	if err != nil {
+		h.handleCareError(c, err, "Gagal menerima undangan")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Undangan berhasil diterima", resp)
+}
+
+// DeclineInvitation menangani request caregiver untuk menolak undangan
+func (h *CareHandler) DeclineInvitation(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	grantID, ok := h.parseGrantID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.careService.DeclineInvitation(userID, grantID); err != nil {
+		h.handleCareError(c, err, "Gagal menolak undangan")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Undangan berhasil ditolak", nil)
+}
+
+// parseGrantID mengambil ID akses caregiver dari path parameter
+func (h *CareHandler) parseGrantID(c *gin.Context) (uint, bool) {
+	grantID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || grantID == 0 {
+		utils.BadRequest(c, "ID akses caregiver tidak valid", nil)
+		return 0, false
+	}
+	return uint(grantID), true
+}
+
+// handleCareError memetakan error dari service ke response HTTP
+func (h *CareHandler) handleCareError(c *gin.Context, err error, defaultMessage string) {
+	errMsg := err.Error()
+	switch {
+	case strings.Contains(errMsg, "tidak ditemukan"):
+		utils.NotFound(c, errMsg)
+	case strings.Contains(errMsg, "sudah"):
+		utils.ErrorResponse(c, http.StatusConflict, errMsg, nil)
+	case strings.Contains(errMsg, "harus salah satu"), strings.Contains(errMsg, "diri sendiri"):
+		utils.BadRequest(c, "Validasi gagal", errMsg)
+	default:
+		utils.InternalServerError(c, defaultMessage, errMsg)
+	}
+}
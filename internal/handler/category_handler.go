@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler menangani semua request terkait kategori video edukasi
+type CategoryHandler struct {
+	categoryService *service.CategoryService
+}
+
+// NewCategoryHandler membuat instance baru dari CategoryHandler
+func NewCategoryHandler(categoryService *service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+// GetCategories menangani request untuk mengambil semua kategori
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	resp, err := h.categoryService.GetCategories()
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil kategori", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Kategori berhasil diambil", resp)
+}
+
+// AddCategory menangani request untuk menambah kategori
+func (h *CategoryHandler) AddCategory(c *gin.Context) {
+	var req request.CategoryRequest
+
+	// Bind JSON request ke struct
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.categoryService.CreateCategory(&req)
+	if err != nil {
+		h.handleCategoryError(c, err, "Gagal menambah kategori")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Kategori berhasil ditambahkan", resp)
+}
+
+// UpdateCategory menangani request untuk mengubah nama kategori
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	var req request.CategoryRequest
+
+	// Bind JSON request ke struct
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.categoryService.UpdateCategory(c.Param("id"), &req)
+	if err != nil {
+		h.handleCategoryError(c, err, "Gagal mengubah kategori")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Kategori berhasil diubah", resp)
+}
+
+// DeleteCategory menangani request untuk menghapus kategori
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	if err := h.categoryService.DeleteCategory(c.Param("id")); err != nil {
+		h.handleCategoryError(c, err, "Gagal menghapus kategori")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Kategori berhasil dihapus", nil)
+}
+
+// handleCategoryError memetakan error service kategori ke response HTTP
+func (h *CategoryHandler) handleCategoryError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "ID kategori tidak valid":
+		utils.BadRequest(c, "ID kategori tidak valid", nil)
+	case "kategori tidak boleh kosong":
+		utils.BadRequest(c, "Validasi gagal", err.Error())
+	case "kategori tidak ditemukan":
+		utils.NotFound(c, "Kategori tidak ditemukan")
+	case "kategori sudah ada",
+		"kategori default tidak dapat diubah",
+		"kategori default tidak dapat dihapus":
+		utils.ErrorResponse(c, http.StatusConflict, err.Error(), nil)
+	default:
+		utils.InternalServerError(c, message, err.Error())
+	}
+}
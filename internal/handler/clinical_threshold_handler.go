@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/middleware"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClinicalThresholdHandler menangani semua request terkait batas klinis
+type ClinicalThresholdHandler struct {
+	clinicalThresholdService *service.ClinicalThresholdService
+}
+
+// NewClinicalThresholdHandler membuat instance baru dari ClinicalThresholdHandler
+func NewClinicalThresholdHandler(clinicalThresholdService *service.ClinicalThresholdService) *ClinicalThresholdHandler {
+	return &ClinicalThresholdHandler{
+		clinicalThresholdService: clinicalThresholdService,
+	}
+}
+
+// GetThresholds menangani request untuk mengambil batas klinis yang berlaku untuk pemilik data
+func (h *ClinicalThresholdHandler) GetThresholds(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.clinicalThresholdService.GetThresholds(userID)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil batas klinis", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Batas klinis berhasil diambil", resp)
+}
+
+// GetDefaultThresholds menangani request admin untuk mengambil batas klinis default global
+func (h *ClinicalThresholdHandler) GetDefaultThresholds(c *gin.Context) {
+	resp, err := h.clinicalThresholdService.GetDefaultThresholds()
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil batas klinis default", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Batas klinis default berhasil diambil", resp)
+}
+
+// UpdateDefaultThresholds menangani request admin untuk mengubah batas klinis default global
+func (h *ClinicalThresholdHandler) UpdateDefaultThresholds(c *gin.Context) {
+	adminID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.UpdateClinicalThresholdsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.clinicalThresholdService.UpdateDefaultThresholds(adminID, &req)
+	if err != nil {
+		h.handleThresholdError(c, err, "Gagal mengubah batas klinis default")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Batas klinis default berhasil diubah", resp)
+}
+
+// UpdatePatientThresholds menangani request clinician untuk menyesuaikan batas klinis pasien
+func (h *ClinicalThresholdHandler) UpdatePatientThresholds(c *gin.Context) {
+	clinicianID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	patientID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || patientID == 0 {
+		utils.BadRequest(c, "ID pasien tidak valid", nil)
+		return
+	}
+
+	var req request.UpdateClinicalThresholdsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.clinicalThresholdService.UpdatePatientThresholds(clinicianID, uint(patientID), &req)
+	if err != nil {
+		h.handleThresholdError(c, err, "Gagal mengubah batas klinis pasien")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Batas klinis pasien berhasil diubah", resp)
+}
+
+// ResetPatientThresholds menangani request clinician untuk mengembalikan batas klinis pasien ke default
+func (h *ClinicalThresholdHandler) ResetPatientThresholds(c *gin.Context) {
+	clinicianID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	patientID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || patientID == 0 {
+		utils.BadRequest(c, "ID pasien tidak valid", nil)
+		return
+	}
+
+	if err := h.clinicalThresholdService.ResetPatientThresholds(clinicianID, uint(patientID)); err != nil {
+		h.handleThresholdError(c, err, "Gagal mengembalikan batas klinis pasien")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Batas klinis pasien dikembalikan ke default", nil)
+}
+
+// handleThresholdError memetakan error dari service ke response HTTP
+func (h *ClinicalThresholdHandler) handleThresholdError(c *gin.Context, err error, defaultMessage string) {
+	errMsg := err.Error()
+	switch {
+	case strings.Contains(errMsg, "tidak terhubung"):
+		utils.Forbidden(c, errMsg)
+	case strings.Contains(errMsg, "tidak ditemukan"):
+		utils.NotFound(c, errMsg)
+	case strings.Contains(errMsg, "harus lebih kecil"):
+		utils.BadRequest(c, "Validasi gagal", errMsg)
+	default:
+		utils.InternalServerError(c, defaultMessage, errMsg)
+	}
+}
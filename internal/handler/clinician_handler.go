@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/middleware"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClinicianHandler menangani semua request terkait dashboard clinician
+type ClinicianHandler struct {
+	clinicianService *service.ClinicianService
+}
+
+// NewClinicianHandler membuat instance baru dari ClinicianHandler
+func NewClinicianHandler(clinicianService *service.ClinicianService) *ClinicianHandler {
+	return &ClinicianHandler{
+		clinicianService: clinicianService,
+	}
+}
+
+// GetDashboard menangani request untuk mengambil dashboard pasien milik clinician
+func (h *ClinicianHandler) GetDashboard(c *gin.Context) {
+	clinicianID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.ClinicianDashboardRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.clinicianService.GetDashboard(clinicianID, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "harus salah satu") {
+			utils.BadRequest(c, "Validasi gagal", err.Error())
+			return
+		}
+		utils.InternalServerError(c, "Gagal mengambil dashboard clinician", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Dashboard clinician berhasil diambil", resp)
+}
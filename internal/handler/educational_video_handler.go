@@ -100,3 +100,60 @@ func (h *EducationalVideoHandler) GetEducationalVideosByID(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// UpdateEducationalVideo menangani request untuk mengubah video edukasi
+func (h *EducationalVideoHandler) UpdateEducationalVideo(c *gin.Context) {
+	var req request.EducationalVideoRequest
+
+	// Bind JSON request ke struct
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	// Panggil service untuk mengubah video
+	resp, err := h.educationalVideoService.UpdateEducationalVideo(c.Param("id"), &req)
+	if err != nil {
+		if err.Error() == "ID video tidak valid" {
+			utils.BadRequest(c, "ID video tidak valid", nil)
+			return
+		}
+		if err.Error() == "video tidak ditemukan" {
+			utils.NotFound(c, "Video tidak ditemukan")
+			return
+		}
+		// Cek apakah error adalah validasi
+		if err.Error() == "video_title tidak boleh kosong" ||
+			err.Error() == "video_url tidak boleh kosong" ||
+			err.Error() == "video_url harus berupa URL yang valid" ||
+			err.Error() == "category_ids tidak boleh kosong" ||
+			err.Error() == "kategori tidak ditemukan" {
+			utils.BadRequest(c, "Validasi gagal", err.Error())
+			return
+		}
+		utils.InternalServerError(c, "Gagal mengubah video edukasi", err.Error())
+		return
+	}
+
+	// Response sukses
+	utils.SuccessResponse(c, http.StatusOK, "Video edukasi berhasil diubah", resp)
+}
+
+// DeleteEducationalVideo menangani request untuk menghapus video edukasi
+func (h *EducationalVideoHandler) DeleteEducationalVideo(c *gin.Context) {
+	// Panggil service untuk menghapus video
+	if err := h.educationalVideoService.DeleteEducationalVideo(c.Param("id")); err != nil {
+		if err.Error() == "ID video tidak valid" {
+			utils.BadRequest(c, "ID video tidak valid", nil)
+			return
+		}
+		if err.Error() == "video tidak ditemukan" {
+			utils.NotFound(c, "Video tidak ditemukan")
+			return
+		}
+		utils.InternalServerError(c, "Gagal menghapus video edukasi", err.Error())
+		return
+	}
+
+	// Response sukses
+	utils.SuccessResponse(c, http.StatusOK, "Video edukasi berhasil dihapus", nil)
+}
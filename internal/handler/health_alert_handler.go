@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
 	"BE-PeriksaKesehatan/internal/repository"
 	"BE-PeriksaKesehatan/internal/service"
 	"BE-PeriksaKesehatan/pkg/middleware"
 	"BE-PeriksaKesehatan/pkg/utils"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,8 +30,8 @@ func NewHealthAlertHandler(healthAlertService *service.HealthAlertService, authR
 // CheckHealthAlerts menangani request untuk memeriksa health alerts
 // Endpoint GET yang mengambil data kesehatan terbaru dari database
 func (h *HealthAlertHandler) CheckHealthAlerts(c *gin.Context) {
-	// Ambil user ID dari context (sudah divalidasi oleh middleware)
-	userID, ok := middleware.GetUserIDFromContext(c)
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -45,3 +48,166 @@ func (h *HealthAlertHandler) CheckHealthAlerts(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Health alerts berhasil diperiksa", resp)
 }
 
+
+// GetHealthAlertHistory menangani request untuk mengambil riwayat health alert tersimpan
+// Mendukung filter status, category, start_date, end_date dan pagination (page, limit)
+func (h *HealthAlertHandler) GetHealthAlertHistory(c *gin.Context) {
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.HealthAlertHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.healthAlertService.GetHealthAlertHistory(userID, &req)
+	if err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "harus") || strings.Contains(errMsg, "tidak boleh") {
+			utils.BadRequest(c, "Validasi gagal", errMsg)
+			return
+		}
+		utils.InternalServerError(c, "Gagal mengambil riwayat health alerts", errMsg)
+		return
+	}
+
+	// Response sukses
+	utils.SuccessResponse(c, http.StatusOK, "Riwayat health alerts berhasil diambil", resp)
+}
+
+// parseAlertID mengambil dan memvalidasi parameter :id alert dari URL
+func parseAlertID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		utils.BadRequest(c, "ID alert tidak valid", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// handleAlertLifecycleError memetakan error lifecycle alert ke HTTP status yang sesuai
+func handleAlertLifecycleError(c *gin.Context, err error, message string) {
+	errMsg := err.Error()
+	switch {
+	case strings.Contains(errMsg, "tidak ditemukan"):
+		utils.NotFound(c, "Alert tidak ditemukan")
+	case strings.Contains(errMsg, "sudah diselesaikan"):
+		utils.ErrorResponse(c, http.StatusConflict, "Alert sudah diselesaikan", nil)
+	default:
+		utils.InternalServerError(c, message, errMsg)
+	}
+}
+
+// MarkHealthAlertRead menangani request untuk menandai alert sebagai sudah dibaca
+func (h *HealthAlertHandler) MarkHealthAlertRead(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	alertID, ok := parseAlertID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.healthAlertService.MarkHealthAlertRead(userID, alertID)
+	if err != nil {
+		handleAlertLifecycleError(c, err, "Gagal menandai alert sebagai dibaca")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Alert berhasil ditandai sebagai dibaca", resp)
+}
+
+// AcknowledgeHealthAlert menangani request untuk mengonfirmasi alert
+func (h *HealthAlertHandler) AcknowledgeHealthAlert(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	alertID, ok := parseAlertID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.healthAlertService.AcknowledgeHealthAlert(userID, alertID)
+	if err != nil {
+		handleAlertLifecycleError(c, err, "Gagal mengonfirmasi alert")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Alert berhasil dikonfirmasi", resp)
+}
+
+// ResolveHealthAlert menangani request untuk menyelesaikan alert dengan catatan opsional
+func (h *HealthAlertHandler) ResolveHealthAlert(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	alertID, ok := parseAlertID(c)
+	if !ok {
+		return
+	}
+
+	// Body bersifat opsional, request tanpa body tetap diterima
+	var req request.ResolveHealthAlertRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, "Data tidak valid", err.Error())
+			return
+		}
+	}
+
+	resp, err := h.healthAlertService.ResolveHealthAlert(userID, alertID, req.Note)
+	if err != nil {
+		handleAlertLifecycleError(c, err, "Gagal menyelesaikan alert")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Alert berhasil diselesaikan", resp)
+}
+
+// MarkAllHealthAlertsRead menangani request untuk menandai semua alert unread sebagai dibaca
+func (h *HealthAlertHandler) MarkAllHealthAlertsRead(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.healthAlertService.MarkAllHealthAlertsRead(userID)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal menandai semua alert sebagai dibaca", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Semua alert berhasil ditandai sebagai dibaca", resp)
+}
+
+// GetUnreadHealthAlertCount menangani request untuk mengambil jumlah alert yang belum dibaca
+func (h *HealthAlertHandler) GetUnreadHealthAlertCount(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.healthAlertService.GetUnreadHealthAlertCount(userID)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil jumlah alert yang belum dibaca", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Jumlah alert yang belum dibaca berhasil diambil", resp)
+}
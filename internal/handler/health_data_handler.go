@@ -2,17 +2,16 @@ package handler
 
 import (
 	"BE-PeriksaKesehatan/internal/model/dto/request"
-	"BE-PeriksaKesehatan/internal/model/dto/response"
 	"BE-PeriksaKesehatan/internal/repository"
 	"BE-PeriksaKesehatan/internal/service"
 	"BE-PeriksaKesehatan/pkg/middleware"
 	"BE-PeriksaKesehatan/pkg/utils"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
 )
 
 // HealthDataHandler menangani semua request terkait data kesehatan
@@ -39,8 +38,8 @@ func (h *HealthDataHandler) CreateHealthData(c *gin.Context) {
 		return
 	}
 
-	// Ambil user ID dari context (sudah divalidasi oleh middleware)
-	userID, ok := middleware.GetUserIDFromContext(c)
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -52,10 +51,7 @@ func (h *HealthDataHandler) CreateHealthData(c *gin.Context) {
 		// Cek apakah error adalah validasi (termasuk validasi nullable-aware)
 		// Error validasi biasanya dimulai dengan nama field atau "minimal"
 		errMsg := err.Error()
-		if strings.Contains(errMsg, "harus berada dalam range") ||
-			strings.Contains(errMsg, "wajib diisi") ||
-			strings.Contains(errMsg, "minimal satu") ||
-			strings.Contains(errMsg, "bersamaan") {
+		if isHealthDataValidationError(errMsg) {
 			utils.BadRequest(c, "Validasi gagal", errMsg)
 			return
 		}
@@ -67,11 +63,104 @@ func (h *HealthDataHandler) CreateHealthData(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusCreated, "Data kesehatan berhasil disimpan", resp)
 }
 
+// UpdateHealthData menangani request koreksi pembacaan data kesehatan (partial update)
+func (h *HealthDataHandler) UpdateHealthData(c *gin.Context) {
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	healthDataID, ok := parseHealthDataID(c)
+	if !ok {
+		return
+	}
+
+	var req request.HealthDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.healthDataService.UpdateHealthData(userID, healthDataID, &req)
+	if err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "tidak ditemukan") {
+			utils.NotFound(c, "Data kesehatan tidak ditemukan")
+			return
+		}
+		if isHealthDataValidationError(errMsg) {
+			utils.BadRequest(c, "Validasi gagal", errMsg)
+			return
+		}
+		utils.InternalServerError(c, "Gagal mengubah data kesehatan", errMsg)
+		return
+	}
+
+	// Response sukses
+	utils.SuccessResponse(c, http.StatusOK, "Data kesehatan berhasil diubah", resp)
+}
+
+// DeleteHealthData menangani request untuk menghapus pembacaan data kesehatan
+func (h *HealthDataHandler) DeleteHealthData(c *gin.Context) {
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	healthDataID, ok := parseHealthDataID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.healthDataService.DeleteHealthData(userID, healthDataID); err != nil {
+		if strings.Contains(err.Error(), "tidak ditemukan") {
+			utils.NotFound(c, "Data kesehatan tidak ditemukan")
+			return
+		}
+		utils.InternalServerError(c, "Gagal menghapus data kesehatan", err.Error())
+		return
+	}
+
+	// Response sukses
+	utils.SuccessResponse(c, http.StatusOK, "Data kesehatan berhasil dihapus", nil)
+}
+
+// parseHealthDataID mengambil dan memvalidasi parameter :id data kesehatan dari URL
+func parseHealthDataID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		utils.BadRequest(c, "ID data kesehatan tidak valid", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// isHealthDataValidationError mengecek apakah error berasal dari validasi input data kesehatan
+// Error validasi biasanya mengandung nama field atau kata "minimal"
+func isHealthDataValidationError(errMsg string) bool {
+	return strings.Contains(errMsg, "harus berada dalam range") ||
+		strings.Contains(errMsg, "wajib diisi") ||
+		strings.Contains(errMsg, "minimal satu") ||
+		strings.Contains(errMsg, "bersamaan") ||
+		strings.Contains(errMsg, "measured_at") ||
+		strings.Contains(errMsg, "blood_sugar_context") ||
+		strings.Contains(errMsg, "activity.") ||
+		strings.Contains(errMsg, "notes") ||
+		strings.Contains(errMsg, "symptoms") ||
+		strings.Contains(errMsg, "body_position") ||
+		strings.Contains(errMsg, "measurement_arm") ||
+		strings.Contains(errMsg, "measurement_context")
+}
+
 // GetHealthDataByUserID menangani request untuk mendapatkan data kesehatan terbaru user
-// Mengembalikan 1 record terbaru (inkremental) yang berisi semua data kesehatan user
+// Mengembalikan snapshot nilai terakhir setiap metrik dari seluruh pembacaan user
 func (h *HealthDataHandler) GetHealthDataByUserID(c *gin.Context) {
-	// Ambil user ID dari context (sudah divalidasi oleh middleware)
-	userID, ok := middleware.GetUserIDFromContext(c)
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -91,27 +180,71 @@ func (h *HealthDataHandler) GetHealthDataByUserID(c *gin.Context) {
 	}
 
 	// Build response dari entity ke response DTO
-	resp := &response.HealthDataResponse{
-		ID:         healthData.ID,
-		UserID:     healthData.UserID,
-		Systolic:   healthData.Systolic,
-		Diastolic:  healthData.Diastolic,
-		BloodSugar: healthData.BloodSugar,
-		Weight:     healthData.Weight,
-		Height:     healthData.HeightCM,
-		HeartRate:  healthData.HeartRate,
-		Activity:   healthData.Activity,
-		CreatedAt:  timezoneUtils.ToJakarta(healthData.CreatedAt),
-	}
+	resp := h.healthDataService.MapHealthDataToResponse(healthData)
 
 	// Response sukses
 	utils.SuccessResponse(c, http.StatusOK, "Data kesehatan berhasil diambil", resp)
 }
 
+// GetHealthReadings menangani request untuk mendapatkan semua pembacaan pada satu tanggal
+// Query parameter date (YYYY-MM-DD) opsional, default hari ini
+func (h *HealthDataHandler) GetHealthReadings(c *gin.Context) {
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.HealthReadingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Format tanggal tidak valid, gunakan YYYY-MM-DD", err.Error())
+		return
+	}
+
+	resp, err := h.healthDataService.GetHealthReadingsByDate(userID, req.Date)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil pembacaan data kesehatan", err.Error())
+		return
+	}
+
+	// Response sukses
+	utils.SuccessResponse(c, http.StatusOK, "Pembacaan data kesehatan berhasil diambil", resp)
+}
+
+// SearchReadings menangani request untuk mencari pembacaan berdasarkan catatan, gejala, atau konteks pengukuran
+func (h *HealthDataHandler) SearchReadings(c *gin.Context) {
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.ReadingSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.healthDataService.SearchReadings(userID, &req)
+	if err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "harus") || strings.Contains(errMsg, "tidak boleh") {
+			utils.BadRequest(c, "Validasi gagal", errMsg)
+			return
+		}
+		utils.InternalServerError(c, "Gagal mencari pembacaan data kesehatan", errMsg)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pencarian pembacaan berhasil", resp)
+}
+
 // GetHealthHistory menangani request untuk mendapatkan riwayat kesehatan dengan filter
 func (h *HealthDataHandler) GetHealthHistory(c *gin.Context) {
-	// Ambil user ID dari context (sudah divalidasi oleh middleware)
-	userID, ok := middleware.GetUserIDFromContext(c)
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -172,10 +305,11 @@ func (h *HealthDataHandler) GetHealthHistory(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Riwayat kesehatan berhasil diambil", apiResp)
 }
 
-// DownloadHealthReport menangani request untuk mengunduh laporan riwayat kesehatan dalam format PDF
+// DownloadHealthReport menangani request untuk mengunduh laporan riwayat kesehatan
+// Format laporan: query parameter format=pdf|csv|json, atau header Accept jika format tidak dikirim
 func (h *HealthDataHandler) DownloadHealthReport(c *gin.Context) {
-	// Ambil user ID dari context (sudah divalidasi oleh middleware)
-	userID, ok := middleware.GetUserIDFromContext(c)
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -193,22 +327,108 @@ func (h *HealthDataHandler) DownloadHealthReport(c *gin.Context) {
 		req.TimeRange = "7days"
 	}
 
-	// Generate laporan PDF
-	fileBuffer, filename, err := h.healthDataService.GenerateReportPDF(userID, &req)
+	// Tentukan format laporan
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = negotiateReportFormat(c)
+		if format == "" {
+			utils.ErrorResponse(c, http.StatusNotAcceptable, "Format laporan tidak didukung", "Accept harus application/pdf, text/csv, atau application/json")
+			return
+		}
+	} else if !service.IsValidReportFormat(format) {
+		utils.BadRequest(c, "Validasi gagal", "format harus salah satu dari pdf, csv, json")
+		return
+	}
+
+	// Generate laporan
+	fileBuffer, filename, contentType, err := h.healthDataService.GenerateReport(userID, &req, format)
 	if err != nil {
 		if err.Error() == "start_date dan end_date wajib diisi untuk custom range" {
 			utils.BadRequest(c, "Validasi gagal", err.Error())
 			return
 		}
-		utils.InternalServerError(c, "Gagal membuat laporan PDF", err.Error())
+		utils.InternalServerError(c, fmt.Sprintf("Gagal membuat laporan %s", strings.ToUpper(format)), err.Error())
 		return
 	}
 
-	// Set header untuk download PDF
-	c.Header("Content-Type", "application/pdf")
+	// Set header untuk download file
+	c.Header("Content-Type", contentType)
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 
 	// Kirim file
-	c.Data(http.StatusOK, "application/pdf", fileBuffer.Bytes())
+	c.Data(http.StatusOK, contentType, fileBuffer.Bytes())
+}
+
+// reportMediaTypes memetakan media type di header Accept ke format laporan
+var reportMediaTypes = map[string]string{
+	"application/pdf":  service.ReportFormatPDF,
+	"text/csv":         service.ReportFormatCSV,
+	"application/json": service.ReportFormatJSON,
 }
 
+// negotiateReportFormat menentukan format laporan dari header Accept dengan memperhatikan q-value.
+// Header Accept yang kosong menghasilkan PDF agar client lama tetap menerima PDF.
+// Tipe yang didukung dan disebut eksplisit didahulukan dari wildcard; di antara tipe eksplisit dipilih
+// q-value tertinggi (urutan header untuk q yang sama). Jika yang cocok hanya wildcard, "*/*" dan
+// "application/*" menghasilkan PDF dan "text/*" menghasilkan CSV. Tipe dengan q=0 diabaikan.
+// Mengembalikan string kosong jika Accept hanya berisi tipe yang tidak didukung.
+func negotiateReportFormat(c *gin.Context) string {
+	accept := strings.TrimSpace(c.GetHeader("Accept"))
+	if accept == "" {
+		return service.ReportFormatPDF
+	}
+
+	explicit, wildcard := "", ""
+	explicitQ, wildcardQ := 0.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, q := parseAcceptPart(part)
+		if q <= 0 {
+			continue
+		}
+
+		if format, ok := reportMediaTypes[mediaType]; ok {
+			if q > explicitQ {
+				explicit, explicitQ = format, q
+			}
+			continue
+		}
+
+		var format string
+		switch mediaType {
+		case "*/*", "application/*":
+			format = service.ReportFormatPDF
+		case "text/*":
+			format = service.ReportFormatCSV
+		default:
+			continue
+		}
+		if q > wildcardQ {
+			wildcard, wildcardQ = format, q
+		}
+	}
+
+	if explicit != "" {
+		return explicit
+	}
+	return wildcard
+}
+
+// parseAcceptPart mengambil media type (huruf kecil) dan q-value dari satu elemen header Accept
+// q-value yang tidak ada bernilai 1; q-value yang tidak valid dianggap 0
+func parseAcceptPart(part string) (string, float64) {
+	params := strings.Split(part, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+	q := 1.0
+	for _, param := range params[1:] {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.ToLower(strings.TrimSpace(key)) != "q" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			return mediaType, 0
+		}
+		q = parsed
+	}
+	return mediaType, q
+}
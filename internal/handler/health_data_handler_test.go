@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNegotiateReportFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"accept kosong memakai PDF", "", service.ReportFormatPDF},
+		{"hanya wildcard memakai PDF", "*/*", service.ReportFormatPDF},
+		{"PDF eksplisit", "application/pdf", service.ReportFormatPDF},
+		{"CSV eksplisit", "text/csv", service.ReportFormatCSV},
+		{"JSON eksplisit", "application/json", service.ReportFormatJSON},
+		{"CSV didahulukan dari wildcard", "text/csv, */*;q=0.1", service.ReportFormatCSV},
+		{"JSON didahulukan dari wildcard", "application/json, text/plain, */*", service.ReportFormatJSON},
+		{"tipe tidak didukung dengan wildcard memakai PDF", "application/xml, */*;q=0.8", service.ReportFormatPDF},
+		{"tipe tidak didukung", "application/xml", ""},
+		{"wildcard di depan tidak mengalahkan tipe eksplisit", "*/*, text/csv", service.ReportFormatCSV},
+		{"q-value tertinggi dipilih", "application/pdf;q=0.1, text/csv", service.ReportFormatCSV},
+		{"q-value sama memakai urutan header", "application/json, text/csv", service.ReportFormatJSON},
+		{"q=0 berarti tidak diterima", "text/csv;q=0, */*", service.ReportFormatPDF},
+		{"wildcard text memakai CSV", "text/*", service.ReportFormatCSV},
+		{"semua q=0", "text/csv;q=0", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/health/history/download", nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
+
+			if got := negotiateReportFormat(c); got != tt.want {
+				t.Errorf("negotiateReportFormat(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler menangani endpoint health dan riwayat eksekusi job background
+type JobHandler struct {
+	jobRunner *service.JobRunner
+}
+
+// NewJobHandler membuat instance baru dari JobHandler
+func NewJobHandler(jobRunner *service.JobRunner) *JobHandler {
+	return &JobHandler{
+		jobRunner: jobRunner,
+	}
+}
+
+// GetHealth menangani request health check (publik, untuk load balancer dan monitoring)
+// Mengembalikan 503 jika database tidak dapat diakses. Job yang gagal atau terlambat hanya menandai
+// status "degraded" (tetap 200) agar instance tidak dikeluarkan dari load balancer karena job maintenance.
+func (h *JobHandler) GetHealth(c *gin.Context) {
+	resp, err := h.jobRunner.GetHealth()
+	if err != nil {
+		// Detail error hanya ditulis ke log karena endpoint ini publik
+		log.Printf("Warning: Health check gagal: %v", err)
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Database tidak dapat diakses", gin.H{
+			"status":   "unavailable",
+			"database": "unavailable",
+		})
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Status layanan berhasil diambil", resp)
+}
+
+// GetJobRuns menangani request untuk mengambil riwayat eksekusi job background (admin)
+// Mendukung filter job, status, dan pagination (page, limit)
+func (h *JobHandler) GetJobRuns(c *gin.Context) {
+	var req request.AdminJobRunListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.jobRunner.GetJobRuns(&req)
+	if err != nil {
+		if strings.Contains(err.Error(), "harus salah satu") {
+			utils.BadRequest(c, "Validasi gagal", err.Error())
+			return
+		}
+		utils.InternalServerError(c, "Gagal mengambil riwayat job", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Riwayat job berhasil diambil", resp)
+}
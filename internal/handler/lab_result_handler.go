@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/middleware"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LabResultHandler menangani semua request terkait hasil pemeriksaan laboratorium
+type LabResultHandler struct {
+	labResultService *service.LabResultService
+}
+
+// NewLabResultHandler membuat instance baru dari LabResultHandler
+func NewLabResultHandler(labResultService *service.LabResultService) *LabResultHandler {
+	return &LabResultHandler{
+		labResultService: labResultService,
+	}
+}
+
+// GetLabTestTypes menangani request untuk mengambil daftar jenis pemeriksaan yang didukung
+// Rentang rujukan mengikuti batas klinis yang berlaku untuk pemilik data
+func (h *LabResultHandler) GetLabTestTypes(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	types, err := h.labResultService.GetLabTestTypes(userID)
+	if err != nil {
+		utils.InternalServerError(c, "Gagal mengambil jenis pemeriksaan lab", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Jenis pemeriksaan lab berhasil diambil", types)
+}
+
+// CreateLabResult menangani request untuk mencatat hasil laboratorium
+func (h *LabResultHandler) CreateLabResult(c *gin.Context) {
+	var req request.CreateLabResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.labResultService.CreateLabResult(userID, &req)
+	if err != nil {
+		h.handleLabResultError(c, err, "Gagal menyimpan hasil lab")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Hasil lab berhasil disimpan", resp)
+}
+
+// GetLabResults menangani request untuk mengambil riwayat hasil laboratorium
+// Mendukung filter test_type, start_date, end_date dan pagination (page, limit)
+func (h *LabResultHandler) GetLabResults(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.LabResultListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.labResultService.GetLabResults(userID, &req)
+	if err != nil {
+		h.handleLabResultError(c, err, "Gagal mengambil riwayat hasil lab")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Riwayat hasil lab berhasil diambil", resp)
+}
+
+// GetLabResultTrends menangani request untuk mengambil grafik tren hasil laboratorium
+func (h *LabResultHandler) GetLabResultTrends(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.LabResultTrendRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.labResultService.GetLabResultTrends(userID, &req)
+	if err != nil {
+		h.handleLabResultError(c, err, "Gagal mengambil tren hasil lab")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tren hasil lab berhasil diambil", resp)
+}
+
+// UpdateLabResult menangani request koreksi hasil laboratorium (partial update)
+func (h *LabResultHandler) UpdateLabResult(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	labResultID, ok := parseLabResultID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateLabResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.labResultService.UpdateLabResult(userID, labResultID, &req)
+	if err != nil {
+		h.handleLabResultError(c, err, "Gagal mengubah hasil lab")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Hasil lab berhasil diubah", resp)
+}
+
+// DeleteLabResult menangani request untuk menghapus hasil laboratorium
+func (h *LabResultHandler) DeleteLabResult(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	labResultID, ok := parseLabResultID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.labResultService.DeleteLabResult(userID, labResultID); err != nil {
+		h.handleLabResultError(c, err, "Gagal menghapus hasil lab")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Hasil lab berhasil dihapus", nil)
+}
+
+// handleLabResultError memetakan error service hasil lab ke response HTTP
+func (h *LabResultHandler) handleLabResultError(c *gin.Context, err error, fallbackMessage string) {
+	errMsg := err.Error()
+	switch {
+	case strings.Contains(errMsg, "tidak ditemukan"):
+		utils.NotFound(c, "Hasil lab tidak ditemukan")
+	case strings.Contains(errMsg, "harus"), strings.Contains(errMsg, "tidak boleh"):
+		utils.BadRequest(c, "Validasi gagal", errMsg)
+	default:
+		utils.InternalServerError(c, fallbackMessage, errMsg)
+	}
+}
+
+// parseLabResultID mengambil dan memvalidasi parameter :id hasil lab dari URL
+func parseLabResultID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		utils.BadRequest(c, "ID hasil lab tidak valid", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/middleware"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MedicationHandler menangani semua request terkait obat, log minum obat, dan kepatuhan
+type MedicationHandler struct {
+	medicationService *service.MedicationService
+}
+
+// NewMedicationHandler membuat instance baru dari MedicationHandler
+func NewMedicationHandler(medicationService *service.MedicationService) *MedicationHandler {
+	return &MedicationHandler{
+		medicationService: medicationService,
+	}
+}
+
+// CreateMedication menangani request untuk menambahkan obat
+func (h *MedicationHandler) CreateMedication(c *gin.Context) {
+	var req request.CreateMedicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	// Ambil ID pemilik data dari context (user sendiri atau pasien yang diakses caregiver)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.medicationService.CreateMedication(userID, &req)
+	if err != nil {
+		h.handleMedicationError(c, err, "Gagal menyimpan obat")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Obat berhasil disimpan", resp)
+}
+
+// GetMedications menangani request untuk mengambil daftar obat
+// Mendukung filter is_active dan pagination (page, limit)
+func (h *MedicationHandler) GetMedications(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.MedicationListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.medicationService.GetMedications(userID, &req)
+	if err != nil {
+		h.handleMedicationError(c, err, "Gagal mengambil daftar obat")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Daftar obat berhasil diambil", resp)
+}
+
+// UpdateMedication menangani request untuk mengubah obat (partial update)
+func (h *MedicationHandler) UpdateMedication(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	medicationID, ok := parseMedicationID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateMedicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.medicationService.UpdateMedication(userID, medicationID, &req)
+	if err != nil {
+		h.handleMedicationError(c, err, "Gagal mengubah obat")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Obat berhasil diubah", resp)
+}
+
+// DeleteMedication menangani request untuk menghapus obat beserta log minum obatnya
+func (h *MedicationHandler) DeleteMedication(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	medicationID, ok := parseMedicationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.medicationService.DeleteMedication(userID, medicationID); err != nil {
+		h.handleMedicationError(c, err, "Gagal menghapus obat")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Obat berhasil dihapus", nil)
+}
+
+// LogDose menangani request untuk mencatat dosis obat diminum atau terlewat
+func (h *MedicationHandler) LogDose(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	medicationID, ok := parseMedicationID(c)
+	if !ok {
+		return
+	}
+
+	var req request.LogMedicationDoseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.medicationService.LogDose(userID, medicationID, &req)
+	if err != nil {
+		h.handleMedicationError(c, err, "Gagal mencatat minum obat")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Minum obat berhasil dicatat", resp)
+}
+
+// GetMedicationLogs menangani request untuk mengambil riwayat minum satu obat
+func (h *MedicationHandler) GetMedicationLogs(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	medicationID, ok := parseMedicationID(c)
+	if !ok {
+		return
+	}
+
+	var req request.MedicationLogListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.medicationService.GetMedicationLogs(userID, medicationID, &req)
+	if err != nil {
+		h.handleMedicationError(c, err, "Gagal mengambil riwayat minum obat")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Riwayat minum obat berhasil diambil", resp)
+}
+
+// GetDailySchedule menangani request untuk mengambil jadwal minum obat harian
+func (h *MedicationHandler) GetDailySchedule(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.MedicationScheduleRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.medicationService.GetDailySchedule(userID, &req)
+	if err != nil {
+		h.handleMedicationError(c, err, "Gagal mengambil jadwal minum obat")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Jadwal minum obat berhasil diambil", resp)
+}
+
+// GetAdherence menangani request untuk mengambil persentase kepatuhan minum obat
+// Mendukung time_range yang sama dengan riwayat kesehatan (7days, 30days, 3months, custom)
+func (h *MedicationHandler) GetAdherence(c *gin.Context) {
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.MedicationAdherenceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.medicationService.GetAdherence(userID, &req)
+	if err != nil {
+		h.handleMedicationError(c, err, "Gagal menghitung kepatuhan minum obat")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Kepatuhan minum obat berhasil diambil", resp)
+}
+
+// handleMedicationError memetakan error service obat ke response HTTP
+func (h *MedicationHandler) handleMedicationError(c *gin.Context, err error, fallbackMessage string) {
+	errMsg := err.Error()
+	switch {
+	case strings.Contains(errMsg, "tidak ditemukan"):
+		utils.NotFound(c, "Obat tidak ditemukan")
+	case strings.Contains(errMsg, "harus"), strings.Contains(errMsg, "tidak boleh"), strings.Contains(errMsg, "wajib"):
+		utils.BadRequest(c, "Validasi gagal", errMsg)
+	default:
+		utils.InternalServerError(c, fallbackMessage, errMsg)
+	}
+}
+
+// parseMedicationID mengambil dan memvalidasi parameter :id obat dari URL
+func parseMedicationID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		utils.BadRequest(c, "ID obat tidak valid", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/middleware"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler menangani semua request terkait inbox notifikasi in-app
+type NotificationHandler struct {
+	notificationService *service.NotificationService
+}
+
+// NewNotificationHandler membuat instance baru dari NotificationHandler
+func NewNotificationHandler(notificationService *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications menangani request untuk mengambil inbox notifikasi user
+// Mendukung filter unread_only, type, dan pagination (page, limit)
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	var req request.NotificationListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequest(c, "Query parameter tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.notificationService.GetNotifications(userID, &req)
+	if err != nil {
+		h.handleNotificationError(c, err, "Gagal mengambil notifikasi")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notifikasi berhasil diambil", resp)
+}
+
+// GetUnreadNotificationCount menangani request untuk mengambil jumlah notifikasi yang belum dibaca
+func (h *NotificationHandler) GetUnreadNotificationCount(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.notificationService.GetUnreadNotificationCount(userID)
+	if err != nil {
+		h.handleNotificationError(c, err, "Gagal mengambil jumlah notifikasi belum dibaca")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Jumlah notifikasi belum dibaca berhasil diambil", resp)
+}
+
+// MarkNotificationRead menangani request untuk menandai satu notifikasi sebagai sudah dibaca
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	notificationID, ok := parseNotificationID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.notificationService.MarkNotificationRead(userID, notificationID)
+	if err != nil {
+		h.handleNotificationError(c, err, "Gagal menandai notifikasi sebagai dibaca")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notifikasi ditandai sudah dibaca", resp)
+}
+
+// MarkAllNotificationsRead menangani request untuk menandai semua notifikasi sebagai sudah dibaca
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	resp, err := h.notificationService.MarkAllNotificationsRead(userID)
+	if err != nil {
+		h.handleNotificationError(c, err, "Gagal menandai semua notifikasi sebagai dibaca")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Semua notifikasi ditandai sudah dibaca", resp)
+}
+
+// DeleteNotification menangani request untuk menghapus notifikasi dari inbox
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+		return
+	}
+
+	notificationID, ok := parseNotificationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationService.DeleteNotification(userID, notificationID); err != nil {
+		h.handleNotificationError(c, err, "Gagal menghapus notifikasi")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notifikasi berhasil dihapus", nil)
+}
+
+// handleNotificationError memetakan error service notifikasi ke response HTTP
+func (h *NotificationHandler) handleNotificationError(c *gin.Context, err error, fallbackMessage string) {
+	errMsg := err.Error()
+	switch {
+	case strings.Contains(errMsg, "tidak ditemukan"):
+		utils.NotFound(c, "Notifikasi tidak ditemukan")
+	case strings.Contains(errMsg, "harus"), strings.Contains(errMsg, "tidak boleh"):
+		utils.BadRequest(c, "Validasi gagal", errMsg)
+	default:
+		utils.InternalServerError(c, fallbackMessage, errMsg)
+	}
+}
+
+// parseNotificationID mengambil dan memvalidasi parameter :id notifikasi dari URL
+func parseNotificationID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		utils.BadRequest(c, "ID notifikasi tidak valid", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -24,7 +24,7 @@ func NewProfileHandler(
 }
 
 func (h *ProfileHandler) GetProfile(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -78,7 +78,7 @@ func (h *ProfileHandler) GetProfile(c *gin.Context) {
 }
 
 func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -195,7 +195,7 @@ func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 }
 
 func (h *ProfileHandler) CreatePersonalInfo(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -308,7 +308,7 @@ func (h *ProfileHandler) CreatePersonalInfo(c *gin.Context) {
 }
 
 func (h *ProfileHandler) UpdatePersonalInfo(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -479,7 +479,7 @@ func (h *ProfileHandler) UpdatePersonalInfo(c *gin.Context) {
 }
 
 func (h *ProfileHandler) GetHealthTargets(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -495,7 +495,7 @@ func (h *ProfileHandler) GetHealthTargets(c *gin.Context) {
 }
 
 func (h *ProfileHandler) CreateHealthTargets(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -525,7 +525,7 @@ func (h *ProfileHandler) CreateHealthTargets(c *gin.Context) {
 }
 
 func (h *ProfileHandler) UpdateHealthTargets(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -552,7 +552,7 @@ func (h *ProfileHandler) UpdateHealthTargets(c *gin.Context) {
 }
 
 func (h *ProfileHandler) GetSettings(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -572,7 +572,7 @@ func (h *ProfileHandler) GetSettings(c *gin.Context) {
 }
 
 func (h *ProfileHandler) UpdateSettings(c *gin.Context) {
-	userID, ok := middleware.GetUserIDFromContext(c)
+	userID, ok := middleware.GetTargetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
 		return
@@ -2,15 +2,51 @@ package handler
 
 import (
 	"BE-PeriksaKesehatan/config"
+	"BE-PeriksaKesehatan/internal/model/entity"
 	"BE-PeriksaKesehatan/internal/repository"
 	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/mailer"
 	"BE-PeriksaKesehatan/pkg/middleware"
+	"log"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRouter(cfg *config.Config, userRepo *repository.UserRepository) *gin.Engine {
+// trustedPlatforms memetakan nilai TRUSTED_PLATFORM ke header IP client yang diisi platform tersebut
+var trustedPlatforms = map[string]string{
+	"cloudflare":        gin.PlatformCloudflare,
+	"google_app_engine": gin.PlatformGoogleAppEngine,
+	"flyio":             gin.PlatformFlyIO,
+}
+
+// configureClientIP mengatur sumber IP client (c.ClientIP) yang dipakai pembatasan percobaan per IP dan sesi login.
+// Secara default gin mempercayai X-Forwarded-For dari semua proxy sehingga IP bisa dipalsukan;
+// di sini hanya proxy pada TRUSTED_PROXIES yang dipercaya (default tidak ada).
+func configureClientIP(router *gin.Engine, cfg *config.Config) {
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Printf("Warning: TRUSTED_PROXIES tidak valid (%v), tidak ada proxy yang dipercaya", err)
+		if err := router.SetTrustedProxies(nil); err != nil {
+			log.Printf("Warning: Gagal menonaktifkan trusted proxy: %v", err)
+		}
+	}
+
+	if cfg.TrustedPlatform == "" {
+		return
+	}
+	header, ok := trustedPlatforms[strings.ToLower(strings.TrimSpace(cfg.TrustedPlatform))]
+	if !ok {
+		log.Printf("Warning: TRUSTED_PLATFORM tidak dikenal (%s), harus salah satu dari cloudflare, google_app_engine, flyio", cfg.TrustedPlatform)
+		return
+	}
+	router.TrustedPlatform = header
+}
+
+// SetupRouter menyusun semua route API
+// jobRunner dipakai endpoint health dan riwayat job; job-nya dijalankan terpisah oleh cmd/api
+func SetupRouter(cfg *config.Config, userRepo *repository.UserRepository, jobRunner *service.JobRunner) *gin.Engine {
 	router := gin.Default()
+	configureClientIP(router, cfg)
 
 	healthDataRepo := repository.NewHealthDataRepository(userRepo.GetDB())
 	authRepo := repository.NewAuthRepository(userRepo.GetDB())
@@ -19,51 +55,170 @@ func SetupRouter(cfg *config.Config, userRepo *repository.UserRepository) *gin.E
 	categoryRepo := repository.NewCategoryRepository(userRepo.GetDB())
 	healthTargetRepo := repository.NewHealthTargetRepository(userRepo.GetDB())
 	personalInfoRepo := repository.NewPersonalInfoRepository(userRepo.GetDB())
+	throttleRepo := repository.NewThrottleRepository(userRepo.GetDB())
+	careGrantRepo := repository.NewCareGrantRepository(userRepo.GetDB())
+	clinicianRepo := repository.NewClinicianRepository(userRepo.GetDB())
+	clinicalThresholdRepo := repository.NewClinicalThresholdRepository(userRepo.GetDB())
+	labResultRepo := repository.NewLabResultRepository(userRepo.GetDB())
+	medicationRepo := repository.NewMedicationRepository(userRepo.GetDB())
+	notificationRepo := repository.NewNotificationRepository(userRepo.GetDB())
+
+	notifier, err := service.NewNotifier(cfg.NotifierDriver, notificationRepo)
+	if err != nil {
+		log.Printf("Warning: Gagal menginisialisasi notifier (%v), notifikasi hanya ditulis ke log", err)
+		notifier = service.NewLogNotifier()
+	}
+	notificationService := service.NewNotificationService(notificationRepo, userRepo, notifier)
 
-	healthDataService := service.NewHealthDataService(healthDataRepo, personalInfoRepo)
-	healthAlertService := service.NewHealthAlertService(healthAlertRepo, healthDataRepo, educationalVideoRepo, categoryRepo)
+	clinicalThresholdService := service.NewClinicalThresholdService(clinicalThresholdRepo, clinicianRepo, userRepo)
+	healthAlertService := service.NewHealthAlertService(healthAlertRepo, healthDataRepo, educationalVideoRepo, categoryRepo, clinicalThresholdService, notificationService)
+	labResultService := service.NewLabResultService(labResultRepo, clinicalThresholdService)
+	medicationService := service.NewMedicationService(medicationRepo)
+	healthDataService := service.NewHealthDataService(healthDataRepo, personalInfoRepo, healthAlertService, clinicalThresholdService, labResultService, medicationService, healthTargetRepo, notificationService)
 	educationalVideoService := service.NewEducationalVideoService(educationalVideoRepo, categoryRepo)
+	categoryService := service.NewCategoryService(categoryRepo)
 	profileService := service.NewProfileService(userRepo, healthDataRepo, healthTargetRepo, personalInfoRepo)
+	adminService := service.NewAdminService(userRepo, authRepo)
+	throttleService := service.NewThrottleService(throttleRepo)
+	careService := service.NewCareService(careGrantRepo, clinicianRepo, userRepo)
+	clinicianService := service.NewClinicianService(clinicianRepo, userRepo, healthDataRepo, healthAlertRepo, profileService, clinicalThresholdService)
+	sessionService := service.NewSessionService(authRepo, userRepo, cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+
+	mailSender, err := mailer.New(mailer.Config{
+		Driver:       cfg.MailDriver,
+		From:         cfg.MailFrom,
+		FileDir:      cfg.MailFileDir,
+		SMTPHost:     cfg.SMTPHost,
+		SMTPPort:     cfg.SMTPPort,
+		SMTPUsername: cfg.SMTPUsername,
+		SMTPPassword: cfg.SMTPPassword,
+	})
+	if err != nil {
+		log.Printf("Warning: Gagal menginisialisasi mail sender (%v), email hanya ditulis ke log", err)
+		mailSender = mailer.NewLogSender(cfg.MailFrom)
+	}
+	passwordService := service.NewPasswordService(userRepo, authRepo, throttleService, mailSender, cfg.PasswordResetTTL, cfg.PasswordResetURL)
+	emailVerificationService := service.NewEmailVerificationService(userRepo, authRepo, mailSender, cfg.EmailVerificationTTL, cfg.EmailVerificationURL)
 
 	// Initialize middleware
 	authMiddleware := middleware.AuthMiddleware(authRepo, cfg.JWTSecret)
+	adminOnly := middleware.RequireRoles(userRepo, entity.RoleAdmin)
+	clinicianOnly := middleware.RequireRoles(userRepo, entity.RoleClinician)
+	verifiedOnly := middleware.RequireVerifiedEmail(userRepo, cfg.RequireEmailVerification)
+	// Caregiver dan clinician dapat mengakses data pasien dengan ?patient_id=<id> sesuai scope akses
+	healthCareAccess := middleware.CareAccess(careService, true)
+	profileCareAccess := middleware.CareAccess(careService, false)
 
-	authHandler := NewAuthHandler(userRepo, cfg.JWTSecret)
+	authHandler := NewAuthHandler(userRepo, sessionService, passwordService, emailVerificationService, throttleService, cfg.JWTSecret)
 	healthDataHandler := NewHealthDataHandler(healthDataService, authRepo)
 	healthAlertHandler := NewHealthAlertHandler(healthAlertService, authRepo)
 	educationalVideoHandler := NewEducationalVideoHandler(educationalVideoService)
+	categoryHandler := NewCategoryHandler(categoryService)
 	profileHandler := NewProfileHandler(profileService)
+	adminHandler := NewAdminHandler(adminService, throttleService, clinicianService)
+	clinicianHandler := NewClinicianHandler(clinicianService)
+	clinicalThresholdHandler := NewClinicalThresholdHandler(clinicalThresholdService)
+	careHandler := NewCareHandler(careService)
+	labResultHandler := NewLabResultHandler(labResultService)
+	medicationHandler := NewMedicationHandler(medicationService)
+	notificationHandler := NewNotificationHandler(notificationService)
+	jobHandler := NewJobHandler(jobRunner)
+
+	// Health check publik (di luar /api) untuk load balancer dan monitoring
+	router.GET("/health", jobHandler.GetHealth)
 
 	api := router.Group("/api")
 	{
 		// Public routes (no auth required)
 		auth := api.Group("/auth")
 		{
-			auth.POST("/register", authHandler.Register)
-			auth.POST("/login", authHandler.Login)
+			auth.POST("/register", middleware.RateLimit(throttleService, service.ThrottleActionRegister), authHandler.Register)
+			auth.POST("/login", middleware.RateLimit(throttleService, service.ThrottleActionLogin), authHandler.Login)
 			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/forgot-password", middleware.RateLimit(throttleService, service.ThrottleActionPasswordReset), authHandler.ForgotPassword)
+			auth.POST("/reset-password", middleware.RateLimit(throttleService, service.ThrottleActionPasswordReset), authHandler.ResetPassword)
+			auth.PUT("/change-password", authMiddleware, authHandler.ChangePassword)
+			auth.POST("/verify-email", authHandler.VerifyEmail)
+			auth.POST("/resend-verification", authMiddleware, authHandler.ResendVerificationEmail)
+
+			// Manajemen sesi login (perlu access token)
+			sessions := auth.Group("/sessions", authMiddleware)
+			{
+				sessions.GET("", authHandler.GetSessions)
+				sessions.DELETE("", authHandler.RevokeAllSessions)
+				sessions.DELETE("/:id", authHandler.RevokeSession)
+			}
 		}
 
 		// Protected routes (require auth)
 		health := api.Group("/health")
-		health.Use(authMiddleware)
+		health.Use(authMiddleware, verifiedOnly, healthCareAccess)
 		{
 			health.POST("/data", healthDataHandler.CreateHealthData)
 			health.GET("/data", healthDataHandler.GetHealthDataByUserID)
+			health.PUT("/data/:id", healthDataHandler.UpdateHealthData)
+			health.DELETE("/data/:id", healthDataHandler.DeleteHealthData)
+			health.GET("/readings", healthDataHandler.GetHealthReadings)
+			health.GET("/readings/search", healthDataHandler.SearchReadings)
 			health.GET("/history", healthDataHandler.GetHealthHistory)
 			health.GET("/history/download", healthDataHandler.DownloadHealthReport)
 			health.GET("/check-health-alerts", healthAlertHandler.CheckHealthAlerts)
+			health.GET("/alerts", healthAlertHandler.GetHealthAlertHistory)
+			health.GET("/alerts/unread-count", healthAlertHandler.GetUnreadHealthAlertCount)
+			health.PUT("/alerts/read-all", healthAlertHandler.MarkAllHealthAlertsRead)
+			health.PUT("/alerts/:id/read", healthAlertHandler.MarkHealthAlertRead)
+			health.PUT("/alerts/:id/acknowledge", healthAlertHandler.AcknowledgeHealthAlert)
+			health.PUT("/alerts/:id/resolve", healthAlertHandler.ResolveHealthAlert)
+			health.GET("/thresholds", clinicalThresholdHandler.GetThresholds)
+			health.GET("/lab-results/types", labResultHandler.GetLabTestTypes)
+			health.GET("/lab-results/trends", labResultHandler.GetLabResultTrends)
+			health.POST("/lab-results", labResultHandler.CreateLabResult)
+			health.GET("/lab-results", labResultHandler.GetLabResults)
+			health.PUT("/lab-results/:id", labResultHandler.UpdateLabResult)
+			health.DELETE("/lab-results/:id", labResultHandler.DeleteLabResult)
+			health.GET("/medications/schedule", medicationHandler.GetDailySchedule)
+			health.GET("/medications/adherence", medicationHandler.GetAdherence)
+			health.POST("/medications", medicationHandler.CreateMedication)
+			health.GET("/medications", medicationHandler.GetMedications)
+			health.PUT("/medications/:id", medicationHandler.UpdateMedication)
+			health.DELETE("/medications/:id", medicationHandler.DeleteMedication)
+			health.POST("/medications/:id/logs", medicationHandler.LogDose)
+			health.GET("/medications/:id/logs", medicationHandler.GetMedicationLogs)
+		}
+
+		// Inbox notifikasi in-app milik user yang login
+		notifications := api.Group("/notifications")
+		notifications.Use(authMiddleware, verifiedOnly)
+		{
+			notifications.GET("", notificationHandler.GetNotifications)
+			notifications.GET("/unread-count", notificationHandler.GetUnreadNotificationCount)
+			notifications.PUT("/read-all", notificationHandler.MarkAllNotificationsRead)
+			notifications.PUT("/:id/read", notificationHandler.MarkNotificationRead)
+			notifications.DELETE("/:id", notificationHandler.DeleteNotification)
 		}
 
 		education := api.Group("/education")
 		{
-			education.POST("/add-educational-video", educationalVideoHandler.AddEducationalVideo)
+			// Public: daftar dan detail video edukasi
 			education.GET("/get-educational-videos", educationalVideoHandler.GetAllEducationalVideos)
 			education.GET("/get-educational-videos/:id", educationalVideoHandler.GetEducationalVideosByID)
+			education.GET("/get-categories", categoryHandler.GetCategories)
+
+			// Admin only: manajemen konten edukasi
+			educationAdmin := education.Group("", authMiddleware, adminOnly)
+			{
+				educationAdmin.POST("/add-educational-video", educationalVideoHandler.AddEducationalVideo)
+				educationAdmin.PUT("/update-educational-video/:id", educationalVideoHandler.UpdateEducationalVideo)
+				educationAdmin.DELETE("/delete-educational-video/:id", educationalVideoHandler.DeleteEducationalVideo)
+				educationAdmin.POST("/add-category", categoryHandler.AddCategory)
+				educationAdmin.PUT("/update-category/:id", categoryHandler.UpdateCategory)
+				educationAdmin.DELETE("/delete-category/:id", categoryHandler.DeleteCategory)
+			}
 		}
 
 		profile := api.Group("/profile")
-		profile.Use(authMiddleware)
+		profile.Use(authMiddleware, verifiedOnly, profileCareAccess)
 		{
 			// Single source of truth untuk data profil user (personal info)
 			profile.GET("", profileHandler.GetProfile)
@@ -77,6 +232,47 @@ func SetupRouter(cfg *config.Config, userRepo *repository.UserRepository) *gin.E
 			profile.GET("/settings", profileHandler.GetSettings)
 			profile.PUT("/settings", profileHandler.UpdateSettings)
 		}
+
+		// Akses caregiver/keluarga ke data kesehatan pasien
+		care := api.Group("/care")
+		care.Use(authMiddleware, verifiedOnly)
+		{
+			// Sisi pasien: kelola caregiver yang diberi akses
+			care.POST("/grants", careHandler.InviteCaregiver)
+			care.GET("/grants", careHandler.GetGrantedAccess)
+			care.PUT("/grants/:id", careHandler.UpdateGrantScope)
+			care.DELETE("/grants/:id", careHandler.RevokeGrant)
+
+			// Sisi caregiver: undangan dan pasien yang memberi akses
+			care.GET("/patients", careHandler.GetReceivedAccess)
+			care.PUT("/invitations/:id/accept", careHandler.AcceptInvitation)
+			care.PUT("/invitations/:id/decline", careHandler.DeclineInvitation)
+		}
+
+		// Dashboard clinician untuk pasien yang terhubung
+		clinician := api.Group("/clinician")
+		clinician.Use(authMiddleware, clinicianOnly)
+		{
+			clinician.GET("/dashboard", clinicianHandler.GetDashboard)
+			clinician.PUT("/patients/:id/thresholds", clinicalThresholdHandler.UpdatePatientThresholds)
+			clinician.DELETE("/patients/:id/thresholds", clinicalThresholdHandler.ResetPatientThresholds)
+		}
+
+		admin := api.Group("/admin")
+		admin.Use(authMiddleware, adminOnly)
+		{
+			admin.GET("/users", adminHandler.GetUsers)
+			admin.PUT("/users/:id/role", adminHandler.UpdateUserRole)
+			admin.GET("/lockouts", adminHandler.GetLockouts)
+			admin.DELETE("/lockouts/:id", adminHandler.UnlockLockout)
+			admin.GET("/auth-audit-logs", adminHandler.GetAuthAuditLogs)
+			admin.GET("/thresholds", clinicalThresholdHandler.GetDefaultThresholds)
+			admin.PUT("/thresholds", clinicalThresholdHandler.UpdateDefaultThresholds)
+			admin.GET("/clinicians/:id/patients", adminHandler.GetClinicianPatients)
+			admin.POST("/clinicians/:id/patients", adminHandler.AssignPatientToClinician)
+			admin.DELETE("/clinicians/:id/patients/:patientId", adminHandler.UnassignPatientFromClinician)
+			admin.GET("/jobs/runs", jobHandler.GetJobRuns)
+		}
 	}
 
 	return router
@@ -0,0 +1,553 @@
+package migration
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// defaultSchema adalah schema PostgreSQL tempat tabel aplikasi dibuat
+const defaultSchema = "public"
+
+// baselineMigration adalah skema database sebelum migration berversi diperkenalkan:
+// AutoMigrate salinan entity yang dibekukan ditambah migration manual yang sebelumnya dijalankan setiap startup.
+// Seluruh langkah idempoten sehingga database lama yang sudah berisi skema ini cukup ditandai versi 1,
+// dan dijalankan tanpa transaksi agar langkah non-kritis yang gagal (mis. index) tidak membatalkan langkah lain.
+// Baseline tidak dapat di-rollback.
+var baselineMigration = Migration{
+	Version:       1,
+	Name:          "baseline",
+	NoTransaction: true,
+	Up:            baselineUp,
+}
+
+// baselineUp menjalankan skema baseline secara berurutan
+func baselineUp(db *gorm.DB) error {
+	steps := []struct {
+		name string
+		run  func(db *gorm.DB) error
+	}{
+		// Harus sebelum AutoMigrate: user lama perlu ditandai terverifikasi saat kolom baru ditambahkan
+		{"migrate users email verification", migrateUserEmailVerification},
+		// AutoMigrate untuk create tables dan add columns
+		{"auto-migrate", autoMigrateTables},
+		// Manual migrations untuk alter constraints
+		{"migrate health_data nullable", migrateHealthDataNullable},
+		{"migrate health_data daily record", migrateHealthDataDailyRecord},
+		{"migrate care_grants", migrateCareGrantsTable},
+		{"migrate health_data measured_at", migrateHealthDataMeasuredAt},
+		{"migrate educational_videos", migrateEducationalVideosTable},
+		{"migrate personal_infos", migratePersonalInfosTable},
+		{"migrate remove user columns", migrateRemoveUserColumns},
+	}
+
+	for _, step := range steps {
+		if err := step.run(db); err != nil {
+			return fmt.Errorf("%s: %w", step.name, err)
+		}
+	}
+	return nil
+}
+
+// baselineModels mengembalikan salinan entity baseline (0001_baseline_schema.go) yang dibuat oleh AutoMigrate.
+// Tabel atau kolom baru setelah baseline ditambahkan lewat migration berversi, bukan ke daftar ini.
+func baselineModels() []interface{} {
+	return []interface{}{
+		&baselineUser{},
+		&baselineHealthData{},
+		&baselineBlacklistedToken{},
+		&baselineUserSession{},
+		&baselinePasswordResetToken{},
+		&baselineEmailVerificationToken{},
+		&baselineAuthThrottle{},
+		&baselineAuthAuditLog{},
+		&baselineCareGrant{},
+		&baselineClinicianPatient{},
+		&baselineClinicalThreshold{},
+		&baselineHealthAlert{},
+		&baselineCategory{},
+		&baselineEducationalVideo{},
+		&baselineEducationalVideoCategory{},
+		&baselineHealthTarget{},
+		&baselinePersonalInfo{},
+		&baselineLabResult{},
+		&baselineMedication{},
+		&baselineMedicationLog{},
+		&baselineNotification{},
+	}
+}
+
+// autoMigrateTables menjalankan GORM AutoMigrate untuk salinan entity baseline
+func autoMigrateTables(db *gorm.DB) error {
+	entities := baselineModels()
+
+	if err := db.AutoMigrate(entities...); err != nil {
+		return err
+	}
+
+	log.Println("[DB] Auto-migrate berhasil")
+	return nil
+}
+
+// migrateHealthDataNullable mengubah kolom health_data dari NOT NULL ke NULL.
+// GORM AutoMigrate tidak mengubah constraint NOT NULL secara otomatis.
+// Migration ini idempotent dan aman untuk Supabase/PostgreSQL.
+func migrateHealthDataNullable(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if !migrator.HasTable("health_data") {
+		log.Println("[DB] Tabel health_data belum ada, akan dibuat oleh AutoMigrate")
+		return nil
+	}
+
+	columnsToMigrate := []string{
+		"systolic",
+		"diastolic",
+		"blood_sugar",
+		"weight",
+		"height_cm",
+		"heart_rate",
+	}
+
+	for _, columnName := range columnsToMigrate {
+		if err := makeColumnNullable(db, "health_data", columnName); err != nil {
+			log.Printf("[DB] Warning: Gagal migrate kolom %s.%s: %v", "health_data", columnName, err)
+			// Continue dengan kolom berikutnya, tidak return error
+		}
+	}
+
+	return nil
+}
+
+// makeColumnNullable mengubah kolom menjadi nullable jika belum nullable.
+// Fungsi ini idempotent dan aman untuk dipanggil berulang kali.
+func makeColumnNullable(db *gorm.DB, tableName, columnName string) error {
+	// Cek apakah kolom ada dengan query langsung ke information_schema
+	var columnExists bool
+	checkColumnSQL := `
+		SELECT EXISTS (
+			SELECT 1 
+			FROM information_schema.columns 
+			WHERE table_schema = $1 
+			AND table_name = $2 
+			AND column_name = $3
+		)
+	`
+	err := db.Raw(checkColumnSQL, defaultSchema, tableName, columnName).Scan(&columnExists).Error
+	if err != nil {
+		log.Printf("[DB] Warning: Gagal mengecek kolom %s.%s: %v", tableName, columnName, err)
+		// Coba langsung alter jika kolom mungkin ada
+		return alterColumnNullable(db, tableName, columnName, true)
+	}
+
+	if !columnExists {
+		log.Printf("[DB] Kolom %s.%s tidak ditemukan, skip", tableName, columnName)
+		return nil
+	}
+
+	// Cek apakah kolom sudah nullable
+	isNullable, err := checkColumnNullable(db, tableName, columnName)
+	if err != nil {
+		// Jika gagal cek, coba langsung alter (untuk backward compatibility)
+		log.Printf("[DB] Warning: Gagal mengecek nullable untuk %s.%s, mencoba alter langsung", tableName, columnName)
+		return alterColumnNullable(db, tableName, columnName, true)
+	}
+
+	if isNullable {
+		log.Printf("[DB] Kolom %s.%s sudah nullable, skip", tableName, columnName)
+		return nil
+	}
+
+	return alterColumnNullable(db, tableName, columnName, true)
+}
+
+// checkColumnNullable mengecek apakah kolom sudah nullable
+func checkColumnNullable(db *gorm.DB, tableName, columnName string) (bool, error) {
+	var isNullable string
+	query := `
+		SELECT is_nullable 
+		FROM information_schema.columns 
+		WHERE table_schema = $1 
+		AND table_name = $2 
+		AND column_name = $3
+	`
+
+	err := db.Raw(query, defaultSchema, tableName, columnName).Scan(&isNullable).Error
+	if err != nil {
+		return false, err
+	}
+
+	return isNullable == "YES", nil
+}
+
+// alterColumnNullable mengubah constraint kolom menjadi nullable atau not null
+func alterColumnNullable(db *gorm.DB, tableName, columnName string, nullable bool) error {
+	var alterSQL string
+	if nullable {
+		alterSQL = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL", tableName, columnName)
+	} else {
+		alterSQL = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", tableName, columnName)
+	}
+
+	if err := db.Exec(alterSQL).Error; err != nil {
+		// Cek jika error karena constraint sudah sesuai
+		if isAlreadyNullableError(err) {
+			log.Printf("[DB] Kolom %s.%s sudah nullable, skip", tableName, columnName)
+			return nil
+		}
+		return fmt.Errorf("gagal mengubah kolom %s.%s: %w", tableName, columnName, err)
+	}
+
+	log.Printf("[DB] Kolom %s.%s berhasil diubah menjadi nullable", tableName, columnName)
+	return nil
+}
+
+// migrateHealthDataDailyRecord menambahkan kolom record_date dan expired_at untuk daily record system
+// Migration ini idempotent dan aman untuk Supabase/PostgreSQL
+func migrateHealthDataDailyRecord(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if !migrator.HasTable("health_data") {
+		log.Println("[DB] Tabel health_data belum ada, akan dibuat oleh AutoMigrate")
+		return nil
+	}
+
+	// Tambahkan kolom record_date jika belum ada
+	if !migrator.HasColumn("health_data", "record_date") {
+		// Set default value untuk record yang sudah ada: gunakan DATE(created_at)
+		alterSQL := `
+			ALTER TABLE health_data 
+			ADD COLUMN record_date DATE NOT NULL DEFAULT CURRENT_DATE
+		`
+		if err := db.Exec(alterSQL).Error; err != nil {
+			if isAlreadyExistsError(err) {
+				log.Println("[DB] Kolom record_date sudah ada")
+			} else {
+				return fmt.Errorf("gagal menambahkan kolom record_date: %w", err)
+			}
+		} else {
+			log.Println("[DB] Kolom record_date berhasil ditambahkan")
+
+			// Update record yang sudah ada: set record_date = DATE(created_at)
+			updateSQL := `
+				UPDATE health_data 
+				SET record_date = DATE(created_at)
+				WHERE record_date = CURRENT_DATE
+			`
+			if err := db.Exec(updateSQL).Error; err != nil {
+				log.Printf("[DB] Warning: Gagal update record_date untuk data lama: %v", err)
+				// Tidak return error, karena ini untuk backward compatibility
+			}
+
+			// Hapus default setelah update data lama
+			removeDefaultSQL := `ALTER TABLE health_data ALTER COLUMN record_date DROP DEFAULT`
+			if err := db.Exec(removeDefaultSQL).Error; err != nil {
+				log.Printf("[DB] Warning: Gagal menghapus default record_date: %v", err)
+				// Tidak return error, karena default tidak critical
+			}
+		}
+	}
+
+	// Tambahkan index untuk record_date jika belum ada
+	if err := createIndexIfNotExists(db, "health_data", "record_date", "idx_health_data_record_date"); err != nil {
+		log.Printf("[DB] Warning: Gagal menambahkan index untuk record_date: %v", err)
+		// Tidak return error, karena index bukan critical
+	}
+
+	// Tambahkan kolom expired_at jika belum ada
+	if !migrator.HasColumn("health_data", "expired_at") {
+		alterSQL := `ALTER TABLE health_data ADD COLUMN expired_at TIMESTAMP`
+		if err := db.Exec(alterSQL).Error; err != nil {
+			if isAlreadyExistsError(err) {
+				log.Println("[DB] Kolom expired_at sudah ada")
+			} else {
+				return fmt.Errorf("gagal menambahkan kolom expired_at: %w", err)
+			}
+		} else {
+			log.Println("[DB] Kolom expired_at berhasil ditambahkan")
+		}
+	}
+
+	log.Println("[DB] Migration health_data daily record berhasil")
+	return nil
+}
+
+// migrateHealthDataMeasuredAt mengisi kolom measured_at untuk data lama (daily record system)
+// dan menambahkan index komposit untuk query pembacaan terakhir per user.
+// Migration ini idempotent dan aman untuk Supabase/PostgreSQL.
+func migrateHealthDataMeasuredAt(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if !migrator.HasTable("health_data") {
+		log.Println("[DB] Tabel health_data belum ada, akan dibuat oleh AutoMigrate")
+		return nil
+	}
+
+	// Data lama belum memiliki measured_at: gunakan created_at sebagai waktu pengukuran
+	updateSQL := `
+		UPDATE health_data
+		SET measured_at = created_at
+		WHERE measured_at IS NULL
+	`
+	result := db.Exec(updateSQL)
+	if result.Error != nil {
+		return fmt.Errorf("gagal mengisi measured_at untuk data lama: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("[DB] measured_at berhasil diisi untuk %d data lama", result.RowsAffected)
+	}
+
+	if err := createIndexIfNotExists(db, "health_data", "user_id, measured_at", "idx_health_data_user_measured_at"); err != nil {
+		log.Printf("[DB] Warning: Gagal menambahkan index untuk measured_at: %v", err)
+		// Tidak return error, karena index bukan critical
+	}
+
+	return nil
+}
+
+// migrateUserEmailVerification menambahkan kolom email_verified_at ke tabel users.
+// User yang sudah terdaftar sebelum fitur verifikasi email dianggap terverifikasi
+// (diisi dengan created_at) agar tidak terkunci dari aplikasi.
+// Backfill hanya dilakukan sekali, yaitu saat kolom baru ditambahkan.
+func migrateUserEmailVerification(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if !migrator.HasTable("users") {
+		log.Println("[DB] Tabel users belum ada, akan dibuat oleh AutoMigrate")
+		return nil
+	}
+
+	if migrator.HasColumn("users", "email_verified_at") {
+		return nil
+	}
+
+	if err := db.Exec("ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ").Error; err != nil {
+		return fmt.Errorf("gagal menambahkan kolom email_verified_at: %w", err)
+	}
+
+	result := db.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL")
+	if result.Error != nil {
+		return fmt.Errorf("gagal menandai user lama sebagai terverifikasi: %w", result.Error)
+	}
+	log.Printf("[DB] Kolom email_verified_at ditambahkan, %d user lama ditandai terverifikasi", result.RowsAffected)
+
+	return nil
+}
+
+// migrateCareGrantsTable menambahkan index untuk pencarian akses caregiver aktif per pasien
+func migrateCareGrantsTable(db *gorm.DB) error {
+	if !db.Migrator().HasTable("care_grants") {
+		log.Println("[DB] Tabel care_grants belum ada, akan dibuat oleh AutoMigrate")
+		return nil
+	}
+
+	if err := createIndexIfNotExists(db, "care_grants", "owner_id, caregiver_id, status", "idx_care_grants_owner_caregiver_status"); err != nil {
+		log.Printf("[DB] Warning: Gagal menambahkan index care_grants: %v", err)
+		// Tidak return error, karena index bukan critical
+	}
+
+	return nil
+}
+
+// migrateEducationalVideosTable menambahkan kolom category_id ke tabel educational_videos
+// dan memastikan kolom tersebut nullable untuk backward compatibility.
+// Migration ini idempotent dan aman untuk Supabase/PostgreSQL.
+func migrateEducationalVideosTable(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if !migrator.HasTable("educational_videos") {
+		log.Println("[DB] Tabel educational_videos belum ada, akan dibuat oleh AutoMigrate")
+		return nil
+	}
+
+	// Jika kolom belum ada, tambahkan sebagai nullable
+	if !migrator.HasColumn("educational_videos", "category_id") {
+		alterSQL := "ALTER TABLE educational_videos ADD COLUMN category_id INTEGER"
+		if err := db.Exec(alterSQL).Error; err != nil {
+			if isAlreadyExistsError(err) {
+				log.Println("[DB] Kolom category_id sudah ada")
+			} else {
+				return fmt.Errorf("gagal menambahkan kolom category_id: %w", err)
+			}
+		} else {
+			log.Println("[DB] Kolom category_id berhasil ditambahkan ke educational_videos")
+		}
+	}
+
+	// Pastikan kolom category_id nullable (untuk backward compatibility dengan data lama)
+	if err := makeColumnNullable(db, "educational_videos", "category_id"); err != nil {
+		log.Printf("[DB] Warning: Gagal membuat category_id nullable: %v", err)
+		// Tidak return error, karena ini untuk backward compatibility
+	}
+
+	// Tambahkan index untuk category_id
+	if err := createIndexIfNotExists(db, "educational_videos", "category_id", "idx_educational_videos_category_id"); err != nil {
+		log.Printf("[DB] Warning: Gagal menambahkan index untuk category_id: %v", err)
+		// Tidak return error, karena index bukan critical
+	}
+
+	return nil
+}
+
+// createIndexIfNotExists membuat index jika belum ada
+func createIndexIfNotExists(db *gorm.DB, tableName, columnName, indexName string) error {
+	indexSQL := fmt.Sprintf(
+		"CREATE INDEX IF NOT EXISTS %s ON %s(%s)",
+		indexName, tableName, columnName,
+	)
+
+	if err := db.Exec(indexSQL).Error; err != nil {
+		return err
+	}
+
+	log.Printf("[DB] Index %s berhasil ditambahkan", indexName)
+	return nil
+}
+
+// migratePersonalInfosTable memastikan tabel personal_infos memiliki constraint yang benar
+// Migration ini idempotent dan aman untuk Supabase/PostgreSQL
+func migratePersonalInfosTable(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if !migrator.HasTable("personal_infos") {
+		log.Println("[DB] Tabel personal_infos belum ada, akan dibuat oleh AutoMigrate")
+		return nil
+	}
+
+	// Pastikan unique constraint pada user_id sudah ada
+	// GORM AutoMigrate sudah membuat uniqueIndex, tapi kita pastikan dengan migration manual
+	if err := ensureUniqueConstraint(db, "personal_infos", "user_id", "idx_personal_infos_user_id"); err != nil {
+		log.Printf("[DB] Warning: Gagal memastikan unique constraint untuk user_id: %v", err)
+		// Tidak return error, karena constraint mungkin sudah ada
+	}
+
+	log.Println("[DB] Migration personal_infos berhasil")
+	return nil
+}
+
+// ensureUniqueConstraint memastikan unique constraint ada pada kolom
+func ensureUniqueConstraint(db *gorm.DB, tableName, columnName, constraintName string) error {
+	// Cek apakah constraint sudah ada
+	var constraintExists bool
+	checkSQL := `
+		SELECT EXISTS (
+			SELECT 1 
+			FROM pg_constraint 
+			WHERE conname = $1
+		)
+	`
+	err := db.Raw(checkSQL, constraintName).Scan(&constraintExists).Error
+	if err != nil {
+		// Jika query gagal, anggap constraint belum ada dan coba buat
+		log.Printf("[DB] Warning: Gagal mengecek constraint %s, mencoba buat langsung", constraintName)
+	}
+
+	if constraintExists {
+		log.Printf("[DB] Constraint %s sudah ada, skip", constraintName)
+		return nil
+	}
+
+	// Buat unique constraint
+	// Note: GORM AutoMigrate dengan uniqueIndex seharusnya sudah membuat ini
+	// Tapi kita pastikan dengan migration manual
+	createSQL := fmt.Sprintf(
+		"CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s(%s)",
+		constraintName, tableName, columnName,
+	)
+
+	if err := db.Exec(createSQL).Error; err != nil {
+		if isAlreadyExistsError(err) {
+			log.Printf("[DB] Constraint %s sudah ada", constraintName)
+			return nil
+		}
+		return fmt.Errorf("gagal membuat unique constraint %s: %w", constraintName, err)
+	}
+
+	log.Printf("[DB] Unique constraint %s berhasil dibuat", constraintName)
+	return nil
+}
+
+// migrateRemoveUserColumns menghapus kolom birth_date, phone, dan address dari tabel users
+// karena kolom-kolom tersebut sudah dipindah ke tabel personal_infos
+// Migration ini idempotent dan aman untuk Supabase/PostgreSQL
+func migrateRemoveUserColumns(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if !migrator.HasTable("users") {
+		log.Println("[DB] Tabel users belum ada, skip migration")
+		return nil
+	}
+
+	columnsToRemove := []string{"birth_date", "phone", "address"}
+
+	for _, columnName := range columnsToRemove {
+		if err := dropColumnIfExists(db, "users", columnName); err != nil {
+			log.Printf("[DB] Warning: Gagal menghapus kolom %s.%s: %v", "users", columnName, err)
+			// Continue dengan kolom berikutnya, tidak return error
+		}
+	}
+
+	log.Println("[DB] Migration remove user columns berhasil")
+	return nil
+}
+
+// dropColumnIfExists menghapus kolom jika kolom tersebut ada
+// Fungsi ini idempotent dan aman untuk dipanggil berulang kali
+func dropColumnIfExists(db *gorm.DB, tableName, columnName string) error {
+	migrator := db.Migrator()
+
+	// Cek apakah kolom ada
+	if !migrator.HasColumn(tableName, columnName) {
+		log.Printf("[DB] Kolom %s.%s tidak ditemukan, skip", tableName, columnName)
+		return nil
+	}
+
+	// Hapus kolom
+	dropSQL := fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s", tableName, columnName)
+	if err := db.Exec(dropSQL).Error; err != nil {
+		// Cek jika error karena kolom sudah tidak ada
+		if isColumnNotExistsError(err) {
+			log.Printf("[DB] Kolom %s.%s sudah tidak ada, skip", tableName, columnName)
+			return nil
+		}
+		return fmt.Errorf("gagal menghapus kolom %s.%s: %w", tableName, columnName, err)
+	}
+
+	log.Printf("[DB] Kolom %s.%s berhasil dihapus", tableName, columnName)
+	return nil
+}
+
+// isColumnNotExistsError mengecek apakah error karena kolom tidak ada
+func isColumnNotExistsError(err error) bool {
+	if err == nil {
+		return false
+	}
+	errMsg := strings.ToLower(err.Error())
+	return strings.Contains(errMsg, "does not exist") ||
+		strings.Contains(errMsg, "column") && strings.Contains(errMsg, "not found")
+}
+
+// Helper functions untuk error checking
+
+// isAlreadyNullableError mengecek apakah error karena kolom sudah nullable
+func isAlreadyNullableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	errMsg := strings.ToLower(err.Error())
+	return strings.Contains(errMsg, "does not exist") ||
+		strings.Contains(errMsg, "already") ||
+		strings.Contains(errMsg, "duplicate")
+}
+
+// isAlreadyExistsError mengecek apakah error karena resource sudah ada
+func isAlreadyExistsError(err error) bool {
+	if err == nil {
+		return false
+	}
+	errMsg := strings.ToLower(err.Error())
+	return strings.Contains(errMsg, "already exists") ||
+		strings.Contains(errMsg, "duplicate")
+}
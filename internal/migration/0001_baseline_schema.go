@@ -0,0 +1,398 @@
+package migration
+
+import "time"
+
+// Salinan struct entity sebagaimana adanya saat baseline dibuat, khusus untuk autoMigrateTables.
+// Baseline sengaja tidak memakai struct di package entity agar skema versi 1 tidak ikut berubah
+// ketika entity berubah. JANGAN ubah struct di file ini: perubahan skema setelah baseline
+// ditambahkan lewat migration berversi baru.
+
+type baselineUser struct {
+	ID                  uint   `gorm:"primaryKey"`
+	Nama                string `gorm:"type:varchar(100);not null"`
+	Username            string `gorm:"type:varchar(50);unique;not null"`
+	Email               string `gorm:"type:varchar(100);unique;not null"`
+	Password            string `gorm:"type:varchar(255);not null"`
+	Role                string `gorm:"type:varchar(20);not null;default:'patient';index"`
+	EmailVerifiedAt     *time.Time
+	NotificationEnabled *bool                `gorm:"default:true;column:notification_enabled"`
+	Language            *string              `gorm:"type:varchar(10);default:'id'"`
+	HealthData          []baselineHealthData `gorm:"foreignKey:UserID"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (baselineUser) TableName() string {
+	return "users"
+}
+
+type baselineHealthData struct {
+	ID                 uint         `gorm:"primaryKey"`
+	UserID             uint         `gorm:"not null;index"`
+	User               baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Systolic           *int         `gorm:"type:int"`
+	Diastolic          *int         `gorm:"type:int"`
+	BloodSugar         *int         `gorm:"type:int"`
+	BloodSugarContext  *string      `gorm:"type:varchar(20)"`
+	Weight             *float64     `gorm:"type:double precision"`
+	HeightCM           *int         `gorm:"type:int;column:height_cm"`
+	HeartRate          *int         `gorm:"type:int"`
+	OxygenSaturation   *int         `gorm:"type:int"`
+	Temperature        *float64     `gorm:"type:decimal(4,1)"`
+	RespiratoryRate    *int         `gorm:"type:int"`
+	Activity           *string      `gorm:"type:text"`
+	ActivityType       *string      `gorm:"type:varchar(30)"`
+	ActivityDuration   *int         `gorm:"type:int"`
+	ActivityIntensity  *string      `gorm:"type:varchar(10)"`
+	Steps              *int         `gorm:"type:int"`
+	DistanceKm         *float64     `gorm:"type:decimal(6,2)"`
+	CaloriesBurned     *float64     `gorm:"type:decimal(7,1)"`
+	Notes              *string      `gorm:"type:text"`
+	BodyPosition       *string      `gorm:"type:varchar(10)"`
+	MeasurementArm     *string      `gorm:"type:varchar(10)"`
+	Symptoms           *string      `gorm:"type:varchar(255)"`
+	MeasurementContext *string      `gorm:"type:varchar(30)"`
+	MeasuredAt         time.Time    `gorm:"index"`
+	RecordDate         time.Time    `gorm:"type:date;not null;index"`
+	ExpiredAt          *time.Time   `gorm:"type:timestamp"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func (baselineHealthData) TableName() string {
+	return "health_data"
+}
+
+type baselineBlacklistedToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	Token     string    `gorm:"type:text;unique;not null;index"`
+	UserID    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+func (baselineBlacklistedToken) TableName() string {
+	return "blacklisted_tokens"
+}
+
+type baselineUserSession struct {
+	ID                uint      `gorm:"primaryKey"`
+	UserID            uint      `gorm:"not null;index"`
+	RefreshTokenHash  string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	PreviousTokenHash *string   `gorm:"type:varchar(64);index"`
+	UserAgent         string    `gorm:"type:varchar(255)"`
+	IPAddress         string    `gorm:"type:varchar(45)"`
+	ExpiresAt         time.Time `gorm:"not null;index"`
+	LastUsedAt        time.Time
+	RevokedAt         *time.Time `gorm:"index"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (baselineUserSession) TableName() string {
+	return "user_sessions"
+}
+
+type baselinePasswordResetToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (baselinePasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+type baselineEmailVerificationToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (baselineEmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
+
+type baselineAuthThrottle struct {
+	ID            uint   `gorm:"primaryKey"`
+	Action        string `gorm:"type:varchar(30);not null;uniqueIndex:idx_auth_throttles_action_scope_key"`
+	Scope         string `gorm:"type:varchar(20);not null;uniqueIndex:idx_auth_throttles_action_scope_key"`
+	Key           string `gorm:"type:varchar(255);not null;uniqueIndex:idx_auth_throttles_action_scope_key"`
+	FailureCount  int    `gorm:"not null;default:0"`
+	LastFailureAt *time.Time
+	NextAttemptAt *time.Time
+	LockedUntil   *time.Time `gorm:"index"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (baselineAuthThrottle) TableName() string {
+	return "auth_throttles"
+}
+
+type baselineAuthAuditLog struct {
+	ID        uint   `gorm:"primaryKey"`
+	Event     string `gorm:"type:varchar(30);not null;index"`
+	Action    string `gorm:"type:varchar(30);not null"`
+	Scope     string `gorm:"type:varchar(20);not null"`
+	Key       string `gorm:"type:varchar(255);not null;index"`
+	UserID    *uint  `gorm:"index"`
+	ActorID   *uint
+	IPAddress string    `gorm:"type:varchar(45)"`
+	Details   string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (baselineAuthAuditLog) TableName() string {
+	return "auth_audit_logs"
+}
+
+type baselineCareGrant struct {
+	ID          uint          `gorm:"primaryKey"`
+	OwnerID     uint          `gorm:"not null;index"`
+	CaregiverID uint          `gorm:"not null;index"`
+	Scope       string        `gorm:"type:varchar(20);not null;default:'view'"`
+	Status      string        `gorm:"type:varchar(20);not null;default:'pending';index"`
+	Owner       *baselineUser `gorm:"foreignKey:OwnerID"`
+	Caregiver   *baselineUser `gorm:"foreignKey:CaregiverID"`
+	AcceptedAt  *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (baselineCareGrant) TableName() string {
+	return "care_grants"
+}
+
+type baselineClinicianPatient struct {
+	ID          uint          `gorm:"primaryKey"`
+	ClinicianID uint          `gorm:"not null;uniqueIndex:idx_clinician_patients_pair"`
+	PatientID   uint          `gorm:"not null;uniqueIndex:idx_clinician_patients_pair;index"`
+	Clinician   *baselineUser `gorm:"foreignKey:ClinicianID;constraint:OnDelete:CASCADE"`
+	Patient     *baselineUser `gorm:"foreignKey:PatientID;constraint:OnDelete:CASCADE"`
+	AssignedBy  *uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (baselineClinicianPatient) TableName() string {
+	return "clinician_patients"
+}
+
+type baselineClinicalThreshold struct {
+	ID                    uint          `gorm:"primaryKey"`
+	UserID                *uint         `gorm:"uniqueIndex"`
+	User                  *baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	SystolicMin           *int          `gorm:"type:int"`
+	SystolicMax           *int          `gorm:"type:int"`
+	DiastolicMin          *int          `gorm:"type:int"`
+	DiastolicMax          *int          `gorm:"type:int"`
+	BloodSugarMin         *int          `gorm:"type:int"`
+	BloodSugarMax         *int          `gorm:"type:int"`
+	BloodSugarFastingMin  *int          `gorm:"type:int"`
+	BloodSugarFastingMax  *int          `gorm:"type:int"`
+	BloodSugarPostMealMin *int          `gorm:"type:int"`
+	BloodSugarPostMealMax *int          `gorm:"type:int"`
+	BloodSugarBedtimeMin  *int          `gorm:"type:int"`
+	BloodSugarBedtimeMax  *int          `gorm:"type:int"`
+	HeartRateMin          *int          `gorm:"type:int"`
+	HeartRateMax          *int          `gorm:"type:int"`
+	OxygenSaturationMin   *int          `gorm:"type:int"`
+	TemperatureMin        *float64      `gorm:"type:decimal(4,1)"`
+	TemperatureMax        *float64      `gorm:"type:decimal(4,1)"`
+	RespiratoryRateMin    *int          `gorm:"type:int"`
+	RespiratoryRateMax    *int          `gorm:"type:int"`
+	BMIMin                *float64      `gorm:"type:decimal(5,2);column:bmi_min"`
+	BMIMax                *float64      `gorm:"type:decimal(5,2);column:bmi_max"`
+	Note                  *string       `gorm:"type:text"`
+	UpdatedBy             *uint
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+func (baselineClinicalThreshold) TableName() string {
+	return "clinical_thresholds"
+}
+
+type baselineHealthAlert struct {
+	ID              uint         `gorm:"primaryKey"`
+	UserID          uint         `gorm:"not null;index"`
+	User            baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	HealthDataID    *uint        `gorm:"index"`
+	AlertType       string       `gorm:"type:varchar(100);not null"`
+	Category        string       `gorm:"type:varchar(50);not null;default:'';index"`
+	Value           string       `gorm:"type:varchar(100)"`
+	Label           string       `gorm:"type:varchar(100)"`
+	Message         string       `gorm:"type:text;not null"`
+	Status          string       `gorm:"type:varchar(20);not null"`
+	Recommendations string       `gorm:"type:text"`
+	RecordDate      time.Time    `gorm:"type:date;index"`
+	RecordedAt      time.Time    `gorm:"not null"`
+	State           string       `gorm:"type:varchar(20);not null;default:'unread';index"`
+	ReadAt          *time.Time
+	AcknowledgedAt  *time.Time
+	ResolvedAt      *time.Time
+	ResolutionNote  *string `gorm:"type:text"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (baselineHealthAlert) TableName() string {
+	return "health_alerts"
+}
+
+type baselineCategory struct {
+	ID        uint   `gorm:"primaryKey"`
+	Kategori  string `gorm:"type:varchar(100);not null;unique"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselineCategory) TableName() string {
+	return "categories"
+}
+
+type baselineEducationalVideo struct {
+	ID              uint               `gorm:"primaryKey"`
+	VideoTitle      string             `gorm:"type:varchar(255);not null"`
+	VideoURL        string             `gorm:"type:text;not null"`
+	CategoryID      *uint              `gorm:"index"`
+	Category        *baselineCategory  `gorm:"foreignKey:CategoryID"`
+	HealthCondition string             `gorm:"type:varchar(100);not null"`
+	Categories      []baselineCategory `gorm:"many2many:educational_video_categories;foreignKey:ID;joinForeignKey:EducationalVideoID;References:ID;joinReferences:CategoryID"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (baselineEducationalVideo) TableName() string {
+	return "educational_videos"
+}
+
+type baselineEducationalVideoCategory struct {
+	ID                 uint                     `gorm:"primaryKey"`
+	EducationalVideoID uint                     `gorm:"not null;index"`
+	CategoryID         uint                     `gorm:"not null;index"`
+	EducationalVideo   baselineEducationalVideo `gorm:"foreignKey:EducationalVideoID"`
+	Category           baselineCategory         `gorm:"foreignKey:CategoryID"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func (baselineEducationalVideoCategory) TableName() string {
+	return "educational_video_categories"
+}
+
+type baselineHealthTarget struct {
+	ID               uint         `gorm:"primaryKey"`
+	UserID           uint         `gorm:"not null;uniqueIndex"`
+	User             baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	TargetSystolic   *int         `gorm:"type:int"`
+	TargetDiastolic  *int         `gorm:"type:int"`
+	TargetBloodSugar *int         `gorm:"type:int"`
+	TargetWeight     *float64     `gorm:"type:decimal(5,2)"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (baselineHealthTarget) TableName() string {
+	return "health_targets"
+}
+
+type baselinePersonalInfo struct {
+	ID        uint         `gorm:"primaryKey"`
+	UserID    uint         `gorm:"not null;uniqueIndex"`
+	User      baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Name      string       `gorm:"type:varchar(100);not null"`
+	BirthDate *time.Time   `gorm:"type:date"`
+	Phone     *string      `gorm:"type:varchar(15)"`
+	Address   *string      `gorm:"type:text"`
+	PhotoURL  *string      `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselinePersonalInfo) TableName() string {
+	return "personal_infos"
+}
+
+type baselineLabResult struct {
+	ID           uint         `gorm:"primaryKey"`
+	UserID       uint         `gorm:"not null;index"`
+	User         baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	TestType     string       `gorm:"type:varchar(30);not null;index"`
+	Value        float64      `gorm:"type:decimal(8,2);not null"`
+	Unit         string       `gorm:"type:varchar(20);not null"`
+	ReferenceMin *float64     `gorm:"type:decimal(8,2)"`
+	ReferenceMax *float64     `gorm:"type:decimal(8,2)"`
+	TestedAt     time.Time    `gorm:"not null;index"`
+	LabName      *string      `gorm:"type:varchar(100)"`
+	Notes        *string      `gorm:"type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (baselineLabResult) TableName() string {
+	return "lab_results"
+}
+
+type baselineMedication struct {
+	ID            uint         `gorm:"primaryKey"`
+	UserID        uint         `gorm:"not null;index"`
+	User          baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Name          string       `gorm:"type:varchar(100);not null"`
+	Dose          string       `gorm:"type:varchar(50);not null"`
+	Instructions  *string      `gorm:"type:text"`
+	Purpose       *string      `gorm:"type:varchar(100)"`
+	ScheduleTimes string       `gorm:"type:varchar(200);not null;default:''"`
+	StartDate     time.Time    `gorm:"type:date;not null"`
+	EndDate       *time.Time   `gorm:"type:date"`
+	IsActive      bool         `gorm:"not null;default:true;index"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (baselineMedication) TableName() string {
+	return "medications"
+}
+
+type baselineMedicationLog struct {
+	ID           uint               `gorm:"primaryKey"`
+	MedicationID uint               `gorm:"not null;uniqueIndex:idx_medication_logs_medication_scheduled"`
+	Medication   baselineMedication `gorm:"foreignKey:MedicationID;constraint:OnDelete:CASCADE"`
+	UserID       uint               `gorm:"not null;index"`
+	ScheduledAt  time.Time          `gorm:"not null;uniqueIndex:idx_medication_logs_medication_scheduled;index"`
+	Status       string             `gorm:"type:varchar(20);not null"`
+	TakenAt      *time.Time
+	Notes        *string `gorm:"type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (baselineMedicationLog) TableName() string {
+	return "medication_logs"
+}
+
+type baselineNotification struct {
+	ID          uint         `gorm:"primaryKey"`
+	UserID      uint         `gorm:"not null;index;uniqueIndex:idx_notifications_user_dedup_key"`
+	User        baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Type        string       `gorm:"type:varchar(50);not null;index"`
+	Title       string       `gorm:"type:varchar(150);not null"`
+	Message     string       `gorm:"type:text;not null"`
+	ReferenceID *uint
+	Params      string     `gorm:"type:text"`
+	DedupKey    *string    `gorm:"type:varchar(150);uniqueIndex:idx_notifications_user_dedup_key"`
+	ReadAt      *time.Time `gorm:"index"`
+	CreatedAt   time.Time  `gorm:"index"`
+}
+
+func (baselineNotification) TableName() string {
+	return "notifications"
+}
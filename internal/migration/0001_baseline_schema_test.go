@@ -0,0 +1,84 @@
+package migration
+
+import (
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+// baselineColumnCase adalah satu kolom yang tidak boleh dimiliki salinan entity baseline
+type baselineColumnCase struct {
+	name   string
+	model  interface{}
+	column string
+}
+
+// TestBaselineSchemaExcludesLaterColumns memastikan kolom yang ditambahkan migration berversi
+// tidak ikut dibuat oleh baseline, agar down lalu up migration tersebut tidak mengubah skema.
+func TestBaselineSchemaExcludesLaterColumns(t *testing.T) {
+	tests := []baselineColumnCase{
+		{name: "users.disabled_at dibuat oleh 0003", model: &baselineUser{}, column: "disabled_at"},
+		{name: "clinical_thresholds.oxygen_saturation_critical dibuat oleh 0006", model: &baselineClinicalThreshold{}, column: "oxygen_saturation_critical"},
+		{name: "clinical_thresholds.temperature_critical dibuat oleh 0007", model: &baselineClinicalThreshold{}, column: "temperature_critical"},
+	}
+	for _, column := range labReferenceColumns {
+		tests = append(tests, baselineColumnCase{name: "clinical_thresholds." + column + " dibuat oleh 0005", model: &baselineClinicalThreshold{}, column: column})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := schema.Parse(tt.model, &sync.Map{}, schema.NamingStrategy{})
+			if err != nil {
+				t.Fatalf("gagal parse schema: %v", err)
+			}
+			if field := s.LookUpField(tt.column); field != nil {
+				t.Errorf("baseline tabel %s tidak boleh memiliki kolom %s", s.Table, tt.column)
+			}
+		})
+	}
+}
+
+// TestBaselineSchemaExcludesLaterTables memastikan tabel yang dibuat migration berversi
+// tidak ikut dibuat oleh AutoMigrate baseline.
+func TestBaselineSchemaExcludesLaterTables(t *testing.T) {
+	laterTables := map[string]string{
+		"job_runs": "0004",
+	}
+
+	for _, model := range baselineModels() {
+		s, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatalf("gagal parse schema: %v", err)
+		}
+		if version, ok := laterTables[s.Table]; ok {
+			t.Errorf("baseline tidak boleh membuat tabel %s (dibuat oleh %s)", s.Table, version)
+		}
+	}
+}
+
+// TestBaselineSchemaExcludesLaterIndexes memastikan index yang dibuat migration berversi
+// tidak ikut dibuat oleh AutoMigrate baseline.
+func TestBaselineSchemaExcludesLaterIndexes(t *testing.T) {
+	tests := []struct {
+		name  string
+		model interface{}
+		index string
+	}{
+		{name: "health_alerts unique (user_id, category, record_date) dibuat oleh 0008", model: &baselineHealthAlert{}, index: "idx_health_alerts_user_category_record_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := schema.Parse(tt.model, &sync.Map{}, schema.NamingStrategy{})
+			if err != nil {
+				t.Fatalf("gagal parse schema: %v", err)
+			}
+			for _, index := range s.ParseIndexes() {
+				if index.Name == tt.index {
+					t.Errorf("baseline tabel %s tidak boleh memiliki index %s", s.Table, tt.index)
+				}
+			}
+		})
+	}
+}
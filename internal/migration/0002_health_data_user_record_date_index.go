@@ -0,0 +1,17 @@
+package migration
+
+import "gorm.io/gorm"
+
+// healthDataUserRecordDateIndexMigration menambahkan index komposit (user_id, record_date) pada health_data.
+// Hampir semua query data kesehatan (ringkasan, tren, riwayat, laporan, pencarian) memfilter per user
+// dalam rentang tanggal; sebelumnya hanya tersedia index terpisah untuk masing-masing kolom.
+var healthDataUserRecordDateIndexMigration = Migration{
+	Version: 2,
+	Name:    "health_data_user_record_date_index",
+	Up: func(tx *gorm.DB) error {
+		return tx.Exec("CREATE INDEX IF NOT EXISTS idx_health_data_user_record_date ON health_data(user_id, record_date)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("DROP INDEX IF EXISTS idx_health_data_user_record_date").Error
+	},
+}
@@ -0,0 +1,16 @@
+package migration
+
+import "gorm.io/gorm"
+
+// usersDisabledAtMigration menambahkan kolom disabled_at ke tabel users untuk menonaktifkan akun lewat cmd/admin
+// Kolom ini hanya dibuat oleh migration ini (baseline tidak memilikinya), sehingga down lalu up menghasilkan skema yang sama.
+var usersDisabledAtMigration = Migration{
+	Version: 3,
+	Name:    "users_disabled_at",
+	Up: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE users DROP COLUMN IF EXISTS disabled_at").Error
+	},
+}
@@ -0,0 +1,35 @@
+package migration
+
+import "gorm.io/gorm"
+
+// jobRunsMigration membuat tabel job_runs untuk riwayat eksekusi job background (pembersihan data kadaluarsa)
+var jobRunsMigration = Migration{
+	Version: 4,
+	Name:    "job_runs",
+	Up: func(tx *gorm.DB) error {
+		statements := []string{
+			`CREATE TABLE IF NOT EXISTS job_runs (
+				id BIGSERIAL PRIMARY KEY,
+				job_name VARCHAR(100) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				instance VARCHAR(255) NOT NULL DEFAULT '',
+				started_at TIMESTAMPTZ NOT NULL,
+				finished_at TIMESTAMPTZ,
+				duration_ms BIGINT NOT NULL DEFAULT 0,
+				affected_rows BIGINT NOT NULL DEFAULT 0,
+				error TEXT
+			)`,
+			"CREATE INDEX IF NOT EXISTS idx_job_runs_job_name_started_at ON job_runs(job_name, started_at)",
+			"CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs(started_at)",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("DROP TABLE IF EXISTS job_runs").Error
+	},
+}
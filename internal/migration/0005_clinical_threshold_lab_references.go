@@ -0,0 +1,55 @@
+package migration
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// labReferenceColumns adalah kolom rentang rujukan lab di clinical_thresholds (satuan standar jenis pemeriksaan)
+var labReferenceColumns = []string{
+	"hba1c_min", "hba1c_max",
+	"total_cholesterol_min", "total_cholesterol_max",
+	"ldl_min", "ldl_max",
+	"hdl_min", "hdl_max",
+	"triglycerides_min", "triglycerides_max",
+	"uric_acid_min", "uric_acid_max",
+	"creatinine_min", "creatinine_max",
+}
+
+// clinicalThresholdLabReferencesMigration memindahkan rentang rujukan hasil lab dari kode ke clinical_thresholds
+// sehingga admin dapat mengubah default global dan clinician dapat menyesuaikannya per pasien.
+// Default global yang sudah ada diisi dengan rentang rujukan yang sebelumnya tertanam di kode.
+var clinicalThresholdLabReferencesMigration = Migration{
+	Version: 5,
+	Name:    "clinical_threshold_lab_references",
+	Up: func(tx *gorm.DB) error {
+		for _, column := range labReferenceColumns {
+			statement := fmt.Sprintf("ALTER TABLE clinical_thresholds ADD COLUMN IF NOT EXISTS %s DECIMAL(8,2)", column)
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec(`UPDATE clinical_thresholds SET
+			hba1c_max = 5.6,
+			total_cholesterol_max = 199,
+			ldl_max = 99,
+			hdl_min = 40,
+			triglycerides_max = 149,
+			uric_acid_min = 3.4,
+			uric_acid_max = 7.0,
+			creatinine_min = 0.6,
+			creatinine_max = 1.3
+			WHERE user_id IS NULL`).Error
+	},
+	Down: func(tx *gorm.DB) error {
+		for _, column := range labReferenceColumns {
+			statement := fmt.Sprintf("ALTER TABLE clinical_thresholds DROP COLUMN IF EXISTS %s", column)
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
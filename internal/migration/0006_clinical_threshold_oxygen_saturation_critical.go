@@ -0,0 +1,20 @@
+package migration
+
+import "gorm.io/gorm"
+
+// clinicalThresholdOxygenSaturationCriticalMigration menambahkan batas kritis SpO2 (gawat darurat) ke clinical_thresholds
+// sehingga batas yang sebelumnya tertanam di evaluasi alert dapat diubah per default global maupun per pasien.
+// Default global yang sudah ada diisi dengan nilai yang sebelumnya dipakai di kode (90%).
+var clinicalThresholdOxygenSaturationCriticalMigration = Migration{
+	Version: 6,
+	Name:    "clinical_threshold_oxygen_saturation_critical",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.Exec("ALTER TABLE clinical_thresholds ADD COLUMN IF NOT EXISTS oxygen_saturation_critical INT").Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE clinical_thresholds SET oxygen_saturation_critical = 90 WHERE user_id IS NULL").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE clinical_thresholds DROP COLUMN IF EXISTS oxygen_saturation_critical").Error
+	},
+}
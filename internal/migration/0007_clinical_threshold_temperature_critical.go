@@ -0,0 +1,20 @@
+package migration
+
+import "gorm.io/gorm"
+
+// clinicalThresholdTemperatureCriticalMigration menambahkan batas demam tinggi ke clinical_thresholds
+// sehingga batas yang sebelumnya tertanam di evaluasi alert dapat diubah per default global maupun per pasien.
+// Default global yang sudah ada diisi dengan nilai yang sebelumnya dipakai di kode (39.0 °C).
+var clinicalThresholdTemperatureCriticalMigration = Migration{
+	Version: 7,
+	Name:    "clinical_threshold_temperature_critical",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.Exec("ALTER TABLE clinical_thresholds ADD COLUMN IF NOT EXISTS temperature_critical DECIMAL(4,1)").Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE clinical_thresholds SET temperature_critical = 39.0 WHERE user_id IS NULL").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("ALTER TABLE clinical_thresholds DROP COLUMN IF EXISTS temperature_critical").Error
+	},
+}
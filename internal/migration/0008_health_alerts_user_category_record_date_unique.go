@@ -0,0 +1,23 @@
+package migration
+
+import "gorm.io/gorm"
+
+// healthAlertsUserCategoryRecordDateUniqueMigration menambahkan unique index (user_id, category, record_date)
+// pada health_alerts. Sebelumnya deduplikasi harian hanya dijaga di kode (cek lalu insert), sehingga
+// sinkronisasi alert yang berjalan bersamaan dapat membuat dua alert untuk kategori dan hari yang sama.
+// Duplikat yang sudah ada dibersihkan dulu dengan menyimpan alert yang paling akhir diperbarui.
+var healthAlertsUserCategoryRecordDateUniqueMigration = Migration{
+	Version: 8,
+	Name:    "health_alerts_user_category_record_date_unique",
+	Up: func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM health_alerts a USING health_alerts b
+			WHERE a.user_id = b.user_id AND a.category = b.category AND a.record_date = b.record_date
+			AND (a.updated_at < b.updated_at OR (a.updated_at = b.updated_at AND a.id < b.id))`).Error; err != nil {
+			return err
+		}
+		return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_health_alerts_user_category_record_date ON health_alerts(user_id, category, record_date)").Error
+	},
+	Down: func(tx *gorm.DB) error {
+		return tx.Exec("DROP INDEX IF EXISTS idx_health_alerts_user_category_record_date").Error
+	},
+}
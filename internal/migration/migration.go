@@ -0,0 +1,330 @@
+// Package migration menjalankan migration database berversi (up/down) yang tercatat di tabel schema_migrations.
+//
+// Setiap migration memiliki nomor versi berurutan dan didaftarkan di registry (lihat migrations.go).
+// Runner mengambil PostgreSQL advisory lock selama migration berjalan sehingga beberapa replika API
+// atau command cmd/migrate yang dijalankan bersamaan tidak saling balapan: proses lain menunggu,
+// lalu melihat bahwa migration sudah diterapkan.
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// schemaMigrationsTable adalah tabel pencatat versi migration yang sudah diterapkan
+	schemaMigrationsTable = "schema_migrations"
+
+	// advisoryLockID adalah key pg_advisory_lock khusus migration aplikasi ini
+	advisoryLockID int64 = 7263540121
+)
+
+// Migration adalah satu perubahan skema berversi
+// Up wajib diisi; Down boleh nil untuk migration yang tidak dapat di-rollback.
+// Secara default Up/Down dijalankan dalam transaksi bersama pencatatan versinya.
+// NoTransaction hanya untuk migration idempoten atau yang tidak bisa berjalan dalam transaksi
+// (mis. CREATE INDEX CONCURRENTLY): jika gagal di tengah jalan, versinya tidak tercatat dan aman diulang.
+type Migration struct {
+	Version       uint
+	Name          string
+	Up            func(tx *gorm.DB) error
+	Down          func(tx *gorm.DB) error
+	NoTransaction bool
+}
+
+// Status adalah status satu migration terhadap database
+// AppliedAt nil berarti migration belum diterapkan (pending)
+type Status struct {
+	Version   uint
+	Name      string
+	AppliedAt *time.Time
+}
+
+// appliedMigration adalah baris tabel schema_migrations
+type appliedMigration struct {
+	Version   uint
+	Name      string
+	AppliedAt time.Time
+}
+
+// Runner menerapkan dan me-rollback migration dari registry
+type Runner struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewRunner membuat instance baru dari Runner dengan seluruh migration terdaftar
+func NewRunner(db *gorm.DB) *Runner {
+	return &Runner{
+		db:         db,
+		migrations: registry,
+	}
+}
+
+// Up menerapkan semua migration yang belum diterapkan secara berurutan
+// Mengembalikan jumlah migration yang diterapkan; berhenti pada migration pertama yang gagal
+func (r *Runner) Up() (int, error) {
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	err := r.withLock(func(conn *gorm.DB) error {
+		appliedVersions, err := r.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range r.migrations {
+			if _, ok := appliedVersions[m.Version]; ok {
+				continue
+			}
+
+			log.Printf("[DB] Menerapkan migration %s", m.label())
+			if err := r.run(conn, m, m.Up, func(tx *gorm.DB) error {
+				return tx.Exec("INSERT INTO "+schemaMigrationsTable+" (version, name) VALUES (?, ?)", m.Version, m.Name).Error
+			}); err != nil {
+				return fmt.Errorf("migration %s gagal: %w", m.label(), err)
+			}
+			applied++
+		}
+
+		r.warnUnknownVersions(appliedVersions)
+		return nil
+	})
+	if err != nil {
+		return applied, err
+	}
+
+	if applied == 0 {
+		log.Println("[DB] Skema database sudah versi terbaru")
+	} else {
+		log.Printf("[DB] %d migration berhasil diterapkan", applied)
+	}
+	return applied, nil
+}
+
+// Down me-rollback sejumlah steps migration terakhir yang sudah diterapkan (terbaru lebih dulu)
+// Mengembalikan jumlah migration yang di-rollback
+func (r *Runner) Down(steps int) (int, error) {
+	if steps < 1 {
+		return 0, errors.New("jumlah migration yang di-rollback harus minimal 1")
+	}
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+
+	rolledBack := 0
+	err := r.withLock(func(conn *gorm.DB) error {
+		appliedVersions, err := r.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]uint, 0, len(appliedVersions))
+		for version := range appliedVersions {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for _, version := range versions {
+			if rolledBack == steps {
+				break
+			}
+
+			m, ok := r.find(version)
+			if !ok {
+				return fmt.Errorf("migration versi %d tidak dikenal oleh aplikasi ini", version)
+			}
+			if m.Down == nil {
+				return fmt.Errorf("migration %s tidak dapat di-rollback", m.label())
+			}
+
+			log.Printf("[DB] Me-rollback migration %s", m.label())
+			if err := r.run(conn, m, m.Down, func(tx *gorm.DB) error {
+				return tx.Exec("DELETE FROM "+schemaMigrationsTable+" WHERE version = ?", m.Version).Error
+			}); err != nil {
+				return fmt.Errorf("rollback migration %s gagal: %w", m.label(), err)
+			}
+			rolledBack++
+		}
+		return nil
+	})
+	if err != nil {
+		return rolledBack, err
+	}
+
+	log.Printf("[DB] %d migration berhasil di-rollback", rolledBack)
+	return rolledBack, nil
+}
+
+// Status mengembalikan status semua migration terdaftar, ditambah versi yang tercatat di database
+// tetapi tidak dikenal oleh aplikasi ini (mis. database sudah dimigrasi oleh versi aplikasi yang lebih baru)
+func (r *Runner) Status() ([]Status, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	if err := r.ensureTable(r.db); err != nil {
+		return nil, err
+	}
+
+	appliedVersions, err := r.appliedVersions(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		status := Status{Version: m.Version, Name: m.Name}
+		if applied, ok := appliedVersions[m.Version]; ok {
+			appliedAt := applied.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	for version, applied := range appliedVersions {
+		if _, ok := r.find(version); !ok {
+			appliedAt := applied.AppliedAt
+			statuses = append(statuses, Status{Version: version, Name: applied.Name, AppliedAt: &appliedAt})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// Pending mengembalikan migration terdaftar yang belum diterapkan
+func (r *Runner) Pending() ([]Migration, error) {
+	statuses, err := r.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			continue
+		}
+		if m, ok := r.find(status.Version); ok {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// withLock menjalankan fn pada satu koneksi database yang memegang advisory lock migration
+// Advisory lock berlaku per sesi, sehingga lock, migration, dan unlock harus memakai koneksi yang sama.
+// Lock otomatis dilepas PostgreSQL jika proses berhenti di tengah jalan.
+func (r *Runner) withLock(fn func(conn *gorm.DB) error) error {
+	return r.db.Connection(func(conn *gorm.DB) error {
+		var locked bool
+		if err := conn.Raw("SELECT pg_try_advisory_lock(?)", advisoryLockID).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("gagal mengambil advisory lock migration: %w", err)
+		}
+		if !locked {
+			log.Println("[DB] Migration sedang dijalankan proses lain, menunggu advisory lock...")
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", advisoryLockID).Error; err != nil {
+				return fmt.Errorf("gagal mengambil advisory lock migration: %w", err)
+			}
+		}
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", advisoryLockID).Error; err != nil {
+				log.Printf("[DB] Warning: Gagal melepas advisory lock migration: %v", err)
+			}
+		}()
+
+		if err := r.ensureTable(conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+// run menjalankan satu arah migration beserta pencatatan versinya
+// Dalam transaksi kecuali migration ditandai NoTransaction
+func (r *Runner) run(conn *gorm.DB, m Migration, migrate, record func(tx *gorm.DB) error) error {
+	apply := func(tx *gorm.DB) error {
+		if err := migrate(tx); err != nil {
+			return err
+		}
+		return record(tx)
+	}
+
+	if m.NoTransaction {
+		return apply(conn)
+	}
+	return conn.Transaction(apply)
+}
+
+// ensureTable membuat tabel schema_migrations jika belum ada
+func (r *Runner) ensureTable(db *gorm.DB) error {
+	createSQL := `
+		CREATE TABLE IF NOT EXISTS ` + schemaMigrationsTable + ` (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`
+	if err := db.Exec(createSQL).Error; err != nil {
+		return fmt.Errorf("gagal membuat tabel %s: %w", schemaMigrationsTable, err)
+	}
+	return nil
+}
+
+// appliedVersions mengambil versi migration yang sudah diterapkan, diindeks per versi
+func (r *Runner) appliedVersions(db *gorm.DB) (map[uint]appliedMigration, error) {
+	var rows []appliedMigration
+	if err := db.Table(schemaMigrationsTable).Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("gagal membaca tabel %s: %w", schemaMigrationsTable, err)
+	}
+
+	applied := make(map[uint]appliedMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// warnUnknownVersions mencatat peringatan untuk versi di database yang tidak dikenal aplikasi ini
+func (r *Runner) warnUnknownVersions(appliedVersions map[uint]appliedMigration) {
+	for version, applied := range appliedVersions {
+		if _, ok := r.find(version); !ok {
+			log.Printf("[DB] Warning: Migration %04d_%s tercatat di database tetapi tidak dikenal aplikasi ini", version, applied.Name)
+		}
+	}
+}
+
+// find mencari migration terdaftar berdasarkan versi
+func (r *Runner) find(version uint) (Migration, bool) {
+	for _, m := range r.migrations {
+		if m.Version == version {
+			return m, true
+		}
+	}
+	return Migration{}, false
+}
+
+// validate memastikan registry migration terurut naik, tanpa versi ganda, dan memiliki Up
+func (r *Runner) validate() error {
+	var previous uint
+	for _, m := range r.migrations {
+		if m.Version == 0 || m.Version <= previous {
+			return fmt.Errorf("versi migration %s harus lebih besar dari versi sebelumnya (%d)", m.label(), previous)
+		}
+		if m.Name == "" || m.Up == nil {
+			return fmt.Errorf("migration versi %d harus memiliki nama dan fungsi Up", m.Version)
+		}
+		previous = m.Version
+	}
+	return nil
+}
+
+// label mengembalikan nama migration berformat 0001_nama untuk log
+func (m Migration) label() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
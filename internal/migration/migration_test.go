@@ -0,0 +1,71 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestRunnerValidate(t *testing.T) {
+	up := func(tx *gorm.DB) error { return nil }
+
+	tests := []struct {
+		name       string
+		migrations []Migration
+		wantErr    string
+	}{
+		{
+			name:       "registry",
+			migrations: registry,
+		},
+		{
+			name:       "kosong",
+			migrations: nil,
+		},
+		{
+			name:       "versi berurutan dengan celah",
+			migrations: []Migration{{Version: 1, Name: "a", Up: up}, {Version: 3, Name: "b", Up: up}},
+		},
+		{
+			name:       "versi nol",
+			migrations: []Migration{{Version: 0, Name: "a", Up: up}},
+			wantErr:    "harus lebih besar",
+		},
+		{
+			name:       "versi duplikat",
+			migrations: []Migration{{Version: 1, Name: "a", Up: up}, {Version: 1, Name: "b", Up: up}},
+			wantErr:    "harus lebih besar",
+		},
+		{
+			name:       "versi tidak berurutan",
+			migrations: []Migration{{Version: 2, Name: "a", Up: up}, {Version: 1, Name: "b", Up: up}},
+			wantErr:    "harus lebih besar",
+		},
+		{
+			name:       "tanpa nama",
+			migrations: []Migration{{Version: 1, Up: up}},
+			wantErr:    "harus memiliki nama",
+		},
+		{
+			name:       "tanpa fungsi Up",
+			migrations: []Migration{{Version: 1, Name: "a"}},
+			wantErr:    "harus memiliki nama dan fungsi Up",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Runner{migrations: tt.migrations}).validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validate() error = %v, want mengandung %q", err, tt.wantErr)
+			}
+		})
+	}
+}
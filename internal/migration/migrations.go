@@ -0,0 +1,15 @@
+package migration
+
+// registry adalah daftar semua migration berurutan berdasarkan versi.
+// Migration baru ditulis di file NNNN_nama.go lalu ditambahkan di akhir daftar ini.
+// Migration yang sudah dirilis tidak boleh diubah; perbaikan skema dibuat sebagai migration baru.
+var registry = []Migration{
+	baselineMigration,
+	healthDataUserRecordDateIndexMigration,
+	usersDisabledAtMigration,
+	jobRunsMigration,
+	clinicalThresholdLabReferencesMigration,
+	clinicalThresholdOxygenSaturationCriticalMigration,
+	clinicalThresholdTemperatureCriticalMigration,
+	healthAlertsUserCategoryRecordDateUniqueMigration,
+}
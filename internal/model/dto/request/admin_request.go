@@ -0,0 +1,46 @@
+package request
+
+// UpdateUserRoleRequest untuk menangkap input JSON saat admin mengubah role user
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required"` // patient, clinician, admin
+}
+
+// AdminUserListRequest untuk query parameter daftar user (admin)
+type AdminUserListRequest struct {
+	// Filter role: "patient", "clinician", "admin" (opsional)
+	Role string `form:"role"`
+
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// AdminLockoutListRequest untuk query parameter daftar penguncian aktif (admin)
+type AdminLockoutListRequest struct {
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// AdminAuthAuditLogListRequest untuk query parameter daftar audit autentikasi (admin)
+type AdminAuthAuditLogListRequest struct {
+	// Filter event: "lockout", "unlock" (opsional)
+	Event string `form:"event"`
+
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// AdminJobRunListRequest untuk query parameter riwayat eksekusi job background (admin)
+type AdminJobRunListRequest struct {
+	// Filter nama job, contoh "cleanup_blacklisted_tokens" (opsional)
+	Job string `form:"job"`
+
+	// Filter status: "running", "success", "failed" (opsional)
+	Status string `form:"status"`
+
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
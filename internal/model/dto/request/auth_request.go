@@ -14,3 +14,31 @@ type LoginRequest struct {
 	Password   string `json:"password" binding:"required"`
 }
 
+// RefreshTokenRequest untuk menangkap input JSON saat memperbarui access token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ChangePasswordRequest untuk menangkap input JSON saat mengganti password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+	ConfirmPassword string `json:"confirm_password" binding:"required,min=6"`
+}
+
+// ForgotPasswordRequest untuk menangkap input JSON saat meminta reset password
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest untuk menangkap input JSON saat mengatur ulang password dengan token
+type ResetPasswordRequest struct {
+	Token           string `json:"token" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+	ConfirmPassword string `json:"confirm_password" binding:"required,min=6"`
+}
+
+// VerifyEmailRequest untuk menangkap input JSON saat verifikasi email
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,12 @@
+package request
+
+// InviteCaregiverRequest untuk menangkap input JSON saat pasien mengundang caregiver
+type InviteCaregiverRequest struct {
+	Identifier string `json:"identifier" binding:"required"` // Email atau username akun caregiver
+	Scope      string `json:"scope"`                         // "view" (default) atau "record"
+}
+
+// UpdateCareGrantRequest untuk menangkap input JSON saat pasien mengubah cakupan akses caregiver
+type UpdateCareGrantRequest struct {
+	Scope string `json:"scope" binding:"required"` // "view" atau "record"
+}
@@ -0,0 +1,47 @@
+package request
+
+// UpdateClinicalThresholdsRequest untuk menangkap input JSON saat mengubah batas klinis.
+// Semua field opsional; field yang tidak dikirim tidak diubah.
+type UpdateClinicalThresholdsRequest struct {
+	SystolicMin              *int     `json:"systolic_min" binding:"omitempty,gt=0"`
+	SystolicMax              *int     `json:"systolic_max" binding:"omitempty,gt=0"`
+	DiastolicMin             *int     `json:"diastolic_min" binding:"omitempty,gt=0"`
+	DiastolicMax             *int     `json:"diastolic_max" binding:"omitempty,gt=0"`
+	BloodSugarMin            *int     `json:"blood_sugar_min" binding:"omitempty,gt=0"`
+	BloodSugarMax            *int     `json:"blood_sugar_max" binding:"omitempty,gt=0"`
+	BloodSugarFastingMin     *int     `json:"blood_sugar_fasting_min" binding:"omitempty,gt=0"`
+	BloodSugarFastingMax     *int     `json:"blood_sugar_fasting_max" binding:"omitempty,gt=0"`
+	BloodSugarPostMealMin    *int     `json:"blood_sugar_post_meal_min" binding:"omitempty,gt=0"`
+	BloodSugarPostMealMax    *int     `json:"blood_sugar_post_meal_max" binding:"omitempty,gt=0"`
+	BloodSugarBedtimeMin     *int     `json:"blood_sugar_bedtime_min" binding:"omitempty,gt=0"`
+	BloodSugarBedtimeMax     *int     `json:"blood_sugar_bedtime_max" binding:"omitempty,gt=0"`
+	HeartRateMin             *int     `json:"heart_rate_min" binding:"omitempty,gt=0"`
+	HeartRateMax             *int     `json:"heart_rate_max" binding:"omitempty,gt=0"`
+	OxygenSaturationMin      *int     `json:"oxygen_saturation_min" binding:"omitempty,gt=0,lte=100"`
+	OxygenSaturationCritical *int     `json:"oxygen_saturation_critical" binding:"omitempty,gt=0,lte=100"`
+	TemperatureMin           *float64 `json:"temperature_min" binding:"omitempty,gt=0"`
+	TemperatureMax           *float64 `json:"temperature_max" binding:"omitempty,gt=0"`
+	TemperatureCritical      *float64 `json:"temperature_critical" binding:"omitempty,gt=0"`
+	RespiratoryRateMin       *int     `json:"respiratory_rate_min" binding:"omitempty,gt=0"`
+	RespiratoryRateMax       *int     `json:"respiratory_rate_max" binding:"omitempty,gt=0"`
+	BMIMin                   *float64 `json:"bmi_min" binding:"omitempty,gt=0"`
+	BMIMax                   *float64 `json:"bmi_max" binding:"omitempty,gt=0"`
+
+	// Rentang rujukan lab dalam satuan standar jenis pemeriksaan (lihat GET /api/health/lab-results/types)
+	HbA1cMin            *float64 `json:"hba1c_min" binding:"omitempty,gt=0"`
+	HbA1cMax            *float64 `json:"hba1c_max" binding:"omitempty,gt=0"`
+	TotalCholesterolMin *float64 `json:"total_cholesterol_min" binding:"omitempty,gt=0"`
+	TotalCholesterolMax *float64 `json:"total_cholesterol_max" binding:"omitempty,gt=0"`
+	LDLMin              *float64 `json:"ldl_min" binding:"omitempty,gt=0"`
+	LDLMax              *float64 `json:"ldl_max" binding:"omitempty,gt=0"`
+	HDLMin              *float64 `json:"hdl_min" binding:"omitempty,gt=0"`
+	HDLMax              *float64 `json:"hdl_max" binding:"omitempty,gt=0"`
+	TriglyceridesMin    *float64 `json:"triglycerides_min" binding:"omitempty,gt=0"`
+	TriglyceridesMax    *float64 `json:"triglycerides_max" binding:"omitempty,gt=0"`
+	UricAcidMin         *float64 `json:"uric_acid_min" binding:"omitempty,gt=0"`
+	UricAcidMax         *float64 `json:"uric_acid_max" binding:"omitempty,gt=0"`
+	CreatinineMin       *float64 `json:"creatinine_min" binding:"omitempty,gt=0"`
+	CreatinineMax       *float64 `json:"creatinine_max" binding:"omitempty,gt=0"`
+
+	Note *string `json:"note"` // Alasan penyesuaian, mis. "Kehamilan trimester 2"
+}
@@ -0,0 +1,13 @@
+package request
+
+// AssignPatientRequest untuk menangkap input JSON saat admin menghubungkan pasien ke clinician
+type AssignPatientRequest struct {
+	PatientID uint `json:"patient_id" binding:"required"`
+}
+
+// ClinicianDashboardRequest untuk query parameter dashboard clinician
+type ClinicianDashboardRequest struct {
+	Sort  string `form:"sort"` // risk (default), name, last_measured
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
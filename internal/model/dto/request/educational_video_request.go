@@ -7,3 +7,15 @@ type EducationalVideoRequest struct {
 	CategoryIDs []uint `json:"category_ids" binding:"required,min=1"` // Array ID kategori (minimal 1)
 }
 
+// CategoryRequest untuk menangkap input JSON saat menambah atau mengubah kategori
+type CategoryRequest struct {
+	Kategori string `json:"kategori" binding:"required,max=100"` // Nama kategori
+}
+
+// EducationalVideoImportRequest adalah satu data video edukasi dari file impor (cmd/admin)
+// Kategori dirujuk berdasarkan nama agar file dapat dipindahkan antar database
+type EducationalVideoImportRequest struct {
+	VideoTitle string   `json:"video_title"`
+	VideoURL   string   `json:"video_url"`
+	Categories []string `json:"categories"` // Nama kategori (minimal 1)
+}
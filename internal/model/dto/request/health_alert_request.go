@@ -11,3 +11,27 @@ type HealthAlertRequest struct {
 	RecordedAt time.Time `json:"recorded_at" binding:"required"` // Timestamp pengukuran
 }
 
+// HealthAlertHistoryRequest untuk filter riwayat health alert (query parameter)
+type HealthAlertHistoryRequest struct {
+	// Filter status hasil klasifikasi: "RENDAH" atau "TINGGI" (opsional)
+	Status string `form:"status"`
+
+	// Filter kategori: "diabetes", "hipertensi", "jantung", "berat_badan", "pernapasan", "suhu_tubuh" (opsional)
+	Category string `form:"category"`
+
+	// Filter status tindak lanjut: "unread", "read", "acknowledged", "resolved" (opsional)
+	State string `form:"state"`
+
+	// Filter rentang tanggal berdasarkan record_date (opsional, inklusif)
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date" time_format:"2006-01-02"`
+
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// ResolveHealthAlertRequest untuk menangkap input JSON saat menyelesaikan alert
+type ResolveHealthAlertRequest struct {
+	Note *string `json:"note" binding:"omitempty,max=1000"` // Catatan penyelesaian (opsional)
+}
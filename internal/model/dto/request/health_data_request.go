@@ -1,5 +1,7 @@
 package request
 
+import "time"
+
 // HealthDataRequest untuk menangkap input JSON saat input data kesehatan
 // 
 // MIGRASI NULLABLE-READY:
@@ -24,6 +26,11 @@ type HealthDataRequest struct {
 	// Gula darah (mg/dL) - nullable, validasi: 0-300 jika dikirim
 	BloodSugar *int `json:"blood_sugar"`
 	
+	// Konteks pengukuran gula darah - nullable, salah satu dari: puasa, setelah_makan, sewaktu, sebelum_tidur
+	// Hanya boleh dikirim bersama blood_sugar (atau saat update pembacaan yang sudah berisi gula darah).
+	// Default "sewaktu" jika blood_sugar dikirim tanpa konteks.
+	BloodSugarContext *string `json:"blood_sugar_context"`
+	
 	// Berat badan (kg) - nullable, validasi: 20-200 jika dikirim
 	Weight *float64 `json:"weight"`
 	
@@ -33,7 +40,82 @@ type HealthDataRequest struct {
 	// Detak jantung (bpm) - nullable, validasi: 0-180 jika dikirim
 	HeartRate *int `json:"heart_rate"`
 	
-	// Aktivitas terbaru (opsional) - sudah nullable dari awal
-	Activity *string `json:"activity"`
+	// Saturasi oksigen / SpO2 (%) - nullable, validasi: 50-100 jika dikirim
+	OxygenSaturation *int `json:"oxygen_saturation"`
+	
+	// Suhu tubuh (°C) - nullable, validasi: 30-45 jika dikirim
+	Temperature *float64 `json:"temperature"`
+	
+	// Laju napas (napas/menit) - nullable, validasi: 5-60 jika dikirim
+	RespiratoryRate *int `json:"respiratory_rate"`
+	
+	// Aktivitas fisik terstruktur (opsional) - nullable
+	// Saat update, aktivitas yang dikirim menggantikan seluruh aktivitas pada pembacaan
+	Activity *ActivityRequest `json:"activity"`
+	
+	// Catatan bebas (opsional) - maksimal 500 karakter, string kosong menghapus catatan saat update
+	Notes *string `json:"notes"`
+	
+	// Posisi tubuh saat ukur tekanan darah (opsional): duduk, berdiri, berbaring
+	// Hanya boleh dikirim bersama systolic/diastolic (atau saat update pembacaan yang sudah berisi tekanan darah)
+	BodyPosition *string `json:"body_position"`
+	
+	// Lengan yang dipakai saat ukur tekanan darah (opsional): kiri, kanan - aturan sama dengan body_position
+	MeasurementArm *string `json:"measurement_arm"`
+	
+	// Tag gejala (opsional), mis. ["pusing", "mual"] - array kosong menghapus gejala saat update
+	Symptoms *[]string `json:"symptoms"`
+	
+	// Kondisi saat pembacaan (opsional): bangun_tidur, istirahat, setelah_aktivitas,
+	// sebelum_minum_obat, setelah_minum_obat, stres, sedang_sakit
+	MeasurementContext *string `json:"measurement_context"`
+	
+	// Waktu pengukuran (RFC3339, opsional) - default waktu saat ini
+	// Untuk input data yang dicatat sebelumnya (backdate), tidak boleh di masa depan
+	MeasuredAt *time.Time `json:"measured_at"`
+}
+
+// ActivityRequest untuk menangkap input aktivitas fisik terstruktur
+// Kalori dihitung di service dari MET (jenis dan intensitas), durasi, dan berat badan user
+type ActivityRequest struct {
+	// Jenis aktivitas - wajib: jalan_kaki, lari, bersepeda, berenang, senam, yoga, angkat_beban, pekerjaan_rumah, lainnya
+	Type string `json:"type"`
+
+	// Durasi aktivitas dalam menit - wajib, validasi: 1-720
+	DurationMinutes int `json:"duration_minutes"`
+
+	// Intensitas - opsional: ringan, sedang, berat (default sedang)
+	Intensity *string `json:"intensity"`
+
+	// Jumlah langkah - opsional, validasi: 0-100000
+	Steps *int `json:"steps"`
+
+	// Jarak tempuh (km) - opsional, validasi: 0-300
+	DistanceKm *float64 `json:"distance_km"`
+}
+
+// HealthReadingsRequest untuk query parameter GET /api/health/readings
+type HealthReadingsRequest struct {
+	// Tanggal pembacaan (YYYY-MM-DD) - opsional, default hari ini
+	Date *time.Time `form:"date" time_format:"2006-01-02"`
 }
 
+// ReadingSearchRequest untuk query parameter GET /api/health/readings/search
+// Semua filter opsional dan digabung dengan AND
+type ReadingSearchRequest struct {
+	// Kata kunci pada catatan atau tag gejala
+	Query string `form:"q"`
+
+	// Tag gejala, mis. pusing
+	Symptom string `form:"symptom"`
+
+	// Konteks pengukuran, mis. bangun_tidur
+	MeasurementContext string `form:"measurement_context"`
+
+	// Rentang tanggal pembacaan (YYYY-MM-DD)
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date" time_format:"2006-01-02"`
+
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
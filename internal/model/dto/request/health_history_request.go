@@ -14,8 +14,13 @@ type HealthHistoryRequest struct {
 	EndDate   *time.Time `json:"end_date" form:"end_date" time_format:"2006-01-02"`
 
 	// Filter jenis metrik (bisa multiple)
-	// Opsi: "tekanan_darah", "gula_darah", "berat_badan", "aktivitas"
+	// Opsi: "tekanan_darah", "gula_darah", "berat_badan", "aktivitas", "saturasi_oksigen", "suhu_tubuh",
+	// "laju_napas", "kepatuhan_obat", "hasil_lab" (khusus laporan)
 	// Jika kosong, akan mengambil semua metrik
 	Metrics []string `json:"metrics" form:"metrics"`
+
+	// Format file laporan (hanya untuk endpoint download)
+	// Opsi: "pdf", "csv", "json". Jika kosong, ditentukan dari header Accept (default: "pdf")
+	Format string `json:"format" form:"format"`
 }
 
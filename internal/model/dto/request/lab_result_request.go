@@ -0,0 +1,63 @@
+package request
+
+import "time"
+
+// CreateLabResultRequest untuk menangkap input JSON saat mencatat hasil laboratorium
+type CreateLabResultRequest struct {
+	// Jenis pemeriksaan: hba1c, total_cholesterol, ldl, hdl, triglycerides, uric_acid, creatinine
+	TestType string `json:"test_type" binding:"required"`
+
+	// Nilai hasil pemeriksaan dalam satuan Unit
+	Value *float64 `json:"value" binding:"required"`
+
+	// Satuan nilai (opsional) - default satuan standar jenis pemeriksaan.
+	// Satuan lain (mis. mmol/L untuk kolesterol) dikonversi ke satuan standar.
+	Unit string `json:"unit"`
+
+	// Rentang rujukan yang tercantum pada hasil lab (opsional, dalam satuan Unit)
+	// Jika tidak dikirim, digunakan rentang rujukan standar
+	ReferenceMin *float64 `json:"reference_min"`
+	ReferenceMax *float64 `json:"reference_max"`
+
+	// Waktu pemeriksaan (RFC3339, opsional) - default waktu saat ini, tidak boleh di masa depan
+	TestedAt *time.Time `json:"tested_at"`
+
+	LabName *string `json:"lab_name" binding:"omitempty,max=100"`
+	Notes   *string `json:"notes" binding:"omitempty,max=1000"`
+}
+
+// UpdateLabResultRequest untuk menangkap input JSON saat mengoreksi hasil laboratorium.
+// Semua field opsional; field yang tidak dikirim tidak diubah. Jenis pemeriksaan tidak dapat diubah.
+type UpdateLabResultRequest struct {
+	Value        *float64   `json:"value"`
+	Unit         string     `json:"unit"` // Satuan untuk value dan rentang rujukan yang dikirim
+	ReferenceMin *float64   `json:"reference_min"`
+	ReferenceMax *float64   `json:"reference_max"`
+	TestedAt     *time.Time `json:"tested_at"`
+	LabName      *string    `json:"lab_name" binding:"omitempty,max=100"`
+	Notes        *string    `json:"notes" binding:"omitempty,max=1000"`
+}
+
+// LabResultListRequest untuk filter riwayat hasil laboratorium (query parameter)
+type LabResultListRequest struct {
+	// Filter jenis pemeriksaan (opsional)
+	TestType string `form:"test_type"`
+
+	// Filter rentang tanggal pemeriksaan (opsional, inklusif)
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date" time_format:"2006-01-02"`
+
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// LabResultTrendRequest untuk query parameter grafik tren hasil laboratorium
+type LabResultTrendRequest struct {
+	// Filter jenis pemeriksaan (opsional) - jika kosong, semua jenis yang memiliki data
+	TestType string `form:"test_type"`
+
+	// Rentang tanggal (opsional) - default 12 bulan terakhir
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date" time_format:"2006-01-02"`
+}
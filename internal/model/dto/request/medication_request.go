@@ -0,0 +1,92 @@
+package request
+
+import "time"
+
+// CreateMedicationRequest untuk menangkap input JSON saat menambahkan obat
+type CreateMedicationRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	Dose string `json:"dose" binding:"required,max=50"` // Dosis per minum, mis. "5 mg" atau "1 tablet"
+
+	// Jam minum obat setiap hari (format HH:MM, Asia/Jakarta), mis. ["07:00", "19:00"]
+	// Jika kosong, obat dianggap "bila perlu" dan tidak dihitung dalam kepatuhan
+	ScheduleTimes []string `json:"schedule_times" binding:"omitempty,max=12"`
+
+	// Tanggal mulai (YYYY-MM-DD, opsional) - default hari ini
+	StartDate *string `json:"start_date"`
+	// Tanggal terakhir minum obat (YYYY-MM-DD, opsional)
+	EndDate *string `json:"end_date"`
+
+	Instructions *string `json:"instructions" binding:"omitempty,max=500"`
+	Purpose      *string `json:"purpose" binding:"omitempty,max=100"`
+}
+
+// UpdateMedicationRequest untuk menangkap input JSON saat mengubah obat.
+// Semua field opsional; field yang tidak dikirim tidak diubah.
+// Kirim schedule_times berupa array kosong untuk menjadikan obat "bila perlu",
+// dan end_date berupa string kosong untuk menghapus tanggal terakhir.
+type UpdateMedicationRequest struct {
+	Name          *string   `json:"name" binding:"omitempty,max=100"`
+	Dose          *string   `json:"dose" binding:"omitempty,max=50"`
+	ScheduleTimes *[]string `json:"schedule_times" binding:"omitempty,max=12"`
+	StartDate     *string   `json:"start_date"`
+	EndDate       *string   `json:"end_date"`
+	Instructions  *string   `json:"instructions" binding:"omitempty,max=500"`
+	Purpose       *string   `json:"purpose" binding:"omitempty,max=100"`
+
+	// Menonaktifkan obat mengisi end_date dengan hari ini jika belum ada
+	IsActive *bool `json:"is_active"`
+}
+
+// MedicationListRequest untuk filter daftar obat (query parameter)
+type MedicationListRequest struct {
+	// Filter status obat (opsional): true = aktif saja, false = nonaktif saja
+	IsActive *bool `form:"is_active"`
+
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// LogMedicationDoseRequest untuk menangkap input JSON saat mencatat dosis diminum atau terlewat
+// Mencatat ulang jadwal yang sama memperbarui status sebelumnya
+type LogMedicationDoseRequest struct {
+	// Jadwal minum obat yang dicatat (RFC3339), harus sesuai salah satu jam jadwal obat
+	// dan tidak boleh di masa depan
+	ScheduledAt *time.Time `json:"scheduled_at" binding:"required"`
+
+	// Status: "taken" atau "missed"
+	Status string `json:"status" binding:"required,oneof=taken missed"`
+
+	// Waktu obat benar-benar diminum (RFC3339, opsional, hanya untuk status taken) - default waktu saat ini
+	TakenAt *time.Time `json:"taken_at"`
+
+	Notes *string `json:"notes" binding:"omitempty,max=500"`
+}
+
+// MedicationLogListRequest untuk filter riwayat minum obat (query parameter)
+type MedicationLogListRequest struct {
+	// Filter rentang tanggal jadwal (opsional, inklusif)
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date" time_format:"2006-01-02"`
+
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// MedicationScheduleRequest untuk query parameter jadwal minum obat harian
+type MedicationScheduleRequest struct {
+	// Tanggal jadwal (opsional, format YYYY-MM-DD) - default hari ini
+	Date *time.Time `form:"date" time_format:"2006-01-02"`
+}
+
+// MedicationAdherenceRequest untuk query parameter kepatuhan minum obat
+// Rentang waktu sama dengan riwayat kesehatan
+type MedicationAdherenceRequest struct {
+	// Opsi: "7days", "30days", "3months", "custom" (default: "7days")
+	// Jika "custom", wajib isi StartDate dan EndDate
+	TimeRange string `form:"time_range"`
+
+	StartDate *time.Time `form:"start_date" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"end_date" time_format:"2006-01-02"`
+}
@@ -0,0 +1,15 @@
+package request
+
+// NotificationListRequest untuk filter inbox notifikasi (query parameter)
+type NotificationListRequest struct {
+	// Hanya notifikasi yang belum dibaca (opsional, default false)
+	UnreadOnly bool `form:"unread_only"`
+
+	// Filter jenis notifikasi (opsional): medication_reminder, measurement_reminder, missed_day_nudge,
+	// health_alert, target_achieved
+	Type string `form:"type"`
+
+	// Pagination (default: page 1, limit 20, maksimal limit 100)
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
@@ -0,0 +1,75 @@
+package response
+
+import "time"
+
+// AdminUserResponse adalah data user yang ditampilkan untuk admin
+type AdminUserResponse struct {
+	ID            uint       `json:"id"`
+	Nama          string     `json:"nama"`
+	Username      string     `json:"username"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	EmailVerified bool       `json:"email_verified"`
+	DisabledAt    *time.Time `json:"disabled_at,omitempty"` // Waktu akun dinonaktifkan, kosong jika akun aktif
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// AdminUserStatsResponse adalah ringkasan jumlah data milik satu user (cmd/admin user-stats)
+type AdminUserStatsResponse struct {
+	User                    AdminUserResponse `json:"user"`
+	HealthDataCount         int64             `json:"health_data_count"`
+	FirstRecordDate         *string           `json:"first_record_date,omitempty"` // Format YYYY-MM-DD
+	LastRecordDate          *string           `json:"last_record_date,omitempty"`  // Format YYYY-MM-DD
+	HealthAlertCount        int64             `json:"health_alert_count"`
+	LabResultCount          int64             `json:"lab_result_count"`
+	MedicationCount         int64             `json:"medication_count"`
+	ActiveMedicationCount   int64             `json:"active_medication_count"`
+	MedicationLogCount      int64             `json:"medication_log_count"`
+	NotificationCount       int64             `json:"notification_count"`
+	UnreadNotificationCount int64             `json:"unread_notification_count"`
+	ActiveSessionCount      int64             `json:"active_session_count"`
+	ActiveCareGrantCount    int64             `json:"active_care_grant_count"`
+}
+
+// AdminUserListResponse adalah response untuk endpoint GET /api/admin/users
+type AdminUserListResponse struct {
+	Users      []AdminUserResponse `json:"users"`
+	Pagination PaginationResponse  `json:"pagination"`
+}
+
+// AdminLockoutResponse adalah data penguncian percobaan autentikasi yang masih berlaku
+type AdminLockoutResponse struct {
+	ID            uint       `json:"id"`
+	Action        string     `json:"action"`
+	Scope         string     `json:"scope"`
+	Key           string     `json:"key"`
+	FailureCount  int        `json:"failure_count"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+}
+
+// AdminLockoutListResponse adalah response untuk endpoint GET /api/admin/lockouts
+type AdminLockoutListResponse struct {
+	Lockouts   []AdminLockoutResponse `json:"lockouts"`
+	Pagination PaginationResponse     `json:"pagination"`
+}
+
+// AdminAuthAuditLogResponse adalah data audit autentikasi
+type AdminAuthAuditLogResponse struct {
+	ID        uint      `json:"id"`
+	Event     string    `json:"event"`
+	Action    string    `json:"action"`
+	Scope     string    `json:"scope"`
+	Key       string    `json:"key"`
+	UserID    *uint     `json:"user_id,omitempty"`
+	ActorID   *uint     `json:"actor_id,omitempty"`
+	IPAddress string    `json:"ip_address"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AdminAuthAuditLogListResponse adalah response untuk endpoint GET /api/admin/auth-audit-logs
+type AdminAuthAuditLogListResponse struct {
+	AuditLogs  []AdminAuthAuditLogResponse `json:"audit_logs"`
+	Pagination PaginationResponse          `json:"pagination"`
+}
@@ -1,17 +1,49 @@
 package response
 
+import "time"
+
 // RegisterResponse untuk mengirim balik data setelah pendaftaran
 type RegisterResponse struct {
-	Message string `json:"message"`
-	Nama    string `json:"nama"`
-	Email   string `json:"email"`
+	Message       string `json:"message"`
+	Nama          string `json:"nama"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 // LoginResponse untuk mengirim balik data ke Flutter
 type LoginResponse struct {
-	Token    string `json:"token"`
-	Nama     string `json:"nama"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	Token            string    `json:"token"`
+	RefreshToken     string    `json:"refresh_token"`
+	ExpiresIn        int64     `json:"expires_in"` // Masa berlaku access token dalam detik
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	Nama             string    `json:"nama"`
+	Username         string    `json:"username"`
+	Email            string    `json:"email"`
+	Role             string    `json:"role"`
+	EmailVerified    bool      `json:"email_verified"`
+}
+
+// TokenResponse adalah pasangan access token dan refresh token hasil login/refresh
+type TokenResponse struct {
+	Token            string    `json:"token"`
+	RefreshToken     string    `json:"refresh_token"`
+	ExpiresIn        int64     `json:"expires_in"` // Masa berlaku access token dalam detik
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// SessionResponse adalah data sesi login aktif milik user
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // true jika sesi ini dipakai oleh request saat ini
+}
+
+// RevokeAllSessionsResponse adalah response untuk pencabutan semua sesi
+type RevokeAllSessionsResponse struct {
+	RevokedCount int64 `json:"revoked_count"`
 }
 
@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+// CareUserSummary adalah data singkat user pada akses caregiver
+type CareUserSummary struct {
+	ID       uint   `json:"id"`
+	Nama     string `json:"nama"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// CareGrantResponse adalah data akses caregiver
+type CareGrantResponse struct {
+	ID         uint             `json:"id"`
+	Owner      *CareUserSummary `json:"owner,omitempty"`     // Pasien pemilik data
+	Caregiver  *CareUserSummary `json:"caregiver,omitempty"` // Akun yang diberi akses
+	Scope      string           `json:"scope"`
+	Status     string           `json:"status"`
+	AcceptedAt *time.Time       `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time       `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,52 @@
+package response
+
+import "time"
+
+// ClinicalThresholdsResponse adalah batas rentang normal yang berlaku
+type ClinicalThresholdsResponse struct {
+	SystolicMin              int     `json:"systolic_min"`
+	SystolicMax              int     `json:"systolic_max"`
+	DiastolicMin             int     `json:"diastolic_min"`
+	DiastolicMax             int     `json:"diastolic_max"`
+	BloodSugarMin            int     `json:"blood_sugar_min"`
+	BloodSugarMax            int     `json:"blood_sugar_max"` // Gula darah sewaktu
+	BloodSugarFastingMin     int     `json:"blood_sugar_fasting_min"`
+	BloodSugarFastingMax     int     `json:"blood_sugar_fasting_max"`
+	BloodSugarPostMealMin    int     `json:"blood_sugar_post_meal_min"` // 2 jam setelah makan
+	BloodSugarPostMealMax    int     `json:"blood_sugar_post_meal_max"`
+	BloodSugarBedtimeMin     int     `json:"blood_sugar_bedtime_min"`
+	BloodSugarBedtimeMax     int     `json:"blood_sugar_bedtime_max"`
+	HeartRateMin             int     `json:"heart_rate_min"`
+	HeartRateMax             int     `json:"heart_rate_max"`
+	OxygenSaturationMin      int     `json:"oxygen_saturation_min"`      // SpO2 normal: nilai ≥ oxygen_saturation_min
+	OxygenSaturationCritical int     `json:"oxygen_saturation_critical"` // SpO2 < oxygen_saturation_critical dianggap gawat darurat
+	TemperatureMin           float64 `json:"temperature_min"`
+	TemperatureMax           float64 `json:"temperature_max"`
+	TemperatureCritical      float64 `json:"temperature_critical"` // Suhu ≥ temperature_critical dianggap demam tinggi
+	RespiratoryRateMin       int     `json:"respiratory_rate_min"`
+	RespiratoryRateMax       int     `json:"respiratory_rate_max"`
+	BMIMin                   float64 `json:"bmi_min"`
+	BMIMax                   float64 `json:"bmi_max"` // BMI normal: bmi_min ≤ BMI < bmi_max
+
+	// Rentang rujukan lab (satuan standar jenis pemeriksaan), null jika tidak ada batas di sisi itu
+	HbA1cMin            *float64 `json:"hba1c_min"`
+	HbA1cMax            *float64 `json:"hba1c_max"`
+	TotalCholesterolMin *float64 `json:"total_cholesterol_min"`
+	TotalCholesterolMax *float64 `json:"total_cholesterol_max"`
+	LDLMin              *float64 `json:"ldl_min"`
+	LDLMax              *float64 `json:"ldl_max"`
+	HDLMin              *float64 `json:"hdl_min"`
+	HDLMax              *float64 `json:"hdl_max"`
+	TriglyceridesMin    *float64 `json:"triglycerides_min"`
+	TriglyceridesMax    *float64 `json:"triglycerides_max"`
+	UricAcidMin         *float64 `json:"uric_acid_min"`
+	UricAcidMax         *float64 `json:"uric_acid_max"`
+	CreatinineMin       *float64 `json:"creatinine_min"`
+	CreatinineMax       *float64 `json:"creatinine_max"`
+
+	Source     string     `json:"source"`     // "default" atau "patient" (ada penyesuaian untuk pasien)
+	Customized bool       `json:"customized"` // true jika ada batas yang berbeda dari standar WHO
+	Note       *string    `json:"note,omitempty"`
+	UpdatedBy  *uint      `json:"updated_by,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
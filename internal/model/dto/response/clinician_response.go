@@ -0,0 +1,52 @@
+package response
+
+import "time"
+
+// ClinicianLatestReadings adalah nilai terakhir setiap metrik pasien
+type ClinicianLatestReadings struct {
+	Systolic          *int     `json:"systolic,omitempty"`
+	Diastolic         *int     `json:"diastolic,omitempty"`
+	BloodSugar        *int     `json:"blood_sugar,omitempty"`
+	BloodSugarContext *string  `json:"blood_sugar_context,omitempty"` // Konteks pengukuran gula darah terakhir
+	Weight            *float64 `json:"weight,omitempty"`
+	BMI               *float64 `json:"bmi,omitempty"`
+	HeartRate         *int     `json:"heart_rate,omitempty"`
+	OxygenSaturation  *int     `json:"oxygen_saturation,omitempty"`
+	Temperature       *float64 `json:"temperature,omitempty"`
+	RespiratoryRate   *int     `json:"respiratory_rate,omitempty"`
+}
+
+// ClinicianPatientStatus adalah status klasifikasi (RENDAH/NORMAL/TINGGI) per metrik
+type ClinicianPatientStatus struct {
+	BloodPressure    string `json:"blood_pressure,omitempty"`
+	BloodSugar       string `json:"blood_sugar,omitempty"`
+	BMI              string `json:"bmi,omitempty"`
+	HeartRate        string `json:"heart_rate,omitempty"`
+	OxygenSaturation string `json:"oxygen_saturation,omitempty"`
+	Temperature      string `json:"temperature,omitempty"`
+	RespiratoryRate  string `json:"respiratory_rate,omitempty"`
+}
+
+// ClinicianPatientSummary adalah ringkasan kondisi satu pasien pada dashboard clinician
+type ClinicianPatientSummary struct {
+	PatientID                uint                     `json:"patient_id"`
+	Nama                     string                   `json:"nama"`
+	Username                 string                   `json:"username"`
+	Email                    string                   `json:"email"`
+	LatestReadings           *ClinicianLatestReadings `json:"latest_readings,omitempty"`
+	Status                   ClinicianPatientStatus   `json:"status"`
+	UnreadAlertCount         int64                    `json:"unread_alert_count"`
+	LastMeasuredAt           *time.Time               `json:"last_measured_at,omitempty"`
+	DaysSinceLastMeasurement *int                     `json:"days_since_last_measurement,omitempty"`
+	Targets                  *HealthTargetsResponse   `json:"targets,omitempty"`
+	RiskScore                int                      `json:"risk_score"`
+	RiskLevel                string                   `json:"risk_level"` // TINGGI, SEDANG, RENDAH
+}
+
+// ClinicianDashboardResponse untuk GET /clinician/dashboard
+type ClinicianDashboardResponse struct {
+	TotalPatients int                       `json:"total_patients"`
+	Sort          string                    `json:"sort"`
+	Patients      []ClinicianPatientSummary `json:"patients"`
+	Pagination    PaginationResponse        `json:"pagination"`
+}
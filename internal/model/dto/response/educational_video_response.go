@@ -1,7 +1,10 @@
 package response
 
+import "time"
+
 // EducationalVideoItem adalah item video dalam response
 type EducationalVideoItem struct {
+	ID    uint   `json:"id"`
 	Title string `json:"title"`
 	URL   string `json:"url"`
 }
@@ -33,3 +36,31 @@ type AddEducationalVideoResponse struct {
 	CategoryIDs []uint `json:"category_ids"`
 }
 
+// CategoryResponse adalah response untuk endpoint manajemen kategori
+type CategoryResponse struct {
+	ID        uint      `json:"id"`
+	Kategori  string    `json:"kategori"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EducationalVideoExportItem adalah satu video edukasi pada file ekspor (cmd/admin)
+// Formatnya sama dengan EducationalVideoImportRequest sehingga hasil ekspor dapat langsung diimpor
+type EducationalVideoExportItem struct {
+	VideoTitle string   `json:"video_title"`
+	VideoURL   string   `json:"video_url"`
+	Categories []string `json:"categories"`
+}
+
+// CategoryExportItem adalah satu kategori pada file ekspor (cmd/admin)
+type CategoryExportItem struct {
+	Kategori string `json:"kategori"`
+}
+
+// ContentImportResponse adalah hasil impor video edukasi atau kategori
+// Data yang sudah ada dilewati (skipped); data tidak valid dicatat di errors tanpa menghentikan impor
+type ContentImportResponse struct {
+	Created int      `json:"created"`
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors,omitempty"`
+}
@@ -30,3 +30,43 @@ type CheckHealthAlertsResponse struct {
 	Alerts []HealthAlertResponse `json:"alerts"`
 }
 
+// HealthAlertHistoryItem adalah satu alert tersimpan dalam riwayat alert
+type HealthAlertHistoryItem struct {
+	ID               uint       `json:"id"`
+	HealthDataID     *uint      `json:"health_data_id,omitempty"`
+	AlertType        string     `json:"alert_type"`
+	Category         string     `json:"category"`
+	Value            string     `json:"value"`
+	Label            string     `json:"label"`
+	Status           string     `json:"status"`
+	Explanation      string     `json:"explanation"`
+	ImmediateActions []string   `json:"immediate_actions"`
+	MedicalAttention []string   `json:"medical_attention"`
+	ManagementTips   []string   `json:"management_tips"`
+	RecordDate       string     `json:"record_date"` // Format: YYYY-MM-DD
+	RecordedAt       time.Time  `json:"recorded_at"`
+	State            string     `json:"state"` // unread, read, acknowledged, resolved
+	ReadAt           *time.Time `json:"read_at"`
+	AcknowledgedAt   *time.Time `json:"acknowledged_at"`
+	ResolvedAt       *time.Time `json:"resolved_at"`
+	ResolutionNote   *string    `json:"resolution_note"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// HealthAlertHistoryResponse adalah response untuk endpoint GET /api/health/alerts
+type HealthAlertHistoryResponse struct {
+	Alerts      []HealthAlertHistoryItem `json:"alerts"`
+	UnreadCount int64                    `json:"unread_count"`
+	Pagination  PaginationResponse       `json:"pagination"`
+}
+
+// HealthAlertUnreadCountResponse adalah response untuk endpoint GET /api/health/alerts/unread-count
+type HealthAlertUnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// MarkAllHealthAlertsReadResponse adalah response untuk endpoint PUT /api/health/alerts/read-all
+type MarkAllHealthAlertsReadResponse struct {
+	UpdatedCount int64 `json:"updated_count"`
+}
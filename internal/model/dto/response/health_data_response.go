@@ -16,11 +16,47 @@ type HealthDataResponse struct {
 	Systolic   *int       `json:"systolic,omitempty"`
 	Diastolic  *int       `json:"diastolic,omitempty"`
 	BloodSugar *int       `json:"blood_sugar,omitempty"`
+	BloodSugarContext *string `json:"blood_sugar_context,omitempty"` // puasa, setelah_makan, sewaktu, sebelum_tidur
 	Weight     *float64   `json:"weight,omitempty"`
 	Height     *int       `json:"height,omitempty"`
 	HeartRate  *int       `json:"heart_rate,omitempty"`
-	Activity   *string    `json:"activity,omitempty"`
+	OxygenSaturation *int     `json:"oxygen_saturation,omitempty"` // SpO2 (%)
+	Temperature      *float64 `json:"temperature,omitempty"`       // Suhu tubuh (°C)
+	RespiratoryRate  *int     `json:"respiratory_rate,omitempty"`  // Laju napas (napas/menit)
+	Activity   *ActivityResponse `json:"activity,omitempty"`
 	
+	// Catatan dan konteks pembacaan
+	Notes              *string  `json:"notes,omitempty"`
+	BodyPosition       *string  `json:"body_position,omitempty"`       // duduk, berdiri, berbaring
+	MeasurementArm     *string  `json:"measurement_arm,omitempty"`     // kiri, kanan
+	Symptoms           []string `json:"symptoms,omitempty"`            // Tag gejala
+	MeasurementContext *string  `json:"measurement_context,omitempty"` // bangun_tidur, istirahat, ...
+	
+	MeasuredAt time.Time  `json:"measured_at"` // Waktu pengukuran
 	CreatedAt  time.Time  `json:"created_at"`
 }
 
+// ActivityResponse berisi aktivitas fisik pada satu pembacaan
+// Data lama (teks bebas sebelum aktivitas terstruktur) hanya berisi Description
+type ActivityResponse struct {
+	Type            *string  `json:"type,omitempty"`             // jalan_kaki, lari, bersepeda, ...
+	TypeLabel       *string  `json:"type_label,omitempty"`       // Nama jenis aktivitas, mis. "Jalan Kaki"
+	DurationMinutes *int     `json:"duration_minutes,omitempty"` // Durasi (menit)
+	Intensity       *string  `json:"intensity,omitempty"`        // ringan, sedang, berat
+	Steps           *int     `json:"steps,omitempty"`            // Jumlah langkah
+	DistanceKm      *float64 `json:"distance_km,omitempty"`      // Jarak tempuh (km)
+	CaloriesBurned  *float64 `json:"calories_burned,omitempty"`  // Estimasi kalori (kkal)
+	Description     *string  `json:"description,omitempty"`      // Deskripsi teks bebas (data lama)
+}
+
+// HealthReadingsResponse berisi semua pembacaan data kesehatan pada satu tanggal
+type HealthReadingsResponse struct {
+	Date     string               `json:"date"` // Format: YYYY-MM-DD
+	Readings []HealthDataResponse `json:"readings"`
+}
+
+// ReadingSearchResponse berisi hasil pencarian pembacaan berdasarkan catatan, gejala, atau konteks pengukuran
+type ReadingSearchResponse struct {
+	Readings   []HealthDataResponse `json:"readings"`
+	Pagination PaginationResponse   `json:"pagination"`
+}
@@ -7,6 +7,7 @@ type HealthHistoryResponse struct {
 	Summary        HealthSummaryResponse      `json:"summary"`         // Ringkasan statistik (untuk internal service)
 	TrendCharts    TrendChartsResponse        `json:"trend_charts"`    // Data grafik tren
 	ReadingHistory []ReadingHistoryResponse   `json:"reading_history"` // Catatan pembacaan kronologis (flat, untuk internal service)
+	MedicationAdherence *MedicationAdherenceCorrelation `json:"medication_adherence,omitempty"` // Kepatuhan minum obat & korelasinya (nil jika tidak ada obat terjadwal)
 }
 
 // HealthHistorySummaryByRange membungkus ringkasan statistik per rentang waktu
@@ -71,6 +72,7 @@ type HealthHistoryAPIResponse struct {
 	Summary        HealthHistorySummaryByRange  `json:"summary"`
 	TrendCharts    TrendChartsResponse          `json:"trend_charts"`
 	ReadingHistory HealthReadingHistoryByRange  `json:"reading_history"`
+	MedicationAdherence *MedicationAdherenceCorrelation `json:"medication_adherence,omitempty"` // Kepatuhan minum obat pada rentang time_range
 }
 
 // HealthSummaryResponse berisi ringkasan statistik untuk semua metrik
@@ -79,6 +81,9 @@ type HealthSummaryResponse struct {
 	BloodSugar    *BloodSugarSummary    `json:"blood_sugar,omitempty"`    // Ringkasan gula darah
 	Weight        *WeightSummary        `json:"weight,omitempty"`         // Ringkasan berat badan
 	Activity      *ActivitySummary     `json:"activity,omitempty"`       // Ringkasan aktivitas
+	OxygenSaturation *VitalSignSummary `json:"oxygen_saturation,omitempty"` // Ringkasan saturasi oksigen (SpO2)
+	Temperature      *VitalSignSummary `json:"temperature,omitempty"`       // Ringkasan suhu tubuh
+	RespiratoryRate  *VitalSignSummary `json:"respiratory_rate,omitempty"`  // Ringkasan laju napas
 }
 
 // BloodPressureSummary ringkasan statistik tekanan darah
@@ -95,8 +100,19 @@ type BloodPressureSummary struct {
 type BloodSugarSummary struct {
 	AvgValue      float64 `json:"avg_value"`       // Rata-rata gula darah
 	ChangePercent float64 `json:"change_percent"`  // Persentase perubahan
-	Status        string  `json:"status"`          // Status: RENDAH / NORMAL / TINGGI (WHO)
-	NormalRange   string  `json:"normal_range"`    // Rentang normal: "70-140 mg/dL (WHO - Gula Darah Sewaktu)"
+	Status        string  `json:"status"`          // Status: RENDAH / NORMAL / TINGGI, mengikuti konteks yang paling perlu diperhatikan
+	NormalRange   string  `json:"normal_range"`    // Rentang normal: "70-140 mg/dL (WHO - Gula Darah Sewaktu)" jika hanya satu konteks
+	ByContext     []BloodSugarContextSummary `json:"by_context"` // Ringkasan per konteks pengukuran
+}
+
+// BloodSugarContextSummary ringkasan gula darah untuk satu konteks pengukuran
+type BloodSugarContextSummary struct {
+	Context     string  `json:"context"`      // puasa, setelah_makan, sewaktu, sebelum_tidur
+	Label       string  `json:"label"`        // Nama konteks, mis. "2 Jam Setelah Makan"
+	AvgValue    float64 `json:"avg_value"`    // Rata-rata gula darah pada konteks ini
+	Count       int     `json:"count"`        // Jumlah pembacaan
+	Status      string  `json:"status"`       // Status: RENDAH / NORMAL / TINGGI
+	NormalRange string  `json:"normal_range"` // Rentang normal untuk konteks ini
 }
 
 // WeightSummary ringkasan statistik berat badan
@@ -109,9 +125,23 @@ type WeightSummary struct {
 
 // ActivitySummary ringkasan statistik aktivitas
 type ActivitySummary struct {
-	TotalSteps     int     `json:"total_steps"`      // Total langkah
-	TotalCalories  float64 `json:"total_calories"`   // Total kalori
-	ChangePercent  float64 `json:"change_percent"`   // Persentase perubahan aktivitas
+	TotalSteps     int     `json:"total_steps"`      // Total langkah (hanya aktivitas yang mencatat langkah)
+	TotalCalories  float64 `json:"total_calories"`   // Total estimasi kalori (MET x berat badan x durasi)
+	TotalDurationMinutes int     `json:"total_duration_minutes"` // Total durasi aktivitas (menit)
+	TotalDistanceKm      float64 `json:"total_distance_km"`      // Total jarak tempuh (km)
+	ActiveDays           int     `json:"active_days"`            // Jumlah hari dengan aktivitas
+	SessionCount         int     `json:"session_count"`          // Jumlah aktivitas yang dicatat
+	ChangePercent  float64 `json:"change_percent"`   // Persentase perubahan kalori harian
+}
+
+// VitalSignSummary ringkasan statistik tanda vital (saturasi oksigen, suhu tubuh, laju napas)
+type VitalSignSummary struct {
+	AvgValue      float64 `json:"avg_value"`      // Rata-rata nilai harian
+	MinValue      float64 `json:"min_value"`      // Nilai terendah pada periode
+	MaxValue      float64 `json:"max_value"`      // Nilai tertinggi pada periode
+	ChangePercent float64 `json:"change_percent"` // Persentase perubahan
+	Status        string  `json:"status"`         // Status: RENDAH / NORMAL / TINGGI berdasarkan rata-rata
+	NormalRange   string  `json:"normal_range"`   // Rentang normal, mis. "95-100% (WHO)"
 }
 
 // TrendChartsResponse berisi data time-series untuk grafik tren dengan filter waktu
@@ -120,6 +150,9 @@ type TrendChartsResponse struct {
 	BloodSugar    BloodSugarTrendCharts    `json:"blood_sugar,omitempty"`    // Data tren gula darah
 	Weight        WeightTrendCharts        `json:"weight,omitempty"`         // Data tren berat badan
 	Activity      ActivityTrendCharts      `json:"activity,omitempty"`       // Data tren aktivitas
+	OxygenSaturation VitalSignTrendCharts `json:"oxygen_saturation,omitempty"` // Data tren saturasi oksigen (SpO2)
+	Temperature      VitalSignTrendCharts `json:"temperature,omitempty"`       // Data tren suhu tubuh
+	RespiratoryRate  VitalSignTrendCharts `json:"respiratory_rate,omitempty"`  // Data tren laju napas
 }
 
 // BloodPressureTrendCharts berisi data tren tekanan darah dengan filter waktu
@@ -150,6 +183,13 @@ type ActivityTrendCharts struct {
 	Months3  []ActivityTrendPointMonth `json:"3Months"`  // Data 90 hari terakhir (per bulan)
 }
 
+// VitalSignTrendCharts berisi data tren tanda vital dengan filter waktu
+type VitalSignTrendCharts struct {
+	Days7   []VitalSignTrendPoint      `json:"7Days"`   // Data 7 hari terakhir (per hari)
+	Month1  []VitalSignTrendPointWeek  `json:"1Month"`  // Data 30 hari terakhir (per minggu)
+	Months3 []VitalSignTrendPointMonth `json:"3Months"` // Data 90 hari terakhir (per bulan)
+}
+
 // BloodPressureTrendPoint satu titik data untuk grafik tekanan darah (7Days - per hari)
 type BloodPressureTrendPoint struct {
 	Date      string  `json:"date"`       // Tanggal (format: YYYY-MM-DD)
@@ -218,6 +258,7 @@ type ActivityTrendPoint struct {
 	Date     string  `json:"date"`      // Tanggal (format: YYYY-MM-DD)
 	Steps    int     `json:"steps"`     // Total langkah hari itu
 	Calories float64 `json:"calories"`  // Total kalori hari itu
+	DurationMinutes int `json:"duration_minutes"` // Total durasi aktivitas hari itu (menit)
 }
 
 // ActivityTrendPointWeek satu titik data untuk grafik aktivitas (1Month - per minggu)
@@ -227,6 +268,7 @@ type ActivityTrendPointWeek struct {
 	EndDate   string  `json:"end_date"`   // Tanggal akhir (format: YYYY-MM-DD)
 	Steps     int     `json:"steps"`      // Total langkah minggu itu
 	Calories  float64 `json:"calories"`   // Total kalori minggu itu
+	DurationMinutes int `json:"duration_minutes"` // Total durasi aktivitas minggu itu (menit)
 }
 
 // ActivityTrendPointMonth satu titik data untuk grafik aktivitas (3Months - per bulan)
@@ -234,16 +276,41 @@ type ActivityTrendPointMonth struct {
 	Month    string  `json:"month"`    // Label bulan: "Dec 2025", "Jan 2026", dll
 	Steps    int     `json:"steps"`     // Total langkah bulan itu
 	Calories float64 `json:"calories"`  // Total kalori bulan itu
+	DurationMinutes int `json:"duration_minutes"` // Total durasi aktivitas bulan itu (menit)
+}
+
+// VitalSignTrendPoint satu titik data untuk grafik tanda vital (7Days - per hari)
+type VitalSignTrendPoint struct {
+	Date     string  `json:"date"`      // Tanggal (format: YYYY-MM-DD)
+	AvgValue float64 `json:"avg_value"` // Nilai pembacaan terakhir hari itu
+}
+
+// VitalSignTrendPointWeek satu titik data untuk grafik tanda vital (1Month - per minggu)
+type VitalSignTrendPointWeek struct {
+	Week      string  `json:"week"`       // Label minggu: "Week 1", "Week 2", dll
+	StartDate string  `json:"start_date"` // Tanggal mulai (format: YYYY-MM-DD)
+	EndDate   string  `json:"end_date"`   // Tanggal akhir (format: YYYY-MM-DD)
+	AvgValue  float64 `json:"avg_value"`  // Rata-rata minggu itu
+}
+
+// VitalSignTrendPointMonth satu titik data untuk grafik tanda vital (3Months - per bulan)
+type VitalSignTrendPointMonth struct {
+	Month    string  `json:"month"`     // Label bulan: "Dec 2025", "Jan 2026", dll
+	AvgValue float64 `json:"avg_value"` // Rata-rata bulan itu
 }
 
 // ReadingHistoryResponse satu catatan pembacaan dalam riwayat
 type ReadingHistoryResponse struct {
 	ID          uint      `json:"id"`           // ID record
 	DateTime    time.Time `json:"date_time"`    // Tanggal & waktu pengukuran
-	MetricType  string    `json:"metric_type"`  // Jenis metrik: "tekanan_darah", "gula_darah", "berat_badan", "aktivitas"
+	MetricType  string    `json:"metric_type"`  // Jenis metrik: "tekanan_darah", "gula_darah", "berat_badan", "detak_jantung", "saturasi_oksigen", "suhu_tubuh", "laju_napas", "aktivitas"
 	Value       string    `json:"value"`        // Nilai pengukuran (format string untuk fleksibilitas)
-	Context     *string   `json:"context"`      // Konteks (puasa, setelah makan, dll)
+	Context     *string   `json:"context"`      // Konteks (puasa, setelah makan, posisi dan lengan tekanan darah, kondisi saat pengukuran)
 	Status      string    `json:"status"`       // Status: RENDAH / NORMAL / TINGGI (WHO)
-	Notes       *string   `json:"notes"`        // Catatan tambahan
+	Notes       *string   `json:"notes"`        // Catatan dari user pada pembacaan
+	MeasurementContext *string  `json:"measurement_context,omitempty"` // Kondisi saat pengukuran (kode), mis. bangun_tidur
+	BodyPosition       *string  `json:"body_position,omitempty"`       // Posisi tubuh (hanya tekanan darah)
+	MeasurementArm     *string  `json:"measurement_arm,omitempty"`     // Lengan pengukuran (hanya tekanan darah)
+	Symptoms           []string `json:"symptoms,omitempty"`            // Tag gejala pada pembacaan
 }
 
@@ -0,0 +1,42 @@
+package response
+
+import "time"
+
+// JobStatusResponse adalah status terakhir satu job background
+type JobStatusResponse struct {
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Interval       string     `json:"interval"`                  // Jarak antar eksekusi, contoh "1h0m0s"
+	Status         string     `json:"status"`                    // ok, running, failing, stale, pending
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`     // Waktu mulai eksekusi terakhir
+	LastRunStatus  string     `json:"last_run_status,omitempty"` // running, success, failed
+	LastDurationMs *int64     `json:"last_duration_ms,omitempty"`
+	LastSuccessAt  *time.Time `json:"last_success_at,omitempty"`
+}
+
+// HealthResponse adalah response untuk endpoint GET /health
+type HealthResponse struct {
+	Status    string              `json:"status"`   // ok, atau degraded jika ada job yang gagal/terlambat
+	Database  string              `json:"database"` // ok
+	Jobs      []JobStatusResponse `json:"jobs"`
+	CheckedAt time.Time           `json:"checked_at"`
+}
+
+// JobRunResponse adalah data satu kali eksekusi job background
+type JobRunResponse struct {
+	ID           uint       `json:"id"`
+	JobName      string     `json:"job_name"`
+	Status       string     `json:"status"`
+	Instance     string     `json:"instance"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	DurationMs   int64      `json:"duration_ms"`
+	AffectedRows int64      `json:"affected_rows"`
+	Error        *string    `json:"error,omitempty"`
+}
+
+// JobRunListResponse adalah response untuk endpoint GET /api/admin/jobs/runs
+type JobRunListResponse struct {
+	Runs       []JobRunResponse   `json:"runs"`
+	Pagination PaginationResponse `json:"pagination"`
+}
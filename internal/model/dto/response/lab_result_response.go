@@ -0,0 +1,67 @@
+package response
+
+import "time"
+
+// LabResultResponse adalah satu hasil pemeriksaan laboratorium
+type LabResultResponse struct {
+	ID             uint      `json:"id"`
+	UserID         uint      `json:"user_id"`
+	TestType       string    `json:"test_type"`
+	Label          string    `json:"label"` // Nama pemeriksaan, mis. "Kolesterol LDL"
+	Value          float64   `json:"value"`
+	Unit           string    `json:"unit"`
+	ReferenceMin   *float64  `json:"reference_min,omitempty"`
+	ReferenceMax   *float64  `json:"reference_max,omitempty"`
+	NormalRange    string    `json:"normal_range"`             // Rentang rujukan, mis. "< 100 mg/dL"
+	Status         string    `json:"status"`                   // RENDAH / NORMAL / TINGGI
+	Interpretation *string   `json:"interpretation,omitempty"` // Interpretasi klinis (mis. "Prediabetes" untuk HbA1c)
+	TestedAt       time.Time `json:"tested_at"`
+	LabName        *string   `json:"lab_name,omitempty"`
+	Notes          *string   `json:"notes,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// LabResultListResponse adalah response untuk endpoint GET /api/health/lab-results
+type LabResultListResponse struct {
+	LabResults []LabResultResponse `json:"lab_results"`
+	Pagination PaginationResponse  `json:"pagination"`
+}
+
+// LabTestTypeResponse adalah informasi satu jenis pemeriksaan laboratorium yang didukung
+type LabTestTypeResponse struct {
+	TestType      string   `json:"test_type"`
+	Label         string   `json:"label"`
+	Unit          string   `json:"unit"`           // Satuan standar
+	AcceptedUnits []string `json:"accepted_units"` // Satuan yang dapat dikirim saat input
+	ReferenceMin  *float64 `json:"reference_min,omitempty"`
+	ReferenceMax  *float64 `json:"reference_max,omitempty"`
+	NormalRange   string   `json:"normal_range"`
+}
+
+// LabResultTrendPoint satu titik data grafik tren hasil laboratorium (per pemeriksaan)
+type LabResultTrendPoint struct {
+	ID       uint      `json:"id"`
+	TestedAt time.Time `json:"tested_at"`
+	Date     string    `json:"date"` // Tanggal (format: YYYY-MM-DD)
+	Value    float64   `json:"value"`
+	Status   string    `json:"status"`
+}
+
+// LabResultTrendSeries berisi tren satu jenis pemeriksaan laboratorium
+type LabResultTrendSeries struct {
+	TestType      string                `json:"test_type"`
+	Label         string                `json:"label"`
+	Unit          string                `json:"unit"`
+	NormalRange   string                `json:"normal_range"`
+	Latest        *LabResultResponse    `json:"latest,omitempty"`         // Hasil terbaru pada rentang waktu
+	ChangePercent *float64              `json:"change_percent,omitempty"` // Perubahan hasil terakhir dibanding hasil pertama pada rentang waktu
+	Trend         string                `json:"trend"`                    // Naik / Turun / Stabil
+	Points        []LabResultTrendPoint `json:"points"`
+}
+
+// LabResultTrendsResponse adalah response untuk endpoint GET /api/health/lab-results/trends
+type LabResultTrendsResponse struct {
+	StartDate string                 `json:"start_date"` // Format: YYYY-MM-DD
+	EndDate   string                 `json:"end_date"`   // Format: YYYY-MM-DD
+	Trends    []LabResultTrendSeries `json:"trends"`
+}
@@ -0,0 +1,123 @@
+package response
+
+import "time"
+
+// MedicationResponse adalah satu obat milik user
+type MedicationResponse struct {
+	ID            uint      `json:"id"`
+	UserID        uint      `json:"user_id"`
+	Name          string    `json:"name"`
+	Dose          string    `json:"dose"`
+	ScheduleTimes []string  `json:"schedule_times"` // Jam minum obat (HH:MM), kosong = bila perlu
+	AsNeeded      bool      `json:"as_needed"`      // true jika obat tidak memiliki jadwal tetap
+	StartDate     string    `json:"start_date"`     // Format: YYYY-MM-DD
+	EndDate       *string   `json:"end_date,omitempty"`
+	Instructions  *string   `json:"instructions,omitempty"`
+	Purpose       *string   `json:"purpose,omitempty"`
+	IsActive      bool      `json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// MedicationListResponse adalah response untuk endpoint GET /api/health/medications
+type MedicationListResponse struct {
+	Medications []MedicationResponse `json:"medications"`
+	Pagination  PaginationResponse   `json:"pagination"`
+}
+
+// MedicationLogResponse adalah satu catatan minum obat
+type MedicationLogResponse struct {
+	ID           uint       `json:"id"`
+	MedicationID uint       `json:"medication_id"`
+	ScheduledAt  time.Time  `json:"scheduled_at"`
+	Status       string     `json:"status"` // taken / missed
+	TakenAt      *time.Time `json:"taken_at,omitempty"`
+	Notes        *string    `json:"notes,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// MedicationLogListResponse adalah response untuk endpoint GET /api/health/medications/:id/logs
+type MedicationLogListResponse struct {
+	Medication MedicationResponse      `json:"medication"`
+	Logs       []MedicationLogResponse `json:"logs"`
+	Pagination PaginationResponse      `json:"pagination"`
+}
+
+// MedicationScheduleItem adalah satu jadwal minum obat pada hari tertentu
+type MedicationScheduleItem struct {
+	MedicationID uint       `json:"medication_id"`
+	Name         string     `json:"name"`
+	Dose         string     `json:"dose"`
+	Instructions *string    `json:"instructions,omitempty"`
+	ScheduledAt  time.Time  `json:"scheduled_at"`
+	Time         string     `json:"time"`   // Jam jadwal (HH:MM)
+	Status       string     `json:"status"` // taken / missed / pending (belum waktunya) / due (sudah waktunya, belum dicatat)
+	TakenAt      *time.Time `json:"taken_at,omitempty"`
+	LogID        *uint      `json:"log_id,omitempty"`
+}
+
+// MedicationScheduleResponse adalah response untuk endpoint GET /api/health/medications/schedule
+type MedicationScheduleResponse struct {
+	Date  string                   `json:"date"` // Format: YYYY-MM-DD
+	Items []MedicationScheduleItem `json:"items"`
+}
+
+// MedicationAdherenceItem adalah kepatuhan minum satu obat dalam rentang waktu
+type MedicationAdherenceItem struct {
+	MedicationID     uint     `json:"medication_id"`
+	Name             string   `json:"name"`
+	Dose             string   `json:"dose"`
+	ExpectedDoses    int      `json:"expected_doses"` // Jumlah jadwal yang sudah lewat dalam rentang waktu
+	TakenDoses       int      `json:"taken_doses"`
+	MissedDoses      int      `json:"missed_doses"`   // Dicatat terlewat
+	UnloggedDoses    int      `json:"unlogged_doses"` // Tidak dicatat (dihitung terlewat)
+	AdherencePercent *float64 `json:"adherence_percent,omitempty"`
+	Status           string   `json:"status"` // BAIK / CUKUP / KURANG / BELUM_ADA_JADWAL
+}
+
+// DailyAdherencePoint adalah kepatuhan minum obat pada satu hari (semua obat)
+type DailyAdherencePoint struct {
+	Date             string  `json:"date"` // Format: YYYY-MM-DD
+	ExpectedDoses    int     `json:"expected_doses"`
+	TakenDoses       int     `json:"taken_doses"`
+	AdherencePercent float64 `json:"adherence_percent"`
+}
+
+// MedicationAdherenceResponse adalah response untuk endpoint GET /api/health/medications/adherence
+type MedicationAdherenceResponse struct {
+	StartDate        string                    `json:"start_date"` // Format: YYYY-MM-DD
+	EndDate          string                    `json:"end_date"`   // Format: YYYY-MM-DD
+	ExpectedDoses    int                       `json:"expected_doses"`
+	TakenDoses       int                       `json:"taken_doses"`
+	MissedDoses      int                       `json:"missed_doses"` // Termasuk jadwal yang tidak dicatat
+	AdherencePercent *float64                  `json:"adherence_percent,omitempty"`
+	Status           string                    `json:"status"` // BAIK (>= 80%) / CUKUP (50-79%) / KURANG (< 50%) / BELUM_ADA_JADWAL
+	Medications      []MedicationAdherenceItem `json:"medications"`
+	Daily            []DailyAdherencePoint     `json:"daily"`
+}
+
+// AdherenceMetricCorrelation membandingkan satu metrik kesehatan pada hari patuh dan tidak patuh minum obat
+type AdherenceMetricCorrelation struct {
+	Metric            string   `json:"metric"` // tekanan_darah (sistolik) / gula_darah
+	Unit              string   `json:"unit"`
+	AdherentDays      int      `json:"adherent_days"`     // Hari dengan kepatuhan >= 80% dan ada pengukuran
+	NonAdherentDays   int      `json:"non_adherent_days"` // Hari dengan kepatuhan < 80% dan ada pengukuran
+	AvgOnAdherentDays *float64 `json:"avg_on_adherent_days,omitempty"`
+	AvgOnNonAdherent  *float64 `json:"avg_on_non_adherent_days,omitempty"`
+	Difference        *float64 `json:"difference,omitempty"`  // Rata-rata hari tidak patuh dikurangi hari patuh
+	Coefficient       *float64 `json:"coefficient,omitempty"` // Korelasi Pearson kepatuhan harian vs rata-rata harian (min. 3 hari)
+	Interpretation    string   `json:"interpretation"`
+}
+
+// MedicationAdherenceCorrelation adalah ringkasan kepatuhan minum obat beserta korelasinya
+// dengan tren tekanan darah dan gula darah pada riwayat kesehatan
+type MedicationAdherenceCorrelation struct {
+	AdherencePercent *float64                     `json:"adherence_percent,omitempty"`
+	Status           string                       `json:"status"`
+	ExpectedDoses    int                          `json:"expected_doses"`
+	TakenDoses       int                          `json:"taken_doses"`
+	Medications      []MedicationAdherenceItem    `json:"medications"`
+	Daily            []DailyAdherencePoint        `json:"daily"`
+	Correlations     []AdherenceMetricCorrelation `json:"correlations"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+// NotificationResponse adalah satu notifikasi pada inbox in-app
+type NotificationResponse struct {
+	ID          uint       `json:"id"`
+	Type        string     `json:"type"`
+	Title       string     `json:"title"`   // Sesuai bahasa user (id / en)
+	Message     string     `json:"message"` // Sesuai bahasa user (id / en)
+	ReferenceID *uint      `json:"reference_id,omitempty"`
+	IsRead      bool       `json:"is_read"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// NotificationListResponse adalah response untuk endpoint GET /api/notifications
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int64                  `json:"unread_count"`
+	Pagination    PaginationResponse     `json:"pagination"`
+}
+
+// NotificationUnreadCountResponse adalah response untuk endpoint GET /api/notifications/unread-count
+type NotificationUnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// MarkAllNotificationsReadResponse adalah response untuk endpoint PUT /api/notifications/read-all
+type MarkAllNotificationsReadResponse struct {
+	UpdatedCount int64 `json:"updated_count"`
+}
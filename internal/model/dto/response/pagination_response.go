@@ -0,0 +1,9 @@
+package response
+
+// PaginationResponse berisi informasi pagination untuk response list
+type PaginationResponse struct {
+	Page       int   `json:"page"`        // Halaman saat ini (mulai dari 1)
+	Limit      int   `json:"limit"`       // Jumlah data per halaman
+	TotalItems int64 `json:"total_items"` // Total data yang cocok dengan filter
+	TotalPages int   `json:"total_pages"` // Total halaman
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Event audit keamanan autentikasi
+const (
+	AuthAuditEventLockout = "lockout" // Akun/IP dikunci sementara karena terlalu banyak percobaan gagal
+	AuthAuditEventUnlock  = "unlock"  // Penguncian dibuka (oleh admin atau reset password)
+)
+
+// AuthAuditLog adalah representasi tabel auth_audit_logs di database
+// Mencatat kejadian keamanan autentikasi seperti penguncian dan pembukaan kunci.
+type AuthAuditLog struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Event     string    `gorm:"type:varchar(30);not null;index" json:"event"`
+	Action    string    `gorm:"type:varchar(30);not null" json:"action"`
+	Scope     string    `gorm:"type:varchar(20);not null" json:"scope"`
+	Key       string    `gorm:"type:varchar(255);not null;index" json:"key"`
+	UserID    *uint     `gorm:"index" json:"user_id,omitempty"` // User yang terdampak (jika diketahui)
+	ActorID   *uint     `json:"actor_id,omitempty"`             // Admin yang melakukan unlock (jika ada)
+	IPAddress string    `gorm:"type:varchar(45)" json:"ip_address"`
+	Details   string    `gorm:"type:text" json:"details"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (AuthAuditLog) TableName() string {
+	return "auth_audit_logs"
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Scope pembatasan percobaan autentikasi
+const (
+	ThrottleScopeIP      = "ip"      // Berdasarkan alamat IP client
+	ThrottleScopeAccount = "account" // Berdasarkan akun (user ID atau identifier/email yang dicoba)
+)
+
+// AuthThrottle adalah representasi tabel auth_throttles di database
+// Menyimpan jumlah percobaan gagal per aksi (login, register, reset password) dan per scope (ip/account)
+// untuk menerapkan jeda bertahap (progressive delay) dan penguncian sementara.
+type AuthThrottle struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	Action        string     `gorm:"type:varchar(30);not null;uniqueIndex:idx_auth_throttles_action_scope_key" json:"action"`
+	Scope         string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_auth_throttles_action_scope_key" json:"scope"`
+	Key           string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_auth_throttles_action_scope_key" json:"key"`
+	FailureCount  int        `gorm:"not null;default:0" json:"failure_count"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"` // Percobaan berikutnya baru diizinkan setelah waktu ini (progressive delay)
+	LockedUntil   *time.Time `gorm:"index" json:"locked_until,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (AuthThrottle) TableName() string {
+	return "auth_throttles"
+}
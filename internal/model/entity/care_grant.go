@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// CareGrantScope adalah cakupan akses yang diberikan pasien ke caregiver
+type CareGrantScope string
+
+const (
+	CareGrantScopeView   CareGrantScope = "view"   // Hanya melihat data kesehatan, riwayat, alert, dan laporan
+	CareGrantScopeRecord CareGrantScope = "record" // Melihat dan mencatat/mengubah data kesehatan atas nama pasien
+)
+
+// IsValidCareGrantScope memeriksa apakah scope dikenal
+func IsValidCareGrantScope(scope CareGrantScope) bool {
+	return scope == CareGrantScopeView || scope == CareGrantScopeRecord
+}
+
+// CareGrantStatus adalah status undangan/akses caregiver
+type CareGrantStatus string
+
+const (
+	CareGrantStatusPending  CareGrantStatus = "pending"  // Undangan belum diterima caregiver
+	CareGrantStatusActive   CareGrantStatus = "active"   // Akses aktif
+	CareGrantStatusDeclined CareGrantStatus = "declined" // Undangan ditolak caregiver
+	CareGrantStatusRevoked  CareGrantStatus = "revoked"  // Akses dicabut oleh pasien atau dilepas caregiver
+)
+
+// CareGrant adalah representasi tabel care_grants di database
+// Pasien (owner) memberikan akses ke akun lain (caregiver/keluarga) untuk melihat
+// atau mencatat data kesehatannya tanpa berbagi password.
+type CareGrant struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	OwnerID     uint            `gorm:"not null;index" json:"owner_id"`     // Pasien pemilik data
+	CaregiverID uint            `gorm:"not null;index" json:"caregiver_id"` // Akun yang diberi akses
+	Scope       CareGrantScope  `gorm:"type:varchar(20);not null;default:'view'" json:"scope"`
+	Status      CareGrantStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	AcceptedAt  *time.Time      `json:"accepted_at,omitempty"`
+	RevokedAt   *time.Time      `json:"revoked_at,omitempty"`
+	Owner       *User           `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+	Caregiver   *User           `gorm:"foreignKey:CaregiverID" json:"caregiver,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (CareGrant) TableName() string {
+	return "care_grants"
+}
@@ -2,6 +2,13 @@ package entity
 
 import "time"
 
+// Nama kategori default yang di-seed tanpa ID tetap.
+// ID-nya berbeda antar database (tergantung kategori yang sudah dibuat admin), sehingga dicari berdasarkan nama.
+const (
+	CategoryNamePernapasan = "Pernapasan"
+	CategoryNameSuhuTubuh  = "Suhu Tubuh"
+)
+
 // Category adalah representasi tabel categories di database
 type Category struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
@@ -0,0 +1,145 @@
+package entity
+
+import "time"
+
+// ClinicalThreshold adalah representasi tabel clinical_thresholds di database
+// Menyimpan batas rentang normal untuk klasifikasi status kesehatan (RENDAH/NORMAL/TINGGI).
+// Baris dengan UserID NULL adalah default global (awalnya sesuai standar WHO),
+// sedangkan baris dengan UserID terisi adalah penyesuaian per pasien oleh clinician
+// (misalnya untuk pasien hamil atau diabetes). Field NULL pada penyesuaian per pasien
+// berarti mengikuti default global.
+type ClinicalThreshold struct {
+	ID     uint  `gorm:"primaryKey" json:"id"`
+	UserID *uint `gorm:"uniqueIndex" json:"user_id,omitempty"` // NULL = default global
+	User   *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+
+	// Tekanan darah normal (mmHg), inklusif: min ≤ nilai ≤ max
+	SystolicMin  *int `gorm:"type:int" json:"systolic_min,omitempty"`
+	SystolicMax  *int `gorm:"type:int" json:"systolic_max,omitempty"`
+	DiastolicMin *int `gorm:"type:int" json:"diastolic_min,omitempty"`
+	DiastolicMax *int `gorm:"type:int" json:"diastolic_max,omitempty"`
+
+	// Gula darah sewaktu normal (mg/dL), inklusif: min ≤ nilai ≤ max
+	BloodSugarMin *int `gorm:"type:int" json:"blood_sugar_min,omitempty"`
+	BloodSugarMax *int `gorm:"type:int" json:"blood_sugar_max,omitempty"`
+
+	// Gula darah normal per konteks pengukuran (mg/dL), inklusif: min ≤ nilai ≤ max
+	BloodSugarFastingMin  *int `gorm:"type:int" json:"blood_sugar_fasting_min,omitempty"`
+	BloodSugarFastingMax  *int `gorm:"type:int" json:"blood_sugar_fasting_max,omitempty"`
+	BloodSugarPostMealMin *int `gorm:"type:int" json:"blood_sugar_post_meal_min,omitempty"`
+	BloodSugarPostMealMax *int `gorm:"type:int" json:"blood_sugar_post_meal_max,omitempty"`
+	BloodSugarBedtimeMin  *int `gorm:"type:int" json:"blood_sugar_bedtime_min,omitempty"`
+	BloodSugarBedtimeMax  *int `gorm:"type:int" json:"blood_sugar_bedtime_max,omitempty"`
+
+	// Detak jantung normal (bpm), inklusif: min ≤ nilai ≤ max
+	HeartRateMin *int `gorm:"type:int" json:"heart_rate_min,omitempty"`
+	HeartRateMax *int `gorm:"type:int" json:"heart_rate_max,omitempty"`
+
+	// Saturasi oksigen / SpO2 normal (%): nilai ≥ min (tidak ada batas atas).
+	// Nilai di bawah critical dianggap gawat darurat dan alert menyarankan segera ke IGD.
+	OxygenSaturationMin      *int `gorm:"type:int" json:"oxygen_saturation_min,omitempty"`
+	OxygenSaturationCritical *int `gorm:"type:int" json:"oxygen_saturation_critical,omitempty"`
+
+	// Suhu tubuh normal (°C), inklusif: min ≤ nilai ≤ max.
+	// Nilai ≥ critical dianggap demam tinggi dan alert menyarankan segera konsultasi dengan dokter.
+	TemperatureMin      *float64 `gorm:"type:decimal(4,1)" json:"temperature_min,omitempty"`
+	TemperatureMax      *float64 `gorm:"type:decimal(4,1)" json:"temperature_max,omitempty"`
+	TemperatureCritical *float64 `gorm:"type:decimal(4,1)" json:"temperature_critical,omitempty"`
+
+	// Laju napas normal (napas/menit), inklusif: min ≤ nilai ≤ max
+	RespiratoryRateMin *int `gorm:"type:int" json:"respiratory_rate_min,omitempty"`
+	RespiratoryRateMax *int `gorm:"type:int" json:"respiratory_rate_max,omitempty"`
+
+	// BMI normal: min ≤ BMI < max
+	BMIMin *float64 `gorm:"type:decimal(5,2);column:bmi_min" json:"bmi_min,omitempty"`
+	BMIMax *float64 `gorm:"type:decimal(5,2);column:bmi_max" json:"bmi_max,omitempty"`
+
+	// Rentang rujukan hasil laboratorium (satuan standar jenis pemeriksaan), inklusif: min ≤ nilai ≤ max.
+	// Batas yang NULL pada semua lapisan berarti pemeriksaan tersebut tidak memiliki batas di sisi itu.
+	HbA1cMin            *float64 `gorm:"type:decimal(8,2);column:hba1c_min" json:"hba1c_min,omitempty"`
+	HbA1cMax            *float64 `gorm:"type:decimal(8,2);column:hba1c_max" json:"hba1c_max,omitempty"`
+	TotalCholesterolMin *float64 `gorm:"type:decimal(8,2)" json:"total_cholesterol_min,omitempty"`
+	TotalCholesterolMax *float64 `gorm:"type:decimal(8,2)" json:"total_cholesterol_max,omitempty"`
+	LDLMin              *float64 `gorm:"type:decimal(8,2);column:ldl_min" json:"ldl_min,omitempty"`
+	LDLMax              *float64 `gorm:"type:decimal(8,2);column:ldl_max" json:"ldl_max,omitempty"`
+	HDLMin              *float64 `gorm:"type:decimal(8,2);column:hdl_min" json:"hdl_min,omitempty"`
+	HDLMax              *float64 `gorm:"type:decimal(8,2);column:hdl_max" json:"hdl_max,omitempty"`
+	TriglyceridesMin    *float64 `gorm:"type:decimal(8,2)" json:"triglycerides_min,omitempty"`
+	TriglyceridesMax    *float64 `gorm:"type:decimal(8,2)" json:"triglycerides_max,omitempty"`
+	UricAcidMin         *float64 `gorm:"type:decimal(8,2)" json:"uric_acid_min,omitempty"`
+	UricAcidMax         *float64 `gorm:"type:decimal(8,2)" json:"uric_acid_max,omitempty"`
+	CreatinineMin       *float64 `gorm:"type:decimal(8,2)" json:"creatinine_min,omitempty"`
+	CreatinineMax       *float64 `gorm:"type:decimal(8,2)" json:"creatinine_max,omitempty"`
+
+	Note      *string   `gorm:"type:text" json:"note,omitempty"` // Alasan penyesuaian (mis. kehamilan)
+	UpdatedBy *uint     `json:"updated_by,omitempty"`            // User (admin/clinician) yang terakhir mengubah
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (ClinicalThreshold) TableName() string {
+	return "clinical_thresholds"
+}
+
+// DefaultClinicalThreshold mengembalikan batas normal standar WHO yang dipakai
+// sebagai seed default global dan sebagai fallback jika default global belum ada di database.
+// Rentang rujukan lab mengikuti nilai rujukan dewasa yang umum dipakai (ADA untuk HbA1c, NCEP ATP III untuk lipid).
+func DefaultClinicalThreshold() ClinicalThreshold {
+	systolicMin, systolicMax := 90, 139
+	diastolicMin, diastolicMax := 60, 89
+	bloodSugarMin, bloodSugarMax := 70, 140
+	fastingMin, fastingMax := 70, 99
+	postMealMin, postMealMax := 70, 139
+	bedtimeMin, bedtimeMax := 100, 140
+	heartRateMin, heartRateMax := 60, 100
+	bmiMin, bmiMax := 18.5, 25.0
+	oxygenSaturationMin, oxygenSaturationCritical := 95, 90
+	temperatureMin, temperatureMax, temperatureCritical := 36.1, 37.5, 39.0
+	respiratoryRateMin, respiratoryRateMax := 12, 20
+	hba1cMax := 5.6
+	totalCholesterolMax := 199.0
+	ldlMax := 99.0
+	hdlMin := 40.0
+	triglyceridesMax := 149.0
+	uricAcidMin, uricAcidMax := 3.4, 7.0
+	creatinineMin, creatinineMax := 0.6, 1.3
+
+	return ClinicalThreshold{
+		SystolicMin:   &systolicMin,
+		SystolicMax:   &systolicMax,
+		DiastolicMin:  &diastolicMin,
+		DiastolicMax:  &diastolicMax,
+		BloodSugarMin: &bloodSugarMin,
+		BloodSugarMax: &bloodSugarMax,
+		HeartRateMin:  &heartRateMin,
+		HeartRateMax:  &heartRateMax,
+		BMIMin:        &bmiMin,
+		BMIMax:        &bmiMax,
+
+		BloodSugarFastingMin:  &fastingMin,
+		BloodSugarFastingMax:  &fastingMax,
+		BloodSugarPostMealMin: &postMealMin,
+		BloodSugarPostMealMax: &postMealMax,
+		BloodSugarBedtimeMin:  &bedtimeMin,
+		BloodSugarBedtimeMax:  &bedtimeMax,
+
+		OxygenSaturationMin:      &oxygenSaturationMin,
+		OxygenSaturationCritical: &oxygenSaturationCritical,
+		TemperatureMin:           &temperatureMin,
+		TemperatureMax:           &temperatureMax,
+		TemperatureCritical:      &temperatureCritical,
+		RespiratoryRateMin:       &respiratoryRateMin,
+		RespiratoryRateMax:       &respiratoryRateMax,
+
+		HbA1cMax:            &hba1cMax,
+		TotalCholesterolMax: &totalCholesterolMax,
+		LDLMax:              &ldlMax,
+		HDLMin:              &hdlMin,
+		TriglyceridesMax:    &triglyceridesMax,
+		UricAcidMin:         &uricAcidMin,
+		UricAcidMax:         &uricAcidMax,
+		CreatinineMin:       &creatinineMin,
+		CreatinineMax:       &creatinineMax,
+	}
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// ClinicianPatient adalah representasi tabel clinician_patients di database
+// Menghubungkan akun clinician dengan pasien yang ditanganinya (ditetapkan oleh admin).
+// Clinician yang terhubung dapat melihat data kesehatan pasien (read-only).
+type ClinicianPatient struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ClinicianID uint      `gorm:"not null;uniqueIndex:idx_clinician_patients_pair" json:"clinician_id"`
+	PatientID   uint      `gorm:"not null;uniqueIndex:idx_clinician_patients_pair;index" json:"patient_id"`
+	AssignedBy  *uint     `json:"assigned_by,omitempty"` // Admin yang menghubungkan - nullable
+	Clinician   *User     `gorm:"foreignKey:ClinicianID;constraint:OnDelete:CASCADE" json:"clinician,omitempty"`
+	Patient     *User     `gorm:"foreignKey:PatientID;constraint:OnDelete:CASCADE" json:"patient,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (ClinicianPatient) TableName() string {
+	return "clinician_patients"
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// EmailVerificationToken adalah representasi tabel email_verification_tokens di database
+// Token dikirim ke email user saat registrasi/kirim ulang dan hanya bisa dipakai sekali.
+// Yang disimpan hanya hash SHA-256-nya.
+type EmailVerificationToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"` // Terisi saat token dipakai atau dibatalkan
+	CreatedAt time.Time  `gorm:"index" json:"created_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (EmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
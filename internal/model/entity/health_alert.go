@@ -10,19 +10,43 @@ const (
 	AlertStatusHigh     AlertStatus = "High"
 	AlertStatusModerate AlertStatus = "Moderate"
 	AlertStatusLow      AlertStatus = "Low"
+
+	// Status hasil klasifikasi (sama dengan status pada response check-health-alerts)
+	AlertStatusRendah AlertStatus = "RENDAH"
+	AlertStatusTinggi AlertStatus = "TINGGI"
+)
+
+// AlertState adalah enum untuk status tindak lanjut alert oleh user
+type AlertState string
+
+const (
+	AlertStateUnread       AlertState = "unread"
+	AlertStateRead         AlertState = "read"
+	AlertStateAcknowledged AlertState = "acknowledged"
+	AlertStateResolved     AlertState = "resolved"
 )
 
 // HealthAlert adalah representasi tabel health_alerts di database
+// Satu alert disimpan per kategori per hari (unique index user_id + category + record_date)
 type HealthAlert struct {
-	ID             uint       `gorm:"primaryKey" json:"id"`
-	UserID         uint       `gorm:"not null;index" json:"user_id"`
-	User           User       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
-	AlertType      string     `gorm:"type:varchar(100);not null" json:"alert_type"`      // Jenis alert (e.g., "Tekanan Darah Tinggi")
-	Message        string     `gorm:"type:text;not null" json:"message"`                // Pesan alert
-	Status         AlertStatus `gorm:"type:varchar(20);not null" json:"status"`          // Status: Critical, High, Moderate, Low
-	Recommendations string    `gorm:"type:text" json:"recommendations"`                 // Rekomendasi (JSON array sebagai string)
-	RecordedAt     time.Time  `gorm:"not null" json:"recorded_at"`                      // Waktu pengukuran data kesehatan
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
+	ID              uint        `gorm:"primaryKey" json:"id"`
+	UserID          uint        `gorm:"not null;index;uniqueIndex:idx_health_alerts_user_category_record_date,priority:1" json:"user_id"`
+	User            User        `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	HealthDataID    *uint       `gorm:"index" json:"health_data_id,omitempty"`                                                                                         // Data kesehatan sumber alert - nullable
+	AlertType       string      `gorm:"type:varchar(100);not null" json:"alert_type"`                                                                                  // Jenis alert (e.g., "Tekanan Darah Tinggi")
+	Category        string      `gorm:"type:varchar(50);not null;default:'';index;uniqueIndex:idx_health_alerts_user_category_record_date,priority:2" json:"category"` // Kategori: diabetes, hipertensi, jantung, berat_badan, pernapasan, suhu_tubuh
+	Value           string      `gorm:"type:varchar(100)" json:"value"`                                                                                                // Nilai pengukuran (e.g., "150 / 95 mmHg")
+	Label           string      `gorm:"type:varchar(100)" json:"label"`                                                                                                // Label kondisi (e.g., "Hipertensi")
+	Message         string      `gorm:"type:text;not null" json:"message"`                                                                                             // Pesan alert
+	Status          AlertStatus `gorm:"type:varchar(20);not null" json:"status"`                                                                                       // Status: RENDAH / TINGGI (hasil klasifikasi)
+	Recommendations string      `gorm:"type:text" json:"recommendations"`                                                                                              // Rekomendasi (JSON object sebagai string)
+	RecordDate      time.Time   `gorm:"type:date;index;uniqueIndex:idx_health_alerts_user_category_record_date,priority:3" json:"record_date"`                         // Tanggal record data kesehatan (untuk deduplikasi harian)
+	RecordedAt      time.Time   `gorm:"not null" json:"recorded_at"`                                                                                                   // Waktu pengukuran data kesehatan
+	State           AlertState  `gorm:"type:varchar(20);not null;default:'unread';index" json:"state"`                                                                 // Status tindak lanjut: unread, read, acknowledged, resolved
+	ReadAt          *time.Time  `json:"read_at,omitempty"`                                                                                                             // Waktu alert dibaca - nullable
+	AcknowledgedAt  *time.Time  `json:"acknowledged_at,omitempty"`                                                                                                     // Waktu alert dikonfirmasi - nullable
+	ResolvedAt      *time.Time  `json:"resolved_at,omitempty"`                                                                                                         // Waktu alert diselesaikan - nullable
+	ResolutionNote  *string     `gorm:"type:text" json:"resolution_note,omitempty"`                                                                                    // Catatan penyelesaian - nullable
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
 }
-
@@ -1,6 +1,246 @@
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// BloodSugarContext adalah konteks pengukuran gula darah
+// Nilai normal gula darah sangat bergantung pada kapan pengukuran dilakukan
+type BloodSugarContext string
+
+const (
+	BloodSugarContextFasting  BloodSugarContext = "puasa"         // Puasa minimal 8 jam
+	BloodSugarContextPostMeal BloodSugarContext = "setelah_makan" // 2 jam setelah makan
+	BloodSugarContextRandom   BloodSugarContext = "sewaktu"       // Sewaktu (tanpa memperhatikan waktu makan)
+	BloodSugarContextBedtime  BloodSugarContext = "sebelum_tidur" // Sebelum tidur
+)
+
+// IsValidBloodSugarContext memeriksa apakah konteks gula darah dikenal
+func IsValidBloodSugarContext(context BloodSugarContext) bool {
+	switch context {
+	case BloodSugarContextFasting, BloodSugarContextPostMeal, BloodSugarContextRandom, BloodSugarContextBedtime:
+		return true
+	}
+	return false
+}
+
+// Label mengembalikan nama konteks gula darah yang mudah dibaca
+func (c BloodSugarContext) Label() string {
+	switch c {
+	case BloodSugarContextFasting:
+		return "Puasa"
+	case BloodSugarContextPostMeal:
+		return "2 Jam Setelah Makan"
+	case BloodSugarContextBedtime:
+		return "Sebelum Tidur"
+	default:
+		return "Sewaktu"
+	}
+}
+
+// ResolveBloodSugarContext mengembalikan konteks gula darah yang berlaku
+// Data lama tanpa konteks dianggap sebagai gula darah sewaktu
+func ResolveBloodSugarContext(context *BloodSugarContext) BloodSugarContext {
+	if context == nil || *context == "" {
+		return BloodSugarContextRandom
+	}
+	return *context
+}
+
+// ActivityType adalah jenis aktivitas fisik yang dicatat
+type ActivityType string
+
+const (
+	ActivityTypeWalking    ActivityType = "jalan_kaki"
+	ActivityTypeRunning    ActivityType = "lari"
+	ActivityTypeCycling    ActivityType = "bersepeda"
+	ActivityTypeSwimming   ActivityType = "berenang"
+	ActivityTypeAerobics   ActivityType = "senam"
+	ActivityTypeYoga       ActivityType = "yoga"
+	ActivityTypeWeightLift ActivityType = "angkat_beban"
+	ActivityTypeHousework  ActivityType = "pekerjaan_rumah"
+	ActivityTypeOther      ActivityType = "lainnya"
+)
+
+// ActivityTypes adalah daftar jenis aktivitas yang dikenal (urutan untuk pesan validasi dan dokumentasi)
+var ActivityTypes = []ActivityType{
+	ActivityTypeWalking, ActivityTypeRunning, ActivityTypeCycling, ActivityTypeSwimming, ActivityTypeAerobics,
+	ActivityTypeYoga, ActivityTypeWeightLift, ActivityTypeHousework, ActivityTypeOther,
+}
+
+// IsValidActivityType memeriksa apakah jenis aktivitas dikenal
+func IsValidActivityType(activityType ActivityType) bool {
+	for _, t := range ActivityTypes {
+		if t == activityType {
+			return true
+		}
+	}
+	return false
+}
+
+// Label mengembalikan nama jenis aktivitas yang mudah dibaca
+func (t ActivityType) Label() string {
+	switch t {
+	case ActivityTypeWalking:
+		return "Jalan Kaki"
+	case ActivityTypeRunning:
+		return "Lari"
+	case ActivityTypeCycling:
+		return "Bersepeda"
+	case ActivityTypeSwimming:
+		return "Berenang"
+	case ActivityTypeAerobics:
+		return "Senam"
+	case ActivityTypeYoga:
+		return "Yoga"
+	case ActivityTypeWeightLift:
+		return "Angkat Beban"
+	case ActivityTypeHousework:
+		return "Pekerjaan Rumah"
+	default:
+		return "Lainnya"
+	}
+}
+
+// ActivityIntensity adalah intensitas aktivitas fisik
+type ActivityIntensity string
+
+const (
+	ActivityIntensityLight    ActivityIntensity = "ringan"
+	ActivityIntensityModerate ActivityIntensity = "sedang"
+	ActivityIntensityVigorous ActivityIntensity = "berat"
+)
+
+// IsValidActivityIntensity memeriksa apakah intensitas aktivitas dikenal
+func IsValidActivityIntensity(intensity ActivityIntensity) bool {
+	switch intensity {
+	case ActivityIntensityLight, ActivityIntensityModerate, ActivityIntensityVigorous:
+		return true
+	}
+	return false
+}
+
+// BodyPosition adalah posisi tubuh saat mengukur tekanan darah
+type BodyPosition string
+
+const (
+	BodyPositionSitting  BodyPosition = "duduk"
+	BodyPositionStanding BodyPosition = "berdiri"
+	BodyPositionLying    BodyPosition = "berbaring"
+)
+
+// IsValidBodyPosition memeriksa apakah posisi tubuh dikenal
+func IsValidBodyPosition(position BodyPosition) bool {
+	switch position {
+	case BodyPositionSitting, BodyPositionStanding, BodyPositionLying:
+		return true
+	}
+	return false
+}
+
+// Label mengembalikan nama posisi tubuh yang mudah dibaca
+func (p BodyPosition) Label() string {
+	switch p {
+	case BodyPositionStanding:
+		return "Berdiri"
+	case BodyPositionLying:
+		return "Berbaring"
+	default:
+		return "Duduk"
+	}
+}
+
+// MeasurementArm adalah lengan yang dipakai saat mengukur tekanan darah
+type MeasurementArm string
+
+const (
+	MeasurementArmLeft  MeasurementArm = "kiri"
+	MeasurementArmRight MeasurementArm = "kanan"
+)
+
+// IsValidMeasurementArm memeriksa apakah lengan pengukuran dikenal
+func IsValidMeasurementArm(arm MeasurementArm) bool {
+	return arm == MeasurementArmLeft || arm == MeasurementArmRight
+}
+
+// Label mengembalikan nama lengan pengukuran yang mudah dibaca
+func (a MeasurementArm) Label() string {
+	if a == MeasurementArmRight {
+		return "Lengan Kanan"
+	}
+	return "Lengan Kiri"
+}
+
+// MeasurementContext adalah kondisi saat pembacaan dilakukan (berlaku untuk semua metrik pada pembacaan)
+type MeasurementContext string
+
+const (
+	MeasurementContextWakeUp        MeasurementContext = "bangun_tidur"
+	MeasurementContextResting       MeasurementContext = "istirahat"
+	MeasurementContextAfterActivity MeasurementContext = "setelah_aktivitas"
+	MeasurementContextBeforeMeds    MeasurementContext = "sebelum_minum_obat"
+	MeasurementContextAfterMeds     MeasurementContext = "setelah_minum_obat"
+	MeasurementContextStressed      MeasurementContext = "stres"
+	MeasurementContextUnwell        MeasurementContext = "sedang_sakit"
+)
+
+// measurementContextLabels berisi nama konteks pengukuran yang mudah dibaca
+var measurementContextLabels = map[MeasurementContext]string{
+	MeasurementContextWakeUp:        "Bangun Tidur",
+	MeasurementContextResting:       "Setelah Istirahat",
+	MeasurementContextAfterActivity: "Setelah Aktivitas",
+	MeasurementContextBeforeMeds:    "Sebelum Minum Obat",
+	MeasurementContextAfterMeds:     "Setelah Minum Obat",
+	MeasurementContextStressed:      "Sedang Stres",
+	MeasurementContextUnwell:        "Sedang Sakit",
+}
+
+// IsValidMeasurementContext memeriksa apakah konteks pengukuran dikenal
+func IsValidMeasurementContext(context MeasurementContext) bool {
+	_, ok := measurementContextLabels[context]
+	return ok
+}
+
+// Label mengembalikan nama konteks pengukuran yang mudah dibaca
+func (c MeasurementContext) Label() string {
+	if label, ok := measurementContextLabels[c]; ok {
+		return label
+	}
+	return string(c)
+}
+
+// Symptoms adalah daftar tag gejala yang dikenal beserta labelnya (urutan untuk pesan validasi dan dokumentasi)
+var Symptoms = []struct {
+	Code  string
+	Label string
+}{
+	{"pusing", "Pusing"},
+	{"sakit_kepala", "Sakit Kepala"},
+	{"lemas", "Lemas"},
+	{"mual", "Mual"},
+	{"sesak_napas", "Sesak Napas"},
+	{"nyeri_dada", "Nyeri Dada"},
+	{"jantung_berdebar", "Jantung Berdebar"},
+	{"pandangan_kabur", "Pandangan Kabur"},
+	{"demam", "Demam"},
+	{"batuk", "Batuk"},
+	{"haus_berlebih", "Haus Berlebih"},
+	{"sering_buang_air_kecil", "Sering Buang Air Kecil"},
+	{"kesemutan", "Kesemutan"},
+	{"bengkak_kaki", "Bengkak di Kaki"},
+	{"lainnya", "Lainnya"},
+}
+
+// SymptomLabel mengembalikan label tag gejala; ok bernilai false jika tag tidak dikenal
+func SymptomLabel(code string) (label string, ok bool) {
+	for _, symptom := range Symptoms {
+		if symptom.Code == code {
+			return symptom.Label, true
+		}
+	}
+	return "", false
+}
 
 type HealthData struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`                    // Primary key HealthData (auto increment: 1, 2, 3, ...)
@@ -11,16 +251,43 @@ type HealthData struct {
 	Systolic   *int      `gorm:"type:int" json:"systolic"`                // Tekanan darah sistolik (mmHg) - nullable
 	Diastolic  *int      `gorm:"type:int" json:"diastolic"`              // Tekanan darah diastolik (mmHg) - nullable
 	BloodSugar *int      `gorm:"type:int" json:"blood_sugar"`           // Gula darah (mg/dL) - nullable
+	BloodSugarContext *BloodSugarContext `gorm:"type:varchar(20)" json:"blood_sugar_context"` // Konteks gula darah: puasa, setelah_makan, sewaktu, sebelum_tidur - nullable (NULL = sewaktu)
 	Weight     *float64  `gorm:"type:double precision" json:"weight"`                 // Berat badan (kg) - nullable
 	HeightCM   *int      `gorm:"type:int;column:height_cm" json:"height,omitempty"` // Tinggi badan dalam cm - nullable
 	HeartRate  *int      `gorm:"type:int" json:"heart_rate"`             // Detak jantung (bpm) - nullable
-	Activity   *string   `gorm:"type:text" json:"activity"`               // Aktivitas terbaru - nullable
+	OxygenSaturation *int     `gorm:"type:int" json:"oxygen_saturation"`             // Saturasi oksigen / SpO2 (%) - nullable
+	Temperature      *float64 `gorm:"type:decimal(4,1)" json:"temperature"`          // Suhu tubuh (°C) - nullable
+	RespiratoryRate  *int     `gorm:"type:int" json:"respiratory_rate"`              // Laju napas (napas/menit) - nullable
+	Activity   *string   `gorm:"type:text" json:"activity"`               // Deskripsi aktivitas teks bebas (data lama sebelum aktivitas terstruktur) - nullable
+	
+	// Aktivitas terstruktur - SEMUA NULLABLE, diisi bersamaan (type, duration, intensity, calories)
+	ActivityType      *ActivityType      `gorm:"type:varchar(30)" json:"activity_type"`          // Jenis aktivitas, mis. jalan_kaki, lari, bersepeda
+	ActivityDuration  *int               `gorm:"type:int" json:"activity_duration"`              // Durasi aktivitas (menit)
+	ActivityIntensity *ActivityIntensity `gorm:"type:varchar(10)" json:"activity_intensity"`     // Intensitas: ringan, sedang, berat
+	Steps             *int               `gorm:"type:int" json:"steps"`                          // Jumlah langkah (opsional)
+	DistanceKm        *float64           `gorm:"type:decimal(6,2)" json:"distance_km"`           // Jarak tempuh (km, opsional)
+	CaloriesBurned    *float64           `gorm:"type:decimal(7,1)" json:"calories_burned"`       // Estimasi kalori (kkal) berbasis MET dan berat badan
 	
-	// Field untuk daily record system
-	RecordDate time.Time `gorm:"type:date;not null;index" json:"record_date"` // Tanggal record (1 record per hari per user)
-	ExpiredAt  *time.Time `gorm:"type:timestamp" json:"expired_at,omitempty"` // Waktu expired (23:59:59 hari ini) - nullable
+	// Catatan dan konteks pembacaan - SEMUA NULLABLE
+	Notes              *string             `gorm:"type:text" json:"notes"`                    // Catatan bebas dari user
+	BodyPosition       *BodyPosition       `gorm:"type:varchar(10)" json:"body_position"`     // Posisi tubuh saat ukur tekanan darah: duduk, berdiri, berbaring
+	MeasurementArm     *MeasurementArm     `gorm:"type:varchar(10)" json:"measurement_arm"`   // Lengan yang dipakai saat ukur tekanan darah: kiri, kanan
+	Symptoms           *string             `gorm:"type:varchar(255)" json:"symptoms"`         // Tag gejala dipisah koma, mis. "pusing,mual"
+	MeasurementContext *MeasurementContext `gorm:"type:varchar(30)" json:"measurement_context"` // Kondisi saat pembacaan, mis. bangun_tidur, setelah_aktivitas
+	
+	// Field waktu pengukuran (1 record = 1 pembacaan, boleh lebih dari 1 per hari)
+	MeasuredAt time.Time `gorm:"index" json:"measured_at"`                      // Waktu pengukuran dilakukan
+	RecordDate time.Time `gorm:"type:date;not null;index" json:"record_date"` // Tanggal pengukuran (Asia/Jakarta) untuk agregasi harian
+	ExpiredAt  *time.Time `gorm:"type:timestamp" json:"expired_at,omitempty"` // Legacy daily record system, tidak diisi lagi - nullable
 	
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+// SymptomList mengembalikan tag gejala pembacaan sebagai slice
+func (h *HealthData) SymptomList() []string {
+	if h.Symptoms == nil || *h.Symptoms == "" {
+		return nil
+	}
+	return strings.Split(*h.Symptoms, ",")
+}
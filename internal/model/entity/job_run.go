@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// Status satu kali eksekusi job background
+const (
+	JobRunStatusRunning = "running" // Job sedang berjalan (atau proses berhenti sebelum job selesai)
+	JobRunStatusSuccess = "success" // Job selesai tanpa error
+	JobRunStatusFailed  = "failed"  // Job selesai dengan error
+)
+
+// IsValidJobRunStatus memeriksa apakah status eksekusi job dikenal
+func IsValidJobRunStatus(status string) bool {
+	switch status {
+	case JobRunStatusRunning, JobRunStatusSuccess, JobRunStatusFailed:
+		return true
+	}
+	return false
+}
+
+// JobRun adalah representasi tabel job_runs di database
+// Satu baris adalah satu kali eksekusi job background (mis. pembersihan token kadaluarsa).
+// Instance berisi hostname proses API yang menjalankan job, karena setiap replika menjalankan job-nya sendiri.
+type JobRun struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	JobName      string     `gorm:"type:varchar(100);not null;index:idx_job_runs_job_name_started_at,priority:1" json:"job_name"`
+	Status       string     `gorm:"type:varchar(20);not null" json:"status"`
+	Instance     string     `gorm:"type:varchar(255);not null;default:''" json:"instance"`
+	StartedAt    time.Time  `gorm:"not null;index;index:idx_job_runs_job_name_started_at,priority:2" json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	DurationMs   int64      `gorm:"not null;default:0" json:"duration_ms"`
+	AffectedRows int64      `gorm:"not null;default:0" json:"affected_rows"` // Jumlah baris yang diproses/dihapus job
+	Error        *string    `gorm:"type:text" json:"error,omitempty"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (JobRun) TableName() string {
+	return "job_runs"
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// LabTestType adalah jenis pemeriksaan laboratorium yang didukung
+type LabTestType string
+
+const (
+	LabTestHbA1c            LabTestType = "hba1c"             // HbA1c (%)
+	LabTestTotalCholesterol LabTestType = "total_cholesterol" // Kolesterol total (mg/dL)
+	LabTestLDL              LabTestType = "ldl"               // Kolesterol LDL (mg/dL)
+	LabTestHDL              LabTestType = "hdl"               // Kolesterol HDL (mg/dL)
+	LabTestTriglycerides    LabTestType = "triglycerides"     // Trigliserida (mg/dL)
+	LabTestUricAcid         LabTestType = "uric_acid"         // Asam urat (mg/dL)
+	LabTestCreatinine       LabTestType = "creatinine"        // Kreatinin (mg/dL)
+)
+
+// LabResult adalah representasi tabel lab_results di database
+// Menyimpan satu hasil pemeriksaan laboratorium. Nilai selalu disimpan dalam satuan standar
+// jenis pemeriksaan (hasil dalam satuan lain dikonversi saat input). Rentang rujukan disimpan
+// per hasil karena setiap laboratorium bisa mencantumkan rentang rujukan yang berbeda.
+type LabResult struct {
+	ID     uint `gorm:"primaryKey" json:"id"`
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+
+	TestType LabTestType `gorm:"type:varchar(30);not null;index" json:"test_type"`
+	Value    float64     `gorm:"type:decimal(8,2);not null" json:"value"`
+	Unit     string      `gorm:"type:varchar(20);not null" json:"unit"` // Satuan standar jenis pemeriksaan
+
+	// Rentang rujukan (dalam satuan standar), NULL berarti tidak ada batas di sisi tersebut
+	ReferenceMin *float64 `gorm:"type:decimal(8,2)" json:"reference_min,omitempty"`
+	ReferenceMax *float64 `gorm:"type:decimal(8,2)" json:"reference_max,omitempty"`
+
+	TestedAt time.Time `gorm:"not null;index" json:"tested_at"`             // Waktu pengambilan sampel/pemeriksaan
+	LabName  *string   `gorm:"type:varchar(100)" json:"lab_name,omitempty"` // Nama laboratorium/klinik
+	Notes    *string   `gorm:"type:text" json:"notes,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (LabResult) TableName() string {
+	return "lab_results"
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// MedicationLogStatus adalah status satu jadwal minum obat
+type MedicationLogStatus string
+
+const (
+	MedicationLogTaken  MedicationLogStatus = "taken"  // Obat sudah diminum
+	MedicationLogMissed MedicationLogStatus = "missed" // Obat terlewat / tidak diminum
+)
+
+// IsValidMedicationLogStatus memeriksa apakah status log obat dikenal
+func IsValidMedicationLogStatus(status MedicationLogStatus) bool {
+	return status == MedicationLogTaken || status == MedicationLogMissed
+}
+
+// Medication adalah representasi tabel medications di database
+// Menyimpan satu obat yang dikonsumsi user beserta jadwal hariannya.
+// Obat tanpa jadwal (ScheduleTimes kosong) dianggap obat "bila perlu" dan tidak dihitung
+// dalam kepatuhan minum obat.
+type Medication struct {
+	ID     uint `gorm:"primaryKey" json:"id"`
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+
+	Name         string  `gorm:"type:varchar(100);not null" json:"name"`     // Nama obat, mis. "Amlodipine"
+	Dose         string  `gorm:"type:varchar(50);not null" json:"dose"`      // Dosis per minum, mis. "5 mg" atau "1 tablet"
+	Instructions *string `gorm:"type:text" json:"instructions,omitempty"`    // Aturan pakai, mis. "Sesudah makan"
+	Purpose      *string `gorm:"type:varchar(100)" json:"purpose,omitempty"` // Tujuan pengobatan, mis. "Hipertensi"
+
+	// ScheduleTimes berisi jam minum obat setiap hari (Asia/Jakarta, format HH:MM)
+	// dipisahkan koma dan terurut, mis. "07:00,19:00"
+	ScheduleTimes string `gorm:"type:varchar(200);not null;default:''" json:"schedule_times"`
+
+	StartDate time.Time  `gorm:"type:date;not null" json:"start_date"` // Tanggal mulai minum obat
+	EndDate   *time.Time `gorm:"type:date" json:"end_date,omitempty"`  // Tanggal terakhir minum obat, NULL = tidak ditentukan
+	IsActive  bool       `gorm:"not null;default:true;index" json:"is_active"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (Medication) TableName() string {
+	return "medications"
+}
+
+// Schedule mengembalikan daftar jam minum obat (HH:MM)
+func (m *Medication) Schedule() []string {
+	if m.ScheduleTimes == "" {
+		return []string{}
+	}
+	return strings.Split(m.ScheduleTimes, ",")
+}
+
+// MedicationLog adalah representasi tabel medication_logs di database
+// Satu baris mencatat satu jadwal minum obat (diminum atau terlewat).
+// Kombinasi medication_id dan scheduled_at unik sehingga pencatatan ulang memperbarui status.
+type MedicationLog struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	MedicationID uint       `gorm:"not null;uniqueIndex:idx_medication_logs_medication_scheduled" json:"medication_id"`
+	Medication   Medication `gorm:"foreignKey:MedicationID;constraint:OnDelete:CASCADE" json:"medication,omitempty"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+
+	ScheduledAt time.Time           `gorm:"not null;uniqueIndex:idx_medication_logs_medication_scheduled;index" json:"scheduled_at"` // Jadwal minum obat
+	Status      MedicationLogStatus `gorm:"type:varchar(20);not null" json:"status"`
+	TakenAt     *time.Time          `json:"taken_at,omitempty"` // Waktu obat benar-benar diminum (hanya untuk status taken)
+	Notes       *string             `gorm:"type:text" json:"notes,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (MedicationLog) TableName() string {
+	return "medication_logs"
+}
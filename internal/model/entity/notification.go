@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+// NotificationType adalah jenis notifikasi in-app
+type NotificationType string
+
+const (
+	NotificationMedicationReminder  NotificationType = "medication_reminder"  // Pengingat minum obat sesuai jadwal
+	NotificationMeasurementReminder NotificationType = "measurement_reminder" // Pengingat mengukur tekanan darah
+	NotificationMissedDayNudge      NotificationType = "missed_day_nudge"     // Ajakan mencatat data setelah beberapa hari tidak mencatat
+	NotificationHealthAlert         NotificationType = "health_alert"         // Health alert baru dari data kesehatan
+	NotificationTargetAchieved      NotificationType = "target_achieved"      // Pembacaan memenuhi target kesehatan user
+)
+
+// IsValidNotificationType memeriksa apakah jenis notifikasi dikenal
+func IsValidNotificationType(notificationType NotificationType) bool {
+	switch notificationType {
+	case NotificationMedicationReminder, NotificationMeasurementReminder, NotificationMissedDayNudge,
+		NotificationHealthAlert, NotificationTargetAchieved:
+		return true
+	}
+	return false
+}
+
+// Notification adalah representasi tabel notifications di database
+// Satu baris adalah satu notifikasi pada inbox in-app milik user.
+// DedupKey (opsional) unik per user agar scheduler tidak mengirim pengingat yang sama dua kali,
+// termasuk setelah restart atau saat beberapa instance API berjalan bersamaan.
+// Title dan Message disimpan dalam bahasa user saat notifikasi dibuat; Params menyimpan nilai template
+// sehingga notifikasi dapat ditampilkan ulang sesuai bahasa user saat ini.
+type Notification struct {
+	ID     uint `gorm:"primaryKey" json:"id"`
+	UserID uint `gorm:"not null;index;uniqueIndex:idx_notifications_user_dedup_key" json:"user_id"`
+	User   User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+
+	Type        NotificationType `gorm:"type:varchar(50);not null;index" json:"type"`
+	Title       string           `gorm:"type:varchar(150);not null" json:"title"`
+	Message     string           `gorm:"type:text;not null" json:"message"`
+	ReferenceID *uint            `json:"reference_id,omitempty"` // ID data terkait (mis. ID obat untuk pengingat minum obat)
+	Params      string           `gorm:"type:text" json:"-"`     // Nilai template (JSON object sebagai string)
+
+	DedupKey *string `gorm:"type:varchar(150);uniqueIndex:idx_notifications_user_dedup_key" json:"-"`
+
+	ReadAt *time.Time `gorm:"index" json:"read_at,omitempty"` // Waktu notifikasi dibaca, NULL = belum dibaca
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// IsRead memeriksa apakah notifikasi sudah dibaca
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (Notification) TableName() string {
+	return "notifications"
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// PasswordResetToken adalah representasi tabel password_reset_tokens di database
+// Token hanya bisa dipakai sekali dan memiliki masa berlaku. Yang disimpan hanya hash SHA-256-nya.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"` // Terisi saat token dipakai atau dibatalkan
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
@@ -2,6 +2,20 @@ package entity
 
 import "time"
 
+// UserRole adalah enum untuk hak akses user
+type UserRole string
+
+const (
+	RolePatient   UserRole = "patient"
+	RoleClinician UserRole = "clinician"
+	RoleAdmin     UserRole = "admin"
+)
+
+// IsValidUserRole memeriksa apakah role dikenal
+func IsValidUserRole(role UserRole) bool {
+	return role == RolePatient || role == RoleClinician || role == RoleAdmin
+}
+
 // User adalah representasi tabel users di Supabase
 type User struct {
 	ID          uint         `gorm:"primaryKey" json:"id"`                    // Primary key User (auto increment: 1, 2, 3, ...)
@@ -9,6 +23,13 @@ type User struct {
 	Username    string       `gorm:"type:varchar(50);unique;not null" json:"username"`
 	Email       string       `gorm:"type:varchar(100);unique;not null" json:"email"`
 	Password    string       `gorm:"type:varchar(255);not null" json:"-"`
+	Role        UserRole     `gorm:"type:varchar(20);not null;default:'patient';index" json:"role"` // Hak akses: patient, clinician, admin
+
+	// Verifikasi email: nil berarti email belum diverifikasi
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+
+	// Akun dinonaktifkan admin: nil berarti akun aktif. Akun nonaktif tidak dapat login maupun refresh token
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
 
 	// Pengaturan aplikasi
 	NotificationEnabled *bool   `gorm:"default:true;column:notification_enabled" json:"notification_enabled,omitempty"`
@@ -19,3 +40,12 @@ type User struct {
 	UpdatedAt   time.Time    `json:"updated_at"`
 }
 
+// IsDisabled memeriksa apakah akun user dinonaktifkan
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// IsEmailVerified memeriksa apakah email user sudah diverifikasi
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// UserSession adalah representasi tabel user_sessions di database
+// Setiap login membuat satu sesi dengan refresh token yang dirotasi setiap kali dipakai.
+// Yang disimpan hanya hash SHA-256 dari refresh token, bukan token aslinya.
+type UserSession struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	UserID            uint       `gorm:"not null;index" json:"user_id"`
+	RefreshTokenHash  string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // Hash refresh token yang berlaku saat ini
+	PreviousTokenHash *string    `gorm:"type:varchar(64);index" json:"-"`                // Hash refresh token sebelum rotasi terakhir (deteksi reuse)
+	UserAgent         string     `gorm:"type:varchar(255)" json:"user_agent"`
+	IPAddress         string     `gorm:"type:varchar(45)" json:"ip_address"`
+	ExpiresAt         time.Time  `gorm:"not null;index" json:"expires_at"` // Waktu kadaluarsa refresh token
+	LastUsedAt        time.Time  `json:"last_used_at"`
+	RevokedAt         *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// TableName mengembalikan nama tabel untuk GORM
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
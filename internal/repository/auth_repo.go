@@ -62,12 +62,288 @@ func (r *AuthRepository) IsTokenBlacklisted(token string) (bool, error) {
 
 // CleanupExpiredTokens menghapus token yang sudah kadaluarsa dari blacklist.
 // Bisa dipanggil secara berkala untuk membersihkan database.
-func (r *AuthRepository) CleanupExpiredTokens() error {
+func (r *AuthRepository) CleanupExpiredTokens() (int64, error) {
 	now := timezoneUtils.NowInJakarta()
 	result := r.db.Where("expires_at < ?", now).Delete(&entity.BlacklistedToken{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// CreateSession menyimpan sesi login baru
+func (r *AuthRepository) CreateSession(session *entity.UserSession) error {
+	result := r.db.Create(session)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetSessionByRefreshTokenHash mengambil sesi berdasarkan hash refresh token yang berlaku
+func (r *AuthRepository) GetSessionByRefreshTokenHash(tokenHash string) (*entity.UserSession, error) {
+	var session entity.UserSession
+	result := r.db.Where("refresh_token_hash = ?", tokenHash).First(&session)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("sesi tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+// GetSessionByPreviousTokenHash mengambil sesi yang refresh token sebelumnya cocok dengan hash
+// Mengembalikan nil jika tidak ada (bukan error)
+func (r *AuthRepository) GetSessionByPreviousTokenHash(tokenHash string) (*entity.UserSession, error) {
+	var session entity.UserSession
+	result := r.db.Where("previous_token_hash = ?", tokenHash).First(&session)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+// RotateSessionToken menyimpan rotasi refresh token secara atomik.
+// Update hanya berlaku jika refresh token currentTokenHash masih berlaku dan sesi belum dicabut,
+// sehingga dua refresh bersamaan dengan token yang sama tidak dapat sama-sama berhasil.
+func (r *AuthRepository) RotateSessionToken(session *entity.UserSession, currentTokenHash string) error {
+	result := r.db.Model(&entity.UserSession{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, currentTokenHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":  session.RefreshTokenHash,
+			"previous_token_hash": session.PreviousTokenHash,
+			"expires_at":          session.ExpiresAt,
+			"last_used_at":        session.LastUsedAt,
+			"user_agent":          session.UserAgent,
+			"ip_address":          session.IPAddress,
+		})
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected != 1 {
+		return errors.New("sesi tidak ditemukan")
+	}
 	return nil
 }
 
+// GetActiveSessionsByUserID mengambil semua sesi user yang belum dicabut dan belum kadaluarsa
+func (r *AuthRepository) GetActiveSessionsByUserID(userID uint) ([]entity.UserSession, error) {
+	var sessions []entity.UserSession
+	result := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, timezoneUtils.NowInJakarta()).
+		Order("last_used_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
+
+// IsSessionActive memeriksa apakah sesi milik user masih aktif (belum dicabut dan belum kadaluarsa)
+func (r *AuthRepository) IsSessionActive(sessionID, userID uint) (bool, error) {
+	var count int64
+	result := r.db.Model(&entity.UserSession{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", sessionID, userID, timezoneUtils.NowInJakarta()).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// IsUserActive mengecek apakah user masih ada dan tidak dinonaktifkan
+func (r *AuthRepository) IsUserActive(userID uint) (bool, error) {
+	var count int64
+	result := r.db.Model(&entity.User{}).
+		Where("id = ? AND disabled_at IS NULL", userID).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// RevokeSession mencabut satu sesi aktif milik user
+func (r *AuthRepository) RevokeSession(sessionID, userID uint) error {
+	result := r.db.Model(&entity.UserSession{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		Update("revoked_at", timezoneUtils.NowInJakarta())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("sesi tidak ditemukan")
+	}
+	return nil
+}
+
+// RevokeAllSessions mencabut semua sesi aktif milik user
+// Mengembalikan jumlah sesi yang dicabut
+func (r *AuthRepository) RevokeAllSessions(userID uint) (int64, error) {
+	result := r.db.Model(&entity.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", timezoneUtils.NowInJakarta())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// RevokeOtherSessions mencabut semua sesi aktif milik user kecuali sesi exceptSessionID
+// Mengembalikan jumlah sesi yang dicabut
+func (r *AuthRepository) RevokeOtherSessions(userID, exceptSessionID uint) (int64, error) {
+	result := r.db.Model(&entity.UserSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptSessionID).
+		Update("revoked_at", timezoneUtils.NowInJakarta())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// CleanupExpiredSessions menghapus sesi yang refresh token-nya sudah kadaluarsa.
+// Bisa dipanggil secara berkala untuk membersihkan database.
+func (r *AuthRepository) CleanupExpiredSessions() (int64, error) {
+	now := timezoneUtils.NowInJakarta()
+	result := r.db.Where("expires_at < ?", now).Delete(&entity.UserSession{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// CreatePasswordResetToken menyimpan token reset password baru
+func (r *AuthRepository) CreatePasswordResetToken(token *entity.PasswordResetToken) error {
+	result := r.db.Create(token)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetPasswordResetTokenByHash mengambil token reset password berdasarkan hash
+func (r *AuthRepository) GetPasswordResetTokenByHash(tokenHash string) (*entity.PasswordResetToken, error) {
+	var token entity.PasswordResetToken
+	result := r.db.Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("token reset password tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+// MarkPasswordResetTokenUsed menandai token sudah dipakai secara atomik.
+// Mengembalikan false jika token sudah dipakai sebelumnya (misalnya request bersamaan).
+func (r *AuthRepository) MarkPasswordResetTokenUsed(id uint) (bool, error) {
+	result := r.db.Model(&entity.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", timezoneUtils.NowInJakarta())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// InvalidatePasswordResetTokens membatalkan semua token reset password user yang belum dipakai
+func (r *AuthRepository) InvalidatePasswordResetTokens(userID uint) error {
+	result := r.db.Model(&entity.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", timezoneUtils.NowInJakarta())
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// CleanupExpiredPasswordResetTokens menghapus token reset password yang sudah kadaluarsa.
+// Bisa dipanggil secara berkala untuk membersihkan database.
+func (r *AuthRepository) CleanupExpiredPasswordResetTokens() (int64, error) {
+	now := timezoneUtils.NowInJakarta()
+	result := r.db.Where("expires_at < ?", now).Delete(&entity.PasswordResetToken{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// CreateEmailVerificationToken menyimpan token verifikasi email baru
+func (r *AuthRepository) CreateEmailVerificationToken(token *entity.EmailVerificationToken) error {
+	result := r.db.Create(token)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetEmailVerificationTokenByHash mengambil token verifikasi email berdasarkan hash
+func (r *AuthRepository) GetEmailVerificationTokenByHash(tokenHash string) (*entity.EmailVerificationToken, error) {
+	var token entity.EmailVerificationToken
+	result := r.db.Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("token verifikasi email tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+// MarkEmailVerificationTokenUsed menandai token verifikasi sudah dipakai secara atomik.
+// Mengembalikan false jika token sudah dipakai sebelumnya.
+func (r *AuthRepository) MarkEmailVerificationTokenUsed(id uint) (bool, error) {
+	result := r.db.Model(&entity.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", timezoneUtils.NowInJakarta())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// InvalidateEmailVerificationTokens membatalkan semua token verifikasi email user yang belum dipakai
+func (r *AuthRepository) InvalidateEmailVerificationTokens(userID uint) error {
+	result := r.db.Model(&entity.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", timezoneUtils.NowInJakarta())
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetEmailVerificationTokenStats mengambil jumlah token verifikasi yang dibuat sejak waktu tertentu
+// beserta waktu pembuatan token terakhir (nil jika belum pernah ada), untuk rate limiting kirim ulang
+func (r *AuthRepository) GetEmailVerificationTokenStats(userID uint, since time.Time) (int64, *time.Time, error) {
+	var count int64
+	if err := r.db.Model(&entity.EmailVerificationToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error; err != nil {
+		return 0, nil, err
+	}
+
+	var latest entity.EmailVerificationToken
+	result := r.db.Where("user_id = ?", userID).Order("created_at DESC").First(&latest)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return count, nil, nil
+		}
+		return 0, nil, result.Error
+	}
+	return count, &latest.CreatedAt, nil
+}
+
+// CleanupExpiredEmailVerificationTokens menghapus token verifikasi email yang sudah kadaluarsa.
+// Bisa dipanggil secara berkala untuk membersihkan database.
+func (r *AuthRepository) CleanupExpiredEmailVerificationTokens() (int64, error) {
+	now := timezoneUtils.NowInJakarta()
+	result := r.db.Where("expires_at < ?", now).Delete(&entity.EmailVerificationToken{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// CareGrantRepository adalah struct yang menampung koneksi database untuk akses caregiver
+type CareGrantRepository struct {
+	db *gorm.DB
+}
+
+// NewCareGrantRepository membuat instance baru dari CareGrantRepository
+func NewCareGrantRepository(db *gorm.DB) *CareGrantRepository {
+	return &CareGrantRepository{
+		db: db,
+	}
+}
+
+// CreateCareGrant melakukan INSERT undangan akses caregiver baru
+func (r *CareGrantRepository) CreateCareGrant(grant *entity.CareGrant) error {
+	result := r.db.Create(grant)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// UpdateCareGrant menyimpan perubahan akses caregiver
+func (r *CareGrantRepository) UpdateCareGrant(grant *entity.CareGrant) error {
+	result := r.db.Omit("Owner", "Caregiver").Save(grant)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetCareGrantByID mengambil akses caregiver beserta data owner dan caregiver
+func (r *CareGrantRepository) GetCareGrantByID(id uint) (*entity.CareGrant, error) {
+	var grant entity.CareGrant
+	result := r.db.Preload("Owner").Preload("Caregiver").First(&grant, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("akses caregiver tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &grant, nil
+}
+
+// GetOpenCareGrant mengambil akses yang masih pending/aktif antara owner dan caregiver
+// Mengembalikan nil jika tidak ada (bukan error)
+func (r *CareGrantRepository) GetOpenCareGrant(ownerID, caregiverID uint) (*entity.CareGrant, error) {
+	var grant entity.CareGrant
+	result := r.db.
+		Where("owner_id = ? AND caregiver_id = ? AND status IN ?", ownerID, caregiverID,
+			[]entity.CareGrantStatus{entity.CareGrantStatusPending, entity.CareGrantStatusActive}).
+		First(&grant)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &grant, nil
+}
+
+// GetActiveCareGrant mengambil akses aktif caregiver ke data owner
+// Mengembalikan nil jika tidak ada (bukan error)
+func (r *CareGrantRepository) GetActiveCareGrant(ownerID, caregiverID uint) (*entity.CareGrant, error) {
+	var grant entity.CareGrant
+	result := r.db.
+		Where("owner_id = ? AND caregiver_id = ? AND status = ?", ownerID, caregiverID, entity.CareGrantStatusActive).
+		First(&grant)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &grant, nil
+}
+
+// GetCareGrantsByOwnerID mengambil semua akses yang diberikan owner (pending dan aktif)
+func (r *CareGrantRepository) GetCareGrantsByOwnerID(ownerID uint) ([]entity.CareGrant, error) {
+	var grants []entity.CareGrant
+	result := r.db.Preload("Owner").Preload("Caregiver").
+		Where("owner_id = ? AND status IN ?", ownerID,
+			[]entity.CareGrantStatus{entity.CareGrantStatusPending, entity.CareGrantStatusActive}).
+		Order("created_at DESC").
+		Find(&grants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return grants, nil
+}
+
+// GetCareGrantsByCaregiverID mengambil semua akses yang diterima caregiver (pending dan aktif)
+func (r *CareGrantRepository) GetCareGrantsByCaregiverID(caregiverID uint) ([]entity.CareGrant, error) {
+	var grants []entity.CareGrant
+	result := r.db.Preload("Owner").Preload("Caregiver").
+		Where("caregiver_id = ? AND status IN ?", caregiverID,
+			[]entity.CareGrantStatus{entity.CareGrantStatusPending, entity.CareGrantStatusActive}).
+		Order("created_at DESC").
+		Find(&grants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return grants, nil
+}
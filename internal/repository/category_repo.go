@@ -55,3 +55,105 @@ func (r *CategoryRepository) GetCategoryByKategori(kategori string) (*entity.Cat
 	return &category, nil
 }
 
+// CreateCategory melakukan INSERT kategori baru ke database
+func (r *CategoryRepository) CreateCategory(category *entity.Category) error {
+	result := r.db.Create(category)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// UpdateCategoryKategori mengubah nama kategori dalam transaksi
+// Kolom health_condition pada video (data lama) yang memakai nama lama ikut diperbarui
+func (r *CategoryRepository) UpdateCategoryKategori(id uint, oldKategori, newKategori string) error {
+	// Mulai transaksi
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Update nama kategori
+	result := tx.Model(&entity.Category{}).Where("id = ?", id).Update("kategori", newKategori)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errors.New("kategori tidak ditemukan")
+	}
+
+	// Sinkronkan health_condition pada video
+	if err := tx.Model(&entity.EducationalVideo{}).
+		Where("health_condition = ?", oldKategori).
+		Update("health_condition", newKategori).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteCategory menghapus kategori beserta relasinya dalam transaksi
+// Relasi di junction table dihapus dan category_id (data lama) pada video dikosongkan.
+// Video tidak ikut dihapus agar tetap bisa dipindahkan ke kategori lain.
+func (r *CategoryRepository) DeleteCategory(id uint) error {
+	// Mulai transaksi
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Hapus relasi many-to-many
+	if err := tx.Exec("DELETE FROM educational_video_categories WHERE category_id = ?", id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Kosongkan category_id pada video data lama
+	if err := tx.Model(&entity.EducationalVideo{}).
+		Where("category_id = ?", id).
+		Update("category_id", nil).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus kategori
+	result := tx.Delete(&entity.Category{}, id)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errors.New("kategori tidak ditemukan")
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckKategoriExists memeriksa apakah nama kategori sudah dipakai kategori lain (case-insensitive)
+// excludeID diisi ID kategori yang sedang diubah agar tidak bentrok dengan dirinya sendiri
+func (r *CategoryRepository) CheckKategoriExists(kategori string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.Model(&entity.Category{}).Where("LOWER(kategori) = LOWER(?)", kategori)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	result := query.Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
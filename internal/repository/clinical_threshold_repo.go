@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ClinicalThresholdRepository adalah struct yang menampung koneksi database untuk batas klinis
+type ClinicalThresholdRepository struct {
+	db *gorm.DB
+}
+
+// NewClinicalThresholdRepository membuat instance baru dari ClinicalThresholdRepository
+func NewClinicalThresholdRepository(db *gorm.DB) *ClinicalThresholdRepository {
+	return &ClinicalThresholdRepository{
+		db: db,
+	}
+}
+
+// GetDefaultThreshold mengambil batas klinis default global
+// Mengembalikan nil jika belum ada (bukan error)
+func (r *ClinicalThresholdRepository) GetDefaultThreshold() (*entity.ClinicalThreshold, error) {
+	var threshold entity.ClinicalThreshold
+	result := r.db.Where("user_id IS NULL").Order("id ASC").First(&threshold)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &threshold, nil
+}
+
+// GetUserThreshold mengambil penyesuaian batas klinis milik user
+// Mengembalikan nil jika user tidak memiliki penyesuaian (bukan error)
+func (r *ClinicalThresholdRepository) GetUserThreshold(userID uint) (*entity.ClinicalThreshold, error) {
+	var threshold entity.ClinicalThreshold
+	result := r.db.Where("user_id = ?", userID).First(&threshold)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &threshold, nil
+}
+
+// GetUserThresholds mengambil penyesuaian batas klinis untuk banyak user sekaligus
+// User tanpa penyesuaian tidak ada di map hasil
+func (r *ClinicalThresholdRepository) GetUserThresholds(userIDs []uint) (map[uint]*entity.ClinicalThreshold, error) {
+	overrides := make(map[uint]*entity.ClinicalThreshold, len(userIDs))
+	if len(userIDs) == 0 {
+		return overrides, nil
+	}
+
+	var thresholds []entity.ClinicalThreshold
+	result := r.db.Where("user_id IN ?", userIDs).Find(&thresholds)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range thresholds {
+		overrides[*thresholds[i].UserID] = &thresholds[i]
+	}
+	return overrides, nil
+}
+
+// SaveThreshold melakukan INSERT atau UPDATE batas klinis (berdasarkan ID)
+func (r *ClinicalThresholdRepository) SaveThreshold(threshold *entity.ClinicalThreshold) error {
+	result := r.db.Omit("User").Save(threshold)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// DeleteUserThreshold menghapus penyesuaian batas klinis milik user
+func (r *ClinicalThresholdRepository) DeleteUserThreshold(userID uint) error {
+	result := r.db.Where("user_id = ?", userID).Delete(&entity.ClinicalThreshold{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("batas klinis pasien tidak ditemukan")
+	}
+	return nil
+}
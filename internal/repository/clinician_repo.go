@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ClinicianRepository adalah struct yang menampung koneksi database untuk relasi clinician dan pasien
+type ClinicianRepository struct {
+	db *gorm.DB
+}
+
+// NewClinicianRepository membuat instance baru dari ClinicianRepository
+func NewClinicianRepository(db *gorm.DB) *ClinicianRepository {
+	return &ClinicianRepository{
+		db: db,
+	}
+}
+
+// AssignPatient melakukan INSERT relasi clinician dan pasien
+func (r *ClinicianRepository) AssignPatient(assignment *entity.ClinicianPatient) error {
+	result := r.db.Omit("Clinician", "Patient").Create(assignment)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// UnassignPatient menghapus relasi clinician dan pasien
+func (r *ClinicianRepository) UnassignPatient(clinicianID, patientID uint) error {
+	result := r.db.Where("clinician_id = ? AND patient_id = ?", clinicianID, patientID).
+		Delete(&entity.ClinicianPatient{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("pasien tidak ditemukan pada daftar clinician")
+	}
+	return nil
+}
+
+// IsPatientAssigned mengecek apakah pasien terhubung dengan clinician
+// Hanya berlaku selama akun clinician masih memiliki role clinician
+func (r *ClinicianRepository) IsPatientAssigned(clinicianID, patientID uint) (bool, error) {
+	var count int64
+	result := r.db.Model(&entity.ClinicianPatient{}).
+		Joins("JOIN users ON users.id = clinician_patients.clinician_id").
+		Where("clinician_patients.clinician_id = ? AND clinician_patients.patient_id = ? AND users.role = ?",
+			clinicianID, patientID, entity.RoleClinician).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// GetPatientsByClinicianID mengambil semua pasien yang terhubung dengan clinician
+func (r *ClinicianRepository) GetPatientsByClinicianID(clinicianID uint) ([]entity.User, error) {
+	var patients []entity.User
+	result := r.db.
+		Joins("JOIN clinician_patients ON clinician_patients.patient_id = users.id").
+		Where("clinician_patients.clinician_id = ?", clinicianID).
+		Order("users.nama ASC").
+		Find(&patients)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return patients, nil
+}
@@ -200,3 +200,114 @@ func (r *EducationalVideoRepository) GetEducationalVideosByCategoryKategori(kate
 	}
 	return videos, nil
 }
+
+// UpdateEducationalVideoWithCategories memperbarui video beserta relasi kategori dalam transaksi
+// Relasi kategori lama di junction table diganti seluruhnya dengan categoryIDs yang baru,
+// dan category_id (data lama) dikosongkan karena junction table menjadi sumber data kategori
+func (r *EducationalVideoRepository) UpdateEducationalVideoWithCategories(video *entity.EducationalVideo, categoryIDs []uint) error {
+	// Mulai transaksi
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Update field video
+	result := tx.Model(&entity.EducationalVideo{}).Where("id = ?", video.ID).Updates(map[string]interface{}{
+		"video_title":      video.VideoTitle,
+		"video_url":        video.VideoURL,
+		"health_condition": video.HealthCondition,
+		"category_id":      nil,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errors.New("video tidak ditemukan")
+	}
+
+	// Load categories terlebih dahulu
+	var categories []entity.Category
+	if err := tx.Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Validasi: pastikan semua category IDs ditemukan
+	// (Ini double-check, karena sudah divalidasi di service layer)
+	if len(categories) != len(categoryIDs) {
+		tx.Rollback()
+		return errors.New("beberapa kategori tidak ditemukan")
+	}
+
+	// Ganti relasi many-to-many dengan kategori yang baru
+	if err := tx.Model(video).Association("Categories").Replace(categories); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteEducationalVideo menghapus video beserta relasinya di junction table dalam transaksi
+func (r *EducationalVideoRepository) DeleteEducationalVideo(id uint) error {
+	// Mulai transaksi
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Hapus relasi many-to-many terlebih dahulu
+	if err := tx.Exec("DELETE FROM educational_video_categories WHERE educational_video_id = ?", id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Hapus video
+	result := tx.Delete(&entity.EducationalVideo{}, id)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errors.New("video tidak ditemukan")
+	}
+
+	// Commit transaksi
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAllEducationalVideosWithCategories mengambil semua video beserta kategorinya, urut berdasarkan ID
+// Kategori data lama (category_id) ikut dimuat untuk video yang belum memakai junction table
+func (r *EducationalVideoRepository) GetAllEducationalVideosWithCategories() ([]entity.EducationalVideo, error) {
+	var videos []entity.EducationalVideo
+	result := r.db.Preload("Categories").
+		Preload("Category").
+		Order("id ASC").
+		Find(&videos)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return videos, nil
+}
+
+// CheckVideoURLExists memeriksa apakah video dengan URL tersebut sudah ada
+func (r *EducationalVideoRepository) CheckVideoURLExists(videoURL string) (bool, error) {
+	var count int64
+	result := r.db.Model(&entity.EducationalVideo{}).Where("video_url = ?", videoURL).Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"time"
 
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // HealthAlertRepository adalah struct yang menampung koneksi database untuk health alerts
@@ -65,3 +67,179 @@ func (r *HealthAlertRepository) GetHealthAlertByID(id uint) (*entity.HealthAlert
 	return &alert, nil
 }
 
+// HealthAlertFilter berisi filter opsional untuk riwayat alert
+// Field kosong/nil tidak dipakai sebagai filter
+type HealthAlertFilter struct {
+	Status    string
+	Category  string
+	State     string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// UpsertHealthAlertLocked menyimpan alert harian (1 alert per kategori per hari per user).
+// Alert dibuat dengan INSERT ... ON CONFLICT DO NOTHING pada unique index (user_id, category, record_date);
+// jika sudah ada, baris dikunci (SELECT ... FOR UPDATE) lalu diperbarui lewat update di dalam transaksi yang sama,
+// sehingga sinkronisasi bersamaan tidak membuat alert ganda maupun saling menimpa.
+// Mengembalikan true jika alert baru dibuat (ID terisi di alert).
+func (r *HealthAlertRepository) UpsertHealthAlertLocked(alert *entity.HealthAlert, update func(existing *entity.HealthAlert)) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "category"}, {Name: "record_date"}},
+			DoNothing: true,
+		}).Create(alert)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			created = true
+			return nil
+		}
+
+		var existing entity.HealthAlert
+		dateStr := timezoneUtils.ToJakarta(alert.RecordDate).Format("2006-01-02")
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND category = ? AND record_date = ?", alert.UserID, alert.Category, dateStr).
+			First(&existing).Error; err != nil {
+			return err
+		}
+
+		update(&existing)
+		return tx.Save(&existing).Error
+	})
+	return created, err
+}
+
+// UpdateHealthAlert menyimpan perubahan alert yang sudah ada
+func (r *HealthAlertRepository) UpdateHealthAlert(alert *entity.HealthAlert) error {
+	result := r.db.Save(alert)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetHealthAlertsWithFilter mengambil alert milik user dengan filter dan pagination
+// Mengembalikan daftar alert dan total data (sebelum pagination)
+func (r *HealthAlertRepository) GetHealthAlertsWithFilter(userID uint, filter HealthAlertFilter, offset, limit int) ([]entity.HealthAlert, int64, error) {
+	query := r.db.Model(&entity.HealthAlert{}).Where("user_id = ?", userID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.State != "" {
+		query = query.Where("state = ?", filter.State)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("DATE(record_date) >= ?", filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		query = query.Where("DATE(record_date) <= ?", filter.EndDate.Format("2006-01-02"))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var alerts []entity.HealthAlert
+	result := query.Order("record_date DESC, recorded_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&alerts)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return alerts, total, nil
+}
+
+// GetHealthAlertByIDAndUserID mengambil alert berdasarkan ID yang dimiliki oleh user tertentu
+func (r *HealthAlertRepository) GetHealthAlertByIDAndUserID(id, userID uint) (*entity.HealthAlert, error) {
+	var alert entity.HealthAlert
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&alert)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("alert tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &alert, nil
+}
+
+// CountUnreadHealthAlerts menghitung jumlah alert dengan state unread milik user
+func (r *HealthAlertRepository) CountUnreadHealthAlerts(userID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&entity.HealthAlert{}).
+		Where("user_id = ? AND state = ?", userID, entity.AlertStateUnread).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// CountUnreadHealthAlertsByUserIDs menghitung alert unread untuk banyak user sekaligus (satu query)
+// User tanpa alert unread tidak ada di map hasil
+func (r *HealthAlertRepository) CountUnreadHealthAlertsByUserIDs(userIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		UserID uint
+		Count  int64
+	}
+	result := r.db.Model(&entity.HealthAlert{}).
+		Select("user_id, COUNT(*) AS count").
+		Where("user_id IN ? AND state = ?", userIDs, entity.AlertStateUnread).
+		Group("user_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
+
+// MarkAllHealthAlertsRead mengubah semua alert unread milik user menjadi read
+// Mengembalikan jumlah alert yang diperbarui
+func (r *HealthAlertRepository) MarkAllHealthAlertsRead(userID uint, readAt time.Time) (int64, error) {
+	result := r.db.Model(&entity.HealthAlert{}).
+		Where("user_id = ? AND state = ?", userID, entity.AlertStateUnread).
+		Updates(map[string]interface{}{
+			"state":   entity.AlertStateRead,
+			"read_at": readAt,
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// GetHealthAlertsByUserIDAndDate mengambil semua alert milik user pada record_date tertentu
+func (r *HealthAlertRepository) GetHealthAlertsByUserIDAndDate(userID uint, recordDate time.Time) ([]entity.HealthAlert, error) {
+	var alerts []entity.HealthAlert
+	dateStr := timezoneUtils.ToJakarta(recordDate).Format("2006-01-02")
+	result := r.db.Where("user_id = ? AND DATE(record_date) = ?", userID, dateStr).Find(&alerts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return alerts, nil
+}
+
+// DeleteHealthAlert menghapus alert berdasarkan ID
+func (r *HealthAlertRepository) DeleteHealthAlert(id uint) error {
+	result := r.db.Delete(&entity.HealthAlert{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
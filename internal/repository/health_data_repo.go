@@ -3,6 +3,7 @@ package repository
 import (
 	"BE-PeriksaKesehatan/internal/model/entity"
 	"errors"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -74,7 +75,7 @@ func (r *HealthDataRepository) GetHealthDataByUserIDWithFilter(userID uint, star
 	// Range inklusif: DATE(record_date) >= startDate AND DATE(record_date) <= endDate
 	query := r.db.Where("user_id = ?", userID).
 		Where("DATE(record_date) >= ? AND DATE(record_date) <= ?", startDateStr, endDateStr).
-		Order("record_date DESC, created_at DESC")
+		Order("record_date DESC, measured_at DESC")
 	
 	result := query.Find(&healthDataList)
 	if result.Error != nil {
@@ -90,8 +91,8 @@ func (r *HealthDataRepository) GetHealthDataForComparison(userID uint, startDate
 	
 	var healthDataList []entity.HealthData
 	query := r.db.Where("user_id = ?", userID).
-		Where("created_at >= ? AND created_at <= ?", prevStartDate, prevEndDate).
-		Order("created_at DESC")
+		Where("measured_at >= ? AND measured_at <= ?", prevStartDate, prevEndDate).
+		Order("measured_at DESC")
 	
 	result := query.Find(&healthDataList)
 	if result.Error != nil {
@@ -100,10 +101,11 @@ func (r *HealthDataRepository) GetHealthDataForComparison(userID uint, startDate
 	return healthDataList, nil
 }
 
+// GetLatestHealthDataByUserID mengambil 1 pembacaan terakhir milik user (berdasarkan measured_at)
 func (r *HealthDataRepository) GetLatestHealthDataByUserID(userID uint) (*entity.HealthData, error) {
 	var healthData entity.HealthData
 	result := r.db.Where("user_id = ?", userID).
-		Order("created_at DESC").
+		Order("measured_at DESC, id DESC").
 		First(&healthData)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -114,12 +116,13 @@ func (r *HealthDataRepository) GetLatestHealthDataByUserID(userID uint) (*entity
 	return &healthData, nil
 }
 
-// GetHealthDataByUserIDAndDate mencari record berdasarkan user_id dan record_date
-// Digunakan untuk daily record system (1 record per hari per user)
-func (r *HealthDataRepository) GetHealthDataByUserIDAndDate(userID uint, date time.Time) (*entity.HealthData, error) {
+// GetLatestWeightByUserID mengambil berat badan terakhir user yang diukur sebelum atau pada waktu tertentu
+// Mengembalikan nil jika user belum pernah mencatat berat badan sampai waktu tersebut
+func (r *HealthDataRepository) GetLatestWeightByUserID(userID uint, before time.Time) (*float64, error) {
 	var healthData entity.HealthData
-	// Gunakan DATE() untuk membandingkan hanya bagian tanggal, bukan waktu
-	result := r.db.Where("user_id = ? AND DATE(record_date) = DATE(?)", userID, date).
+	result := r.db.Select("weight").
+		Where("user_id = ? AND weight IS NOT NULL AND measured_at <= ?", userID, before).
+		Order("measured_at DESC, id DESC").
 		First(&healthData)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -127,9 +130,279 @@ func (r *HealthDataRepository) GetHealthDataByUserIDAndDate(userID uint, date ti
 		}
 		return nil, result.Error
 	}
+	return healthData.Weight, nil
+}
+
+// GetLatestHeightByUserID mengambil tinggi badan terakhir user yang diukur sebelum atau pada waktu tertentu
+// Mengembalikan nil jika user belum pernah mencatat tinggi badan sampai waktu tersebut
+func (r *HealthDataRepository) GetLatestHeightByUserID(userID uint, before time.Time) (*int, error) {
+	var healthData entity.HealthData
+	result := r.db.Select("height_cm").
+		Where("user_id = ? AND height_cm IS NOT NULL AND measured_at <= ?", userID, before).
+		Order("measured_at DESC, id DESC").
+		First(&healthData)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return healthData.HeightCM, nil
+}
+
+// GetHealthDataByIDAndUserID mengambil pembacaan berdasarkan ID yang dimiliki oleh user tertentu
+func (r *HealthDataRepository) GetHealthDataByIDAndUserID(id, userID uint) (*entity.HealthData, error) {
+	var healthData entity.HealthData
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&healthData)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("data kesehatan tidak ditemukan")
+		}
+		return nil, result.Error
+	}
 	return &healthData, nil
 }
 
+// DeleteHealthData menghapus pembacaan berdasarkan ID
+func (r *HealthDataRepository) DeleteHealthData(id uint) error {
+	result := r.db.Delete(&entity.HealthData{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetHealthDataListByUserIDAndDate mengambil semua pembacaan user pada tanggal tertentu
+// Diurutkan berdasarkan measured_at ASC (pagi ke malam)
+func (r *HealthDataRepository) GetHealthDataListByUserIDAndDate(userID uint, date time.Time) ([]entity.HealthData, error) {
+	var healthDataList []entity.HealthData
+	dateJakarta := timezoneUtils.ToJakarta(date)
+	// Gunakan DATE() untuk membandingkan hanya bagian tanggal, bukan waktu
+	result := r.db.Where("user_id = ? AND DATE(record_date) = ?", userID, dateJakarta.Format("2006-01-02")).
+		Order("measured_at ASC, id ASC").
+		Find(&healthDataList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return healthDataList, nil
+}
+
+// GetLatestHealthSnapshotByUserID menggabungkan nilai terakhir yang tidak NULL dari setiap metrik
+// menjadi satu data kesehatan. Setiap pembacaan bisa hanya berisi sebagian metrik, sehingga
+// nilai terbaru per metrik bisa berasal dari pembacaan yang berbeda.
+// ID, MeasuredAt, RecordDate, dan CreatedAt diambil dari pembacaan paling akhir.
+// Mengembalikan nil jika user belum memiliki data.
+func (r *HealthDataRepository) GetLatestHealthSnapshotByUserID(userID uint) (*entity.HealthData, error) {
+	latest, err := r.GetLatestHealthDataByUserID(userID)
+	if err != nil || latest == nil {
+		return latest, err
+	}
+
+	snapshot := *latest
+
+	// Tekanan darah selalu diambil berpasangan dari pembacaan yang sama
+	if snapshot.Systolic == nil || snapshot.Diastolic == nil {
+		bp, err := r.getLatestHealthDataWithColumns(userID, "systolic", "diastolic")
+		if err != nil {
+			return nil, err
+		}
+		if bp != nil {
+			snapshot.Systolic = bp.Systolic
+			snapshot.Diastolic = bp.Diastolic
+		}
+	}
+
+	if snapshot.BloodSugar == nil {
+		d, err := r.getLatestHealthDataWithColumns(userID, "blood_sugar")
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			snapshot.BloodSugar = d.BloodSugar
+			snapshot.BloodSugarContext = d.BloodSugarContext
+		}
+	}
+
+	if snapshot.Weight == nil {
+		d, err := r.getLatestHealthDataWithColumns(userID, "weight")
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			snapshot.Weight = d.Weight
+		}
+	}
+
+	if snapshot.HeightCM == nil {
+		d, err := r.getLatestHealthDataWithColumns(userID, "height_cm")
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			snapshot.HeightCM = d.HeightCM
+		}
+	}
+
+	if snapshot.HeartRate == nil {
+		d, err := r.getLatestHealthDataWithColumns(userID, "heart_rate")
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			snapshot.HeartRate = d.HeartRate
+		}
+	}
+
+	if snapshot.OxygenSaturation == nil {
+		d, err := r.getLatestHealthDataWithColumns(userID, "oxygen_saturation")
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			snapshot.OxygenSaturation = d.OxygenSaturation
+		}
+	}
+
+	if snapshot.Temperature == nil {
+		d, err := r.getLatestHealthDataWithColumns(userID, "temperature")
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			snapshot.Temperature = d.Temperature
+		}
+	}
+
+	if snapshot.RespiratoryRate == nil {
+		d, err := r.getLatestHealthDataWithColumns(userID, "respiratory_rate")
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			snapshot.RespiratoryRate = d.RespiratoryRate
+		}
+	}
+
+	// Aktivitas terstruktur diambil utuh dari pembacaan yang sama;
+	// teks aktivitas lama hanya dipakai jika belum ada aktivitas terstruktur
+	if snapshot.ActivityType == nil && snapshot.Activity == nil {
+		d, err := r.getLatestHealthDataWithColumns(userID, "activity_type")
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			d, err = r.getLatestHealthDataWithColumns(userID, "activity")
+			if err != nil {
+				return nil, err
+			}
+		}
+		if d != nil {
+			snapshot.Activity = d.Activity
+			snapshot.ActivityType = d.ActivityType
+			snapshot.ActivityDuration = d.ActivityDuration
+			snapshot.ActivityIntensity = d.ActivityIntensity
+			snapshot.Steps = d.Steps
+			snapshot.DistanceKm = d.DistanceKm
+			snapshot.CaloriesBurned = d.CaloriesBurned
+		}
+	}
+
+	return &snapshot, nil
+}
+
+// getLatestHealthDataWithColumns mengambil pembacaan terakhir yang kolom-kolomnya tidak NULL
+// Mengembalikan nil jika tidak ada pembacaan yang memenuhi
+func (r *HealthDataRepository) getLatestHealthDataWithColumns(userID uint, columns ...string) (*entity.HealthData, error) {
+	var healthData entity.HealthData
+	query := r.db.Where("user_id = ?", userID)
+	for _, column := range columns {
+		query = query.Where(column + " IS NOT NULL")
+	}
+	result := query.Order("measured_at DESC, id DESC").First(&healthData)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &healthData, nil
+}
+
+// vitalSnapshotRow adalah hasil query GetLatestVitalSnapshotsByUserIDs untuk satu user
+type vitalSnapshotRow struct {
+	UserID            uint
+	MeasuredAt        time.Time
+	Systolic          *int
+	Diastolic         *int
+	BloodSugar        *int
+	BloodSugarContext *entity.BloodSugarContext
+	Weight            *float64
+	HeightCM          *int
+	HeartRate         *int
+	OxygenSaturation  *int
+	Temperature       *float64
+	RespiratoryRate   *int
+}
+
+// latestNonNull membentuk ekspresi nilai terakhir kolom dari pembacaan yang memenuhi kondisi (per user)
+func latestNonNull(column, condition string) string {
+	return "(ARRAY_AGG(" + column + " ORDER BY measured_at DESC, id DESC) FILTER (WHERE " + condition + "))[1] AS " + column
+}
+
+// GetLatestVitalSnapshotsByUserIDs mengambil nilai terakhir yang tidak NULL dari setiap tanda vital
+// untuk banyak user sekaligus dalam satu query (dipakai dashboard clinician).
+// Aturan penggabungannya sama dengan GetLatestHealthSnapshotByUserID: tekanan darah berpasangan
+// dan konteks gula darah diambil dari pembacaan yang sama; MeasuredAt adalah pembacaan paling akhir.
+// Aktivitas tidak disertakan. User yang belum memiliki data tidak ada di map hasil.
+func (r *HealthDataRepository) GetLatestVitalSnapshotsByUserIDs(userIDs []uint) (map[uint]*entity.HealthData, error) {
+	snapshots := make(map[uint]*entity.HealthData, len(userIDs))
+	if len(userIDs) == 0 {
+		return snapshots, nil
+	}
+
+	bloodPressure := "systolic IS NOT NULL AND diastolic IS NOT NULL"
+	var rows []vitalSnapshotRow
+	result := r.db.Model(&entity.HealthData{}).
+		Select(strings.Join([]string{
+			"user_id",
+			"MAX(measured_at) AS measured_at",
+			latestNonNull("systolic", bloodPressure),
+			latestNonNull("diastolic", bloodPressure),
+			latestNonNull("blood_sugar", "blood_sugar IS NOT NULL"),
+			latestNonNull("blood_sugar_context", "blood_sugar IS NOT NULL"),
+			latestNonNull("weight", "weight IS NOT NULL"),
+			latestNonNull("height_cm", "height_cm IS NOT NULL"),
+			latestNonNull("heart_rate", "heart_rate IS NOT NULL"),
+			latestNonNull("oxygen_saturation", "oxygen_saturation IS NOT NULL"),
+			latestNonNull("temperature", "temperature IS NOT NULL"),
+			latestNonNull("respiratory_rate", "respiratory_rate IS NOT NULL"),
+		}, ", ")).
+		Where("user_id IN ?", userIDs).
+		Group("user_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, row := range rows {
+		snapshots[row.UserID] = &entity.HealthData{
+			UserID:            row.UserID,
+			MeasuredAt:        row.MeasuredAt,
+			Systolic:          row.Systolic,
+			Diastolic:         row.Diastolic,
+			BloodSugar:        row.BloodSugar,
+			BloodSugarContext: row.BloodSugarContext,
+			Weight:            row.Weight,
+			HeightCM:          row.HeightCM,
+			HeartRate:         row.HeartRate,
+			OxygenSaturation:  row.OxygenSaturation,
+			Temperature:       row.Temperature,
+			RespiratoryRate:   row.RespiratoryRate,
+		}
+	}
+	return snapshots, nil
+}
+
 // UpdateHealthData melakukan partial update pada health data
 // Hanya field yang tidak nil yang akan di-update
 // Field yang nil akan diabaikan (tidak di-overwrite dengan NULL)
@@ -147,6 +420,9 @@ func (r *HealthDataRepository) UpdateHealthData(healthData *entity.HealthData) e
 	if healthData.BloodSugar != nil {
 		updates["blood_sugar"] = *healthData.BloodSugar
 	}
+	if healthData.BloodSugarContext != nil {
+		updates["blood_sugar_context"] = *healthData.BloodSugarContext
+	}
 	if healthData.Weight != nil {
 		updates["weight"] = *healthData.Weight
 	}
@@ -156,9 +432,52 @@ func (r *HealthDataRepository) UpdateHealthData(healthData *entity.HealthData) e
 	if healthData.HeartRate != nil {
 		updates["heart_rate"] = *healthData.HeartRate
 	}
+	if healthData.OxygenSaturation != nil {
+		updates["oxygen_saturation"] = *healthData.OxygenSaturation
+	}
+	if healthData.Temperature != nil {
+		updates["temperature"] = *healthData.Temperature
+	}
+	if healthData.RespiratoryRate != nil {
+		updates["respiratory_rate"] = *healthData.RespiratoryRate
+	}
 	if healthData.Activity != nil {
 		updates["activity"] = *healthData.Activity
 	}
+	if healthData.ActivityType != nil {
+		// Aktivitas terstruktur diganti sebagai satu kesatuan: langkah/jarak yang tidak dikirim dikosongkan
+		// dan teks aktivitas lama dihapus
+		updates["activity"] = gorm.Expr("NULL")
+		updates["activity_type"] = *healthData.ActivityType
+		updates["activity_duration"] = nullableUpdateValue(healthData.ActivityDuration)
+		updates["activity_intensity"] = nullableUpdateValue(healthData.ActivityIntensity)
+		updates["steps"] = nullableUpdateValue(healthData.Steps)
+		updates["distance_km"] = nullableUpdateValue(healthData.DistanceKm)
+	}
+	if healthData.CaloriesBurned != nil {
+		// Kalori bisa dihitung ulang tanpa mengubah aktivitas (mis. setelah berat badan dikoreksi)
+		updates["calories_burned"] = *healthData.CaloriesBurned
+	}
+	// Catatan dan konteks pembacaan: string kosong berarti menghapus nilai
+	if healthData.Notes != nil {
+		updates["notes"] = nullableStringUpdateValue(*healthData.Notes)
+	}
+	if healthData.BodyPosition != nil {
+		updates["body_position"] = nullableStringUpdateValue(string(*healthData.BodyPosition))
+	}
+	if healthData.MeasurementArm != nil {
+		updates["measurement_arm"] = nullableStringUpdateValue(string(*healthData.MeasurementArm))
+	}
+	if healthData.Symptoms != nil {
+		updates["symptoms"] = nullableStringUpdateValue(*healthData.Symptoms)
+	}
+	if healthData.MeasurementContext != nil {
+		updates["measurement_context"] = nullableStringUpdateValue(string(*healthData.MeasurementContext))
+	}
+	if !healthData.MeasuredAt.IsZero() {
+		updates["measured_at"] = healthData.MeasuredAt
+		updates["record_date"] = timezoneUtils.ToJakarta(healthData.MeasuredAt).Format("2006-01-02")
+	}
 	
 	// Update hanya jika ada field yang akan di-update
 	if len(updates) > 0 {
@@ -171,3 +490,114 @@ func (r *HealthDataRepository) UpdateHealthData(healthData *entity.HealthData) e
 	return nil
 }
 
+// nullableUpdateValue mengubah pointer menjadi nilai untuk Updates; pointer nil menjadi NULL
+func nullableUpdateValue[T any](value *T) interface{} {
+	if value == nil {
+		return gorm.Expr("NULL")
+	}
+	return *value
+}
+
+// nullableStringUpdateValue mengubah string untuk Updates; string kosong menjadi NULL
+func nullableStringUpdateValue(value string) interface{} {
+	if value == "" {
+		return gorm.Expr("NULL")
+	}
+	return value
+}
+
+// HealthDataSearchFilter berisi filter opsional untuk pencarian pembacaan
+// Field kosong tidak dipakai sebagai filter
+type HealthDataSearchFilter struct {
+	Query              string // Kata kunci pada catatan atau tag gejala
+	Symptom            string // Tag gejala yang harus ada pada pembacaan
+	MeasurementContext entity.MeasurementContext
+	StartDate          *time.Time
+	EndDate            *time.Time
+}
+
+// SearchHealthData mencari pembacaan milik user berdasarkan filter dengan pagination
+// Diurutkan dari pengukuran terbaru. Mengembalikan daftar pembacaan dan total data (sebelum pagination)
+func (r *HealthDataRepository) SearchHealthData(userID uint, filter HealthDataSearchFilter, offset, limit int) ([]entity.HealthData, int64, error) {
+	query := r.db.Model(&entity.HealthData{}).Where("user_id = ?", userID)
+	if filter.Query != "" {
+		// Tag gejala disimpan dengan garis bawah, sehingga "sakit kepala" juga cocok dengan sakit_kepala
+		keyword := "%" + escapeLikePattern(filter.Query) + "%"
+		symptomKeyword := "%" + escapeLikePattern(strings.ReplaceAll(strings.ToLower(filter.Query), " ", "_")) + "%"
+		query = query.Where("(notes ILIKE ? OR symptoms ILIKE ?)", keyword, symptomKeyword)
+	}
+	if filter.Symptom != "" {
+		query = query.Where("(',' || symptoms || ',') LIKE ?", "%,"+escapeLikePattern(filter.Symptom)+",%")
+	}
+	if filter.MeasurementContext != "" {
+		query = query.Where("measurement_context = ?", filter.MeasurementContext)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("record_date >= ?", filter.StartDate.Format("2006-01-02"))
+	}
+	if filter.EndDate != nil {
+		query = query.Where("record_date <= ?", filter.EndDate.Format("2006-01-02"))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var healthDataList []entity.HealthData
+	result := query.Order("measured_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&healthDataList)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return healthDataList, total, nil
+}
+
+// escapeLikePattern meng-escape karakter wildcard LIKE (%, _, \) pada kata kunci pencarian
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// ReminderCandidate adalah user yang menjadi kandidat pengingat beserta tanggal pencatatan terakhirnya
+type ReminderCandidate struct {
+	UserID         uint
+	LastRecordDate time.Time
+}
+
+// GetBloodPressureReminderCandidates mengambil user yang mengaktifkan notifikasi, pernah mencatat tekanan darah
+// sejak tanggal since, tetapi belum mencatat tekanan darah pada tanggal today
+func (r *HealthDataRepository) GetBloodPressureReminderCandidates(since, today time.Time) ([]ReminderCandidate, error) {
+	var candidates []ReminderCandidate
+	result := r.db.Model(&entity.HealthData{}).
+		Select("health_data.user_id AS user_id, MAX(DATE(health_data.record_date)) AS last_record_date").
+		Joins("JOIN users ON users.id = health_data.user_id").
+		Where("health_data.systolic IS NOT NULL AND DATE(health_data.record_date) >= ?", since.Format("2006-01-02")).
+		Where("users.notification_enabled IS NOT FALSE").
+		Group("health_data.user_id").
+		Having("MAX(DATE(health_data.record_date)) < ?", today.Format("2006-01-02")).
+		Scan(&candidates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return candidates, nil
+}
+
+// GetInactiveReminderCandidates mengambil user yang mengaktifkan notifikasi dan pencatatan data kesehatan
+// terakhirnya berada pada rentang [since, before) - sudah pernah aktif tetapi berhenti mencatat
+func (r *HealthDataRepository) GetInactiveReminderCandidates(since, before time.Time) ([]ReminderCandidate, error) {
+	var candidates []ReminderCandidate
+	result := r.db.Model(&entity.HealthData{}).
+		Select("health_data.user_id AS user_id, MAX(DATE(health_data.record_date)) AS last_record_date").
+		Joins("JOIN users ON users.id = health_data.user_id").
+		Where("users.notification_enabled IS NOT FALSE").
+		Group("health_data.user_id").
+		Having("MAX(DATE(health_data.record_date)) >= ? AND MAX(DATE(health_data.record_date)) < ?",
+			since.Format("2006-01-02"), before.Format("2006-01-02")).
+		Scan(&candidates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return candidates, nil
+}
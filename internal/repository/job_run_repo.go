@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JobRunRepository adalah struct yang menampung koneksi database untuk riwayat eksekusi job background
+type JobRunRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRunRepository membuat instance baru dari JobRunRepository
+func NewJobRunRepository(db *gorm.DB) *JobRunRepository {
+	return &JobRunRepository{
+		db: db,
+	}
+}
+
+// JobRunFilter berisi filter opsional untuk riwayat eksekusi job
+// Field kosong tidak dipakai sebagai filter
+type JobRunFilter struct {
+	JobName string
+	Status  string
+}
+
+// JobLastSuccess adalah waktu terakhir suatu job selesai tanpa error
+type JobLastSuccess struct {
+	JobName    string
+	FinishedAt time.Time
+}
+
+// CreateJobRun menyimpan awal eksekusi job (status running)
+func (r *JobRunRepository) CreateJobRun(run *entity.JobRun) error {
+	return r.db.Create(run).Error
+}
+
+// FinishJobRun menyimpan hasil akhir eksekusi job
+func (r *JobRunRepository) FinishJobRun(run *entity.JobRun) error {
+	result := r.db.Model(&entity.JobRun{}).
+		Where("id = ?", run.ID).
+		Updates(map[string]interface{}{
+			"status":        run.Status,
+			"finished_at":   run.FinishedAt,
+			"duration_ms":   run.DurationMs,
+			"affected_rows": run.AffectedRows,
+			"error":         run.Error,
+		})
+	return result.Error
+}
+
+// GetLatestJobRuns mengambil eksekusi terakhir untuk setiap job
+func (r *JobRunRepository) GetLatestJobRuns() ([]entity.JobRun, error) {
+	var runs []entity.JobRun
+	result := r.db.Raw("SELECT DISTINCT ON (job_name) * FROM job_runs ORDER BY job_name, started_at DESC, id DESC").Scan(&runs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return runs, nil
+}
+
+// GetLastSuccessfulJobRuns mengambil waktu selesai terakhir yang sukses untuk setiap job
+func (r *JobRunRepository) GetLastSuccessfulJobRuns() ([]JobLastSuccess, error) {
+	var rows []JobLastSuccess
+	result := r.db.Model(&entity.JobRun{}).
+		Select("job_name, MAX(finished_at) AS finished_at").
+		Where("status = ? AND finished_at IS NOT NULL", entity.JobRunStatusSuccess).
+		Group("job_name").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rows, nil
+}
+
+// GetJobRunsWithFilter mengambil riwayat eksekusi job dengan filter dan pagination
+// Diurutkan dari eksekusi terbaru. Mengembalikan daftar eksekusi dan total data (sebelum pagination)
+func (r *JobRunRepository) GetJobRunsWithFilter(filter JobRunFilter, offset, limit int) ([]entity.JobRun, int64, error) {
+	query := r.db.Model(&entity.JobRun{})
+	if filter.JobName != "" {
+		query = query.Where("job_name = ?", filter.JobName)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []entity.JobRun
+	result := query.Order("started_at DESC, id DESC").Offset(offset).Limit(limit).Find(&runs)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return runs, total, nil
+}
+
+// CleanupOldJobRuns menghapus riwayat eksekusi job yang dimulai sebelum waktu tertentu.
+// Bisa dipanggil secara berkala untuk membersihkan database.
+func (r *JobRunRepository) CleanupOldJobRuns(before time.Time) (int64, error) {
+	result := r.db.Where("started_at < ?", before).Delete(&entity.JobRun{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LabResultRepository adalah struct yang menampung koneksi database untuk hasil laboratorium
+type LabResultRepository struct {
+	db *gorm.DB
+}
+
+// NewLabResultRepository membuat instance baru dari LabResultRepository
+func NewLabResultRepository(db *gorm.DB) *LabResultRepository {
+	return &LabResultRepository{
+		db: db,
+	}
+}
+
+// LabResultFilter berisi filter opsional untuk riwayat hasil laboratorium
+// Field kosong/nil tidak dipakai sebagai filter
+type LabResultFilter struct {
+	TestType  string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// CreateLabResult menyimpan hasil laboratorium baru
+func (r *LabResultRepository) CreateLabResult(labResult *entity.LabResult) error {
+	result := r.db.Omit("User").Create(labResult)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetLabResultByIDAndUserID mengambil hasil laboratorium berdasarkan ID yang dimiliki oleh user tertentu
+func (r *LabResultRepository) GetLabResultByIDAndUserID(id, userID uint) (*entity.LabResult, error) {
+	var labResult entity.LabResult
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&labResult)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("hasil lab tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &labResult, nil
+}
+
+// UpdateLabResult menyimpan perubahan hasil laboratorium
+func (r *LabResultRepository) UpdateLabResult(labResult *entity.LabResult) error {
+	result := r.db.Omit("User").Save(labResult)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// DeleteLabResult menghapus hasil laboratorium berdasarkan ID
+func (r *LabResultRepository) DeleteLabResult(id uint) error {
+	result := r.db.Delete(&entity.LabResult{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("hasil lab tidak ditemukan")
+	}
+	return nil
+}
+
+// GetLabResultsWithFilter mengambil hasil laboratorium milik user dengan filter dan pagination
+// Diurutkan dari pemeriksaan terbaru. Mengembalikan daftar hasil dan total data (sebelum pagination)
+func (r *LabResultRepository) GetLabResultsWithFilter(userID uint, filter LabResultFilter, offset, limit int) ([]entity.LabResult, int64, error) {
+	query := r.applyLabResultFilter(r.db.Model(&entity.LabResult{}).Where("user_id = ?", userID), filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var labResults []entity.LabResult
+	result := query.Order("tested_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&labResults)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return labResults, total, nil
+}
+
+// GetLabResultsByUserID mengambil semua hasil laboratorium milik user sesuai filter tanpa pagination
+// Diurutkan dari pemeriksaan terlama (untuk grafik tren dan laporan)
+func (r *LabResultRepository) GetLabResultsByUserID(userID uint, filter LabResultFilter) ([]entity.LabResult, error) {
+	var labResults []entity.LabResult
+	result := r.applyLabResultFilter(r.db.Where("user_id = ?", userID), filter).
+		Order("tested_at ASC, id ASC").
+		Find(&labResults)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return labResults, nil
+}
+
+// applyLabResultFilter menambahkan kondisi filter ke query
+func (r *LabResultRepository) applyLabResultFilter(query *gorm.DB, filter LabResultFilter) *gorm.DB {
+	if filter.TestType != "" {
+		query = query.Where("test_type = ?", filter.TestType)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("tested_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("tested_at <= ?", *filter.EndDate)
+	}
+	return query
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MedicationRepository adalah struct yang menampung koneksi database untuk obat dan log minum obat
+type MedicationRepository struct {
+	db *gorm.DB
+}
+
+// NewMedicationRepository membuat instance baru dari MedicationRepository
+func NewMedicationRepository(db *gorm.DB) *MedicationRepository {
+	return &MedicationRepository{
+		db: db,
+	}
+}
+
+// MedicationFilter berisi filter opsional untuk daftar obat
+// Field nil tidak dipakai sebagai filter
+type MedicationFilter struct {
+	IsActive *bool
+}
+
+// CreateMedication menyimpan obat baru
+func (r *MedicationRepository) CreateMedication(medication *entity.Medication) error {
+	result := r.db.Omit("User").Create(medication)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetMedicationByIDAndUserID mengambil obat berdasarkan ID yang dimiliki oleh user tertentu
+func (r *MedicationRepository) GetMedicationByIDAndUserID(id, userID uint) (*entity.Medication, error) {
+	var medication entity.Medication
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&medication)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("obat tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &medication, nil
+}
+
+// UpdateMedication menyimpan perubahan data obat
+func (r *MedicationRepository) UpdateMedication(medication *entity.Medication) error {
+	result := r.db.Omit("User").Save(medication)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// DeleteMedication menghapus obat beserta seluruh log minum obatnya
+func (r *MedicationRepository) DeleteMedication(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("medication_id = ?", id).Delete(&entity.MedicationLog{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&entity.Medication{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("obat tidak ditemukan")
+		}
+		return nil
+	})
+}
+
+// GetMedicationsWithFilter mengambil obat milik user dengan filter dan pagination
+// Obat aktif ditampilkan lebih dulu, lalu diurutkan berdasarkan nama.
+// Mengembalikan daftar obat dan total data (sebelum pagination)
+func (r *MedicationRepository) GetMedicationsWithFilter(userID uint, filter MedicationFilter, offset, limit int) ([]entity.Medication, int64, error) {
+	query := r.db.Model(&entity.Medication{}).Where("user_id = ?", userID)
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var medications []entity.Medication
+	result := query.Order("is_active DESC, name ASC, id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&medications)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return medications, total, nil
+}
+
+// GetMedicationsInPeriod mengambil semua obat milik user yang masa pakainya beririsan dengan rentang tanggal
+// (start_date <= endDate dan end_date kosong atau >= startDate). Tanggal dibandingkan dalam zona waktu parameter.
+func (r *MedicationRepository) GetMedicationsInPeriod(userID uint, startDate, endDate time.Time) ([]entity.Medication, error) {
+	var medications []entity.Medication
+	result := r.db.Where("user_id = ? AND start_date <= ? AND (end_date IS NULL OR end_date >= ?)",
+		userID, endDate.Format("2006-01-02"), startDate.Format("2006-01-02")).
+		Order("name ASC, id ASC").
+		Find(&medications)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return medications, nil
+}
+
+// CountMedicationsByUserID menghitung jumlah obat yang pernah dicatat user
+func (r *MedicationRepository) CountMedicationsByUserID(userID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.Medication{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetMedicationLogBySchedule mengambil log minum obat untuk satu jadwal
+// Mengembalikan nil, nil jika jadwal tersebut belum pernah dicatat
+func (r *MedicationRepository) GetMedicationLogBySchedule(medicationID uint, scheduledAt time.Time) (*entity.MedicationLog, error) {
+	var log entity.MedicationLog
+	result := r.db.Where("medication_id = ? AND scheduled_at = ?", medicationID, scheduledAt).First(&log)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &log, nil
+}
+
+// SaveMedicationLog membuat atau memperbarui log minum obat
+func (r *MedicationRepository) SaveMedicationLog(log *entity.MedicationLog) error {
+	result := r.db.Omit("Medication").Save(log)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetMedicationLogsWithFilter mengambil log minum obat untuk satu obat dengan rentang tanggal dan pagination
+// Diurutkan dari jadwal terbaru. Mengembalikan daftar log dan total data (sebelum pagination)
+func (r *MedicationRepository) GetMedicationLogsWithFilter(medicationID uint, startDate, endDate *time.Time, offset, limit int) ([]entity.MedicationLog, int64, error) {
+	query := r.db.Model(&entity.MedicationLog{}).Where("medication_id = ?", medicationID)
+	if startDate != nil {
+		query = query.Where("scheduled_at >= ?", *startDate)
+	}
+	if endDate != nil {
+		query = query.Where("scheduled_at <= ?", *endDate)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []entity.MedicationLog
+	result := query.Order("scheduled_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&logs)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return logs, total, nil
+}
+
+// GetMedicationLogsByUserID mengambil semua log minum obat milik user dalam rentang waktu jadwal
+// Diurutkan dari jadwal terlama (untuk perhitungan kepatuhan)
+func (r *MedicationRepository) GetMedicationLogsByUserID(userID uint, startDate, endDate time.Time) ([]entity.MedicationLog, error) {
+	var logs []entity.MedicationLog
+	result := r.db.Where("user_id = ? AND scheduled_at >= ? AND scheduled_at <= ?", userID, startDate, endDate).
+		Order("scheduled_at ASC, id ASC").
+		Find(&logs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return logs, nil
+}
+
+// GetScheduledMedicationsForReminder mengambil obat terjadwal yang aktif pada tanggal tertentu
+// milik user yang mengaktifkan notifikasi (untuk pengingat minum obat)
+func (r *MedicationRepository) GetScheduledMedicationsForReminder(day time.Time) ([]entity.Medication, error) {
+	dayStr := day.Format("2006-01-02")
+	var medications []entity.Medication
+	result := r.db.
+		Joins("JOIN users ON users.id = medications.user_id").
+		Where("medications.is_active = ? AND medications.schedule_times <> ''", true).
+		Where("medications.start_date <= ? AND (medications.end_date IS NULL OR medications.end_date >= ?)", dayStr, dayStr).
+		Where("users.notification_enabled IS NOT FALSE").
+		Order("medications.id ASC").
+		Find(&medications)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return medications, nil
+}
+
+// GetMedicationLogsScheduledBetween mengambil log minum obat semua user dengan jadwal pada rentang waktu
+func (r *MedicationRepository) GetMedicationLogsScheduledBetween(startTime, endTime time.Time) ([]entity.MedicationLog, error) {
+	var logs []entity.MedicationLog
+	result := r.db.Where("scheduled_at >= ? AND scheduled_at <= ?", startTime, endTime).
+		Order("scheduled_at ASC, id ASC").
+		Find(&logs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return logs, nil
+}
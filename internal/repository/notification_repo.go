@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepository adalah struct yang menampung koneksi database untuk notifikasi in-app
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository membuat instance baru dari NotificationRepository
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+// NotificationFilter berisi filter opsional untuk inbox notifikasi
+// Field kosong tidak dipakai sebagai filter
+type NotificationFilter struct {
+	UnreadOnly bool
+	Type       entity.NotificationType
+}
+
+// CreateNotification menyimpan notifikasi baru ke inbox user
+// Mengembalikan false tanpa error jika notifikasi dengan dedup key yang sama sudah ada
+func (r *NotificationRepository) CreateNotification(notification *entity.Notification) (bool, error) {
+	result := r.db.Omit("User").Create(notification)
+	if result.Error != nil {
+		errMsg := strings.ToLower(result.Error.Error())
+		if notification.DedupKey != nil && (strings.Contains(errMsg, "duplicate") ||
+			strings.Contains(errMsg, "unique") || strings.Contains(errMsg, "23505")) {
+			return false, nil
+		}
+		return false, result.Error
+	}
+	return true, nil
+}
+
+// GetNotificationByIDAndUserID mengambil notifikasi berdasarkan ID yang dimiliki oleh user tertentu
+func (r *NotificationRepository) GetNotificationByIDAndUserID(id, userID uint) (*entity.Notification, error) {
+	var notification entity.Notification
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).First(&notification)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("notifikasi tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &notification, nil
+}
+
+// GetNotificationsWithFilter mengambil notifikasi milik user dengan filter dan pagination
+// Diurutkan dari notifikasi terbaru. Mengembalikan daftar notifikasi dan total data (sebelum pagination)
+func (r *NotificationRepository) GetNotificationsWithFilter(userID uint, filter NotificationFilter, offset, limit int) ([]entity.Notification, int64, error) {
+	query := r.db.Model(&entity.Notification{}).Where("user_id = ?", userID)
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []entity.Notification
+	result := query.Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&notifications)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return notifications, total, nil
+}
+
+// CountUnreadNotifications menghitung jumlah notifikasi yang belum dibaca milik user
+func (r *NotificationRepository) CountUnreadNotifications(userID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// MarkNotificationRead menandai satu notifikasi sebagai sudah dibaca
+func (r *NotificationRepository) MarkNotificationRead(notification *entity.Notification, readAt time.Time) error {
+	result := r.db.Model(notification).Update("read_at", readAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	notification.ReadAt = &readAt
+	return nil
+}
+
+// MarkAllNotificationsRead menandai semua notifikasi yang belum dibaca milik user sebagai sudah dibaca
+// Mengembalikan jumlah notifikasi yang diperbarui
+func (r *NotificationRepository) MarkAllNotificationsRead(userID uint, readAt time.Time) (int64, error) {
+	result := r.db.Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteNotification menghapus notifikasi berdasarkan ID
+func (r *NotificationRepository) DeleteNotification(id uint) error {
+	result := r.db.Delete(&entity.Notification{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("notifikasi tidak ditemukan")
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ThrottleRepository adalah struct yang menampung koneksi database untuk pembatasan percobaan autentikasi
+type ThrottleRepository struct {
+	db *gorm.DB
+}
+
+// NewThrottleRepository membuat instance baru dari ThrottleRepository
+func NewThrottleRepository(db *gorm.DB) *ThrottleRepository {
+	return &ThrottleRepository{
+		db: db,
+	}
+}
+
+// GetThrottle mengambil data percobaan berdasarkan aksi, scope, dan key
+// Mengembalikan nil jika belum ada (bukan error)
+func (r *ThrottleRepository) GetThrottle(action, scope, key string) (*entity.AuthThrottle, error) {
+	var throttle entity.AuthThrottle
+	result := r.db.Where("action = ? AND scope = ? AND key = ?", action, scope, key).First(&throttle)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &throttle, nil
+}
+
+// UpdateThrottleLocked mengubah data percobaan di dalam transaksi dengan row lock (SELECT ... FOR UPDATE)
+// sehingga percobaan bersamaan tidak saling menimpa hitungan. Baris dibuat jika belum ada.
+func (r *ThrottleRepository) UpdateThrottleLocked(action, scope, key string, update func(throttle *entity.AuthThrottle) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.AuthThrottle{
+			Action: action,
+			Scope:  scope,
+			Key:    key,
+		}).Error; err != nil {
+			return err
+		}
+
+		var throttle entity.AuthThrottle
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("action = ? AND scope = ? AND key = ?", action, scope, key).
+			First(&throttle).Error; err != nil {
+			return err
+		}
+
+		if err := update(&throttle); err != nil {
+			return err
+		}
+
+		return tx.Save(&throttle).Error
+	})
+}
+
+// DeleteThrottle menghapus data percobaan (reset hitungan dan buka kunci)
+func (r *ThrottleRepository) DeleteThrottle(action, scope, key string) error {
+	result := r.db.Where("action = ? AND scope = ? AND key = ?", action, scope, key).Delete(&entity.AuthThrottle{})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetThrottleByID mengambil data percobaan berdasarkan ID
+func (r *ThrottleRepository) GetThrottleByID(id uint) (*entity.AuthThrottle, error) {
+	var throttle entity.AuthThrottle
+	result := r.db.First(&throttle, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("penguncian tidak ditemukan")
+		}
+		return nil, result.Error
+	}
+	return &throttle, nil
+}
+
+// GetActiveLockouts mengambil daftar penguncian yang masih berlaku dengan pagination
+func (r *ThrottleRepository) GetActiveLockouts(now time.Time, offset, limit int) ([]entity.AuthThrottle, int64, error) {
+	query := r.db.Model(&entity.AuthThrottle{}).Where("locked_until > ?", now)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var throttles []entity.AuthThrottle
+	result := query.Order("locked_until DESC").Offset(offset).Limit(limit).Find(&throttles)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return throttles, total, nil
+}
+
+// CleanupStaleThrottles menghapus data percobaan yang tidak terkunci dan tidak ada kegagalan sejak waktu tertentu.
+// Bisa dipanggil secara berkala untuk membersihkan database.
+func (r *ThrottleRepository) CleanupStaleThrottles(now, staleBefore time.Time) (int64, error) {
+	result := r.db.
+		Where("(locked_until IS NULL OR locked_until < ?) AND (last_failure_at IS NULL OR last_failure_at < ?)", now, staleBefore).
+		Delete(&entity.AuthThrottle{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// CreateAuditLog menyimpan catatan audit autentikasi
+func (r *ThrottleRepository) CreateAuditLog(auditLog *entity.AuthAuditLog) error {
+	result := r.db.Create(auditLog)
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// GetAuditLogs mengambil catatan audit dengan filter event opsional dan pagination
+func (r *ThrottleRepository) GetAuditLogs(event string, offset, limit int) ([]entity.AuthAuditLog, int64, error) {
+	query := r.db.Model(&entity.AuthAuditLog{})
+	if event != "" {
+		query = query.Where("event = ?", event)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []entity.AuthAuditLog
+	result := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&logs)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return logs, total, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"BE-PeriksaKesehatan/internal/model/entity"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -178,6 +179,123 @@ func (r *UserRepository) CheckUsernameExists(username string) (bool, error) {
 	return count > 0, nil
 }
 
+// UpdateUserRole mengubah role user
+func (r *UserRepository) UpdateUserRole(id uint, role entity.UserRole) error {
+	result := r.db.Model(&entity.User{}).Where("id = ?", id).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// GetUsersWithFilter mengambil daftar user dengan filter role opsional dan pagination
+// Mengembalikan daftar user dan total data (sebelum pagination)
+func (r *UserRepository) GetUsersWithFilter(role string, offset, limit int) ([]entity.User, int64, error) {
+	query := r.db.Model(&entity.User{})
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []entity.User
+	result := query.Order("id ASC").Offset(offset).Limit(limit).Find(&users)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return users, total, nil
+}
+
+// MarkEmailVerified menandai email user sudah diverifikasi
+func (r *UserRepository) MarkEmailVerified(id uint, verifiedAt time.Time) error {
+	result := r.db.Model(&entity.User{}).Where("id = ?", id).Update("email_verified_at", verifiedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// UpdateUserDisabledAt menonaktifkan (disabledAt berisi waktu) atau mengaktifkan kembali (disabledAt nil) akun user
+func (r *UserRepository) UpdateUserDisabledAt(id uint, disabledAt *time.Time) error {
+	result := r.db.Model(&entity.User{}).Where("id = ?", id).Update("disabled_at", disabledAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("user tidak ditemukan")
+	}
+	return nil
+}
+
+// UserDataStats adalah ringkasan jumlah data milik satu user
+type UserDataStats struct {
+	HealthDataCount         int64
+	FirstRecordDate         *time.Time
+	LastRecordDate          *time.Time
+	HealthAlertCount        int64
+	LabResultCount          int64
+	MedicationCount         int64
+	ActiveMedicationCount   int64
+	MedicationLogCount      int64
+	NotificationCount       int64
+	UnreadNotificationCount int64
+	ActiveSessionCount      int64
+	ActiveCareGrantCount    int64
+}
+
+// GetUserDataStats menghitung jumlah data kesehatan, alert, hasil lab, obat, notifikasi,
+// sesi aktif, dan akses caregiver aktif milik user
+func (r *UserRepository) GetUserDataStats(userID uint, now time.Time) (*UserDataStats, error) {
+	var readings struct {
+		Total           int64
+		FirstRecordDate *time.Time
+		LastRecordDate  *time.Time
+	}
+	if err := r.db.Model(&entity.HealthData{}).
+		Select("COUNT(*) AS total, MIN(record_date) AS first_record_date, MAX(record_date) AS last_record_date").
+		Where("user_id = ?", userID).
+		Scan(&readings).Error; err != nil {
+		return nil, err
+	}
+
+	stats := &UserDataStats{
+		HealthDataCount: readings.Total,
+		FirstRecordDate: readings.FirstRecordDate,
+		LastRecordDate:  readings.LastRecordDate,
+	}
+
+	counts := []struct {
+		target *int64
+		query  *gorm.DB
+	}{
+		{&stats.HealthAlertCount, r.db.Model(&entity.HealthAlert{}).Where("user_id = ?", userID)},
+		{&stats.LabResultCount, r.db.Model(&entity.LabResult{}).Where("user_id = ?", userID)},
+		{&stats.MedicationCount, r.db.Model(&entity.Medication{}).Where("user_id = ?", userID)},
+		{&stats.ActiveMedicationCount, r.db.Model(&entity.Medication{}).Where("user_id = ? AND is_active = ?", userID, true)},
+		{&stats.MedicationLogCount, r.db.Model(&entity.MedicationLog{}).Where("user_id = ?", userID)},
+		{&stats.NotificationCount, r.db.Model(&entity.Notification{}).Where("user_id = ?", userID)},
+		{&stats.UnreadNotificationCount, r.db.Model(&entity.Notification{}).Where("user_id = ? AND read_at IS NULL", userID)},
+		{&stats.ActiveSessionCount, r.db.Model(&entity.UserSession{}).Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now)},
+		{&stats.ActiveCareGrantCount, r.db.Model(&entity.CareGrant{}).Where("owner_id = ? AND status = ?", userID, entity.CareGrantStatusActive)},
+	}
+	for _, count := range counts {
+		if err := count.query.Count(count.target).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return stats, nil
+}
+
 func (r *UserRepository) GetDB() *gorm.DB {
 	return r.db
 }
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// minAdminPasswordLength menyamakan panjang minimal password saat registrasi
+const minAdminPasswordLength = 6
+
+// CreateAdmin membuat akun admin baru (dipakai cmd/admin)
+// Berbeda dengan BootstrapAdmin, user yang sudah terdaftar tidak dipromosikan: email yang sudah dipakai ditolak
+func (s *AdminService) CreateAdmin(nama, username, email, password string) (*response.AdminUserResponse, error) {
+	nama = strings.TrimSpace(nama)
+	if nama == "" {
+		nama = "Administrator"
+	}
+
+	email = strings.TrimSpace(email)
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, errors.New("email harus berupa alamat email yang valid")
+	}
+	if err := validateAdminPassword(password); err != nil {
+		return nil, err
+	}
+
+	emailExists, err := s.userRepo.CheckEmailExists(email)
+	if err != nil {
+		return nil, err
+	}
+	if emailExists {
+		return nil, fmt.Errorf("email %s sudah terdaftar", email)
+	}
+
+	admin, err := s.createAdminUser(nama, username, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[Admin] Akun admin %s berhasil dibuat", email)
+	resp := s.mapUserToAdminResponse(admin)
+	return &resp, nil
+}
+
+// ResetUserPassword mengganti password user tanpa password lama (dipakai cmd/admin)
+// Semua sesi user dicabut dan token reset password yang tersisa dibatalkan
+func (s *AdminService) ResetUserPassword(account, newPassword string) (*response.AdminUserResponse, error) {
+	if err := validateAdminPassword(newPassword); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUserByAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdateUserPassword(user.ID, string(hashedPassword)); err != nil {
+		return nil, err
+	}
+	if err := s.authRepo.InvalidatePasswordResetTokens(user.ID); err != nil {
+		return nil, err
+	}
+	if _, err := s.authRepo.RevokeAllSessions(user.ID); err != nil {
+		return nil, fmt.Errorf("gagal mencabut sesi: %w", err)
+	}
+
+	log.Printf("[Admin] Password user %d direset", user.ID)
+	resp := s.mapUserToAdminResponse(user)
+	return &resp, nil
+}
+
+// SetUserDisabled menonaktifkan atau mengaktifkan kembali akun user (dipakai cmd/admin)
+// Saat dinonaktifkan, semua sesi user dicabut sehingga access token yang terikat sesi langsung tidak berlaku
+func (s *AdminService) SetUserDisabled(account string, disabled bool) (*response.AdminUserResponse, error) {
+	user, err := s.findUserByAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	if disabled {
+		if user.IsDisabled() {
+			return nil, errors.New("akun sudah nonaktif")
+		}
+		disabledAt := timezoneUtils.NowInJakarta()
+		if err := s.userRepo.UpdateUserDisabledAt(user.ID, &disabledAt); err != nil {
+			return nil, err
+		}
+		if _, err := s.authRepo.RevokeAllSessions(user.ID); err != nil {
+			return nil, fmt.Errorf("gagal mencabut sesi: %w", err)
+		}
+		user.DisabledAt = &disabledAt
+		log.Printf("[Admin] Akun user %d dinonaktifkan", user.ID)
+	} else {
+		if !user.IsDisabled() {
+			return nil, errors.New("akun sudah aktif")
+		}
+		if err := s.userRepo.UpdateUserDisabledAt(user.ID, nil); err != nil {
+			return nil, err
+		}
+		user.DisabledAt = nil
+		log.Printf("[Admin] Akun user %d diaktifkan kembali", user.ID)
+	}
+
+	resp := s.mapUserToAdminResponse(user)
+	return &resp, nil
+}
+
+// GetUserStats mengambil ringkasan jumlah data milik user (dipakai cmd/admin)
+func (s *AdminService) GetUserStats(account string) (*response.AdminUserStatsResponse, error) {
+	user, err := s.findUserByAccount(account)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.userRepo.GetUserDataStats(user.ID, timezoneUtils.NowInJakarta())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.AdminUserStatsResponse{
+		User:                    s.mapUserToAdminResponse(user),
+		HealthDataCount:         stats.HealthDataCount,
+		HealthAlertCount:        stats.HealthAlertCount,
+		LabResultCount:          stats.LabResultCount,
+		MedicationCount:         stats.MedicationCount,
+		ActiveMedicationCount:   stats.ActiveMedicationCount,
+		MedicationLogCount:      stats.MedicationLogCount,
+		NotificationCount:       stats.NotificationCount,
+		UnreadNotificationCount: stats.UnreadNotificationCount,
+		ActiveSessionCount:      stats.ActiveSessionCount,
+		ActiveCareGrantCount:    stats.ActiveCareGrantCount,
+	}
+	if stats.FirstRecordDate != nil {
+		firstDate := stats.FirstRecordDate.Format("2006-01-02")
+		resp.FirstRecordDate = &firstDate
+	}
+	if stats.LastRecordDate != nil {
+		lastDate := stats.LastRecordDate.Format("2006-01-02")
+		resp.LastRecordDate = &lastDate
+	}
+	return resp, nil
+}
+
+// findUserByAccount mencari user berdasarkan ID, email, atau username
+func (s *AdminService) findUserByAccount(account string) (*entity.User, error) {
+	account = strings.TrimSpace(account)
+	if account == "" {
+		return nil, errors.New("user harus diisi dengan ID, email, atau username")
+	}
+	if id, err := strconv.ParseUint(account, 10, 32); err == nil {
+		return s.userRepo.GetUserByID(uint(id))
+	}
+	return s.userRepo.GetUserByEmailOrUsername(account)
+}
+
+// validateAdminPassword memvalidasi panjang password yang diatur oleh admin
+func validateAdminPassword(password string) error {
+	if utf8.RuneCountInString(password) < minAdminPasswordLength {
+		return fmt.Errorf("password harus minimal %d karakter", minAdminPasswordLength)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AdminService menangani business logic untuk manajemen user oleh admin
+type AdminService struct {
+	userRepo *repository.UserRepository
+	authRepo *repository.AuthRepository
+}
+
+// NewAdminService membuat instance baru dari AdminService
+func NewAdminService(userRepo *repository.UserRepository, authRepo *repository.AuthRepository) *AdminService {
+	return &AdminService{
+		userRepo: userRepo,
+		authRepo: authRepo,
+	}
+}
+
+// BootstrapAdmin memastikan akun admin awal tersedia berdasarkan konfigurasi environment.
+// Jika user dengan email tersebut sudah ada, role-nya dipromosikan menjadi admin.
+// Jika belum ada dan password tersedia, akun admin baru dibuat.
+// Tidak melakukan apa-apa jika email admin tidak dikonfigurasi.
+func (s *AdminService) BootstrapAdmin(email, username, password string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil
+	}
+
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil && err.Error() != "user tidak ditemukan" {
+		return err
+	}
+
+	if user != nil {
+		if user.Role == entity.RoleAdmin {
+			return nil
+		}
+		if err := s.userRepo.UpdateUserRole(user.ID, entity.RoleAdmin); err != nil {
+			return err
+		}
+		log.Printf("[Admin] User %s dipromosikan menjadi admin", email)
+		return nil
+	}
+
+	if password == "" {
+		return errors.New("ADMIN_PASSWORD wajib diisi untuk membuat akun admin baru")
+	}
+
+	if _, err := s.createAdminUser("Administrator", username, email, password); err != nil {
+		return err
+	}
+
+	log.Printf("[Admin] Akun admin %s berhasil dibuat", email)
+	return nil
+}
+
+// createAdminUser membuat akun admin baru yang dianggap sudah terverifikasi
+// Username kosong diisi dari bagian depan email
+func (s *AdminService) createAdminUser(nama, username, email, password string) (*entity.User, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		username = strings.Split(email, "@")[0]
+	}
+
+	usernameExists, err := s.userRepo.CheckUsernameExists(username)
+	if err != nil {
+		return nil, err
+	}
+	if usernameExists {
+		return nil, fmt.Errorf("username %s sudah digunakan", username)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	// Akun admin dibuat oleh operator sehingga dianggap sudah terverifikasi
+	verifiedAt := timezoneUtils.NowInJakarta()
+	admin := &entity.User{
+		Nama:            nama,
+		Username:        username,
+		Email:           email,
+		Password:        string(hashedPassword),
+		Role:            entity.RoleAdmin,
+		EmailVerifiedAt: &verifiedAt,
+	}
+	if err := s.userRepo.CreateUser(admin); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+// GetUsers mengambil daftar user dengan filter role dan pagination
+func (s *AdminService) GetUsers(req *request.AdminUserListRequest) (*response.AdminUserListResponse, error) {
+	role := strings.ToLower(strings.TrimSpace(req.Role))
+	if role != "" && !entity.IsValidUserRole(entity.UserRole(role)) {
+		return nil, errors.New("role harus salah satu dari patient, clinician, admin")
+	}
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	users, total, err := s.userRepo.GetUsersWithFilter(role, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.AdminUserResponse, 0, len(users))
+	for i := range users {
+		items = append(items, s.mapUserToAdminResponse(&users[i]))
+	}
+
+	return &response.AdminUserListResponse{
+		Users:      items,
+		Pagination: newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// UpdateUserRole mengubah role user oleh admin
+// Admin tidak dapat mengubah role dirinya sendiri agar tidak kehilangan akses admin
+func (s *AdminService) UpdateUserRole(adminID, targetUserID uint, req *request.UpdateUserRoleRequest) (*response.AdminUserResponse, error) {
+	role := entity.UserRole(strings.ToLower(strings.TrimSpace(req.Role)))
+	if !entity.IsValidUserRole(role) {
+		return nil, errors.New("role harus salah satu dari patient, clinician, admin")
+	}
+
+	if adminID == targetUserID {
+		return nil, errors.New("admin tidak dapat mengubah role sendiri")
+	}
+
+	if err := s.userRepo.UpdateUserRole(targetUserID, role); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByID(targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := s.mapUserToAdminResponse(user)
+	return &resp, nil
+}
+
+// mapUserToAdminResponse mengubah entity user ke response admin
+func (s *AdminService) mapUserToAdminResponse(user *entity.User) response.AdminUserResponse {
+	return response.AdminUserResponse{
+		ID:            user.ID,
+		Nama:          user.Nama,
+		Username:      user.Username,
+		Email:         user.Email,
+		Role:          string(user.Role),
+		EmailVerified: user.IsEmailVerified(),
+		DisabledAt:    toJakartaPtr(user.DisabledAt),
+		CreatedAt:     timezoneUtils.ToJakarta(user.CreatedAt),
+	}
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"strings"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// CareService menangani undangan dan akses caregiver/keluarga ke data kesehatan pasien
+type CareService struct {
+	careGrantRepo *repository.CareGrantRepository
+	clinicianRepo *repository.ClinicianRepository
+	userRepo      *repository.UserRepository
+}
+
+// NewCareService membuat instance baru dari CareService
+func NewCareService(careGrantRepo *repository.CareGrantRepository, clinicianRepo *repository.ClinicianRepository, userRepo *repository.UserRepository) *CareService {
+	return &CareService{
+		careGrantRepo: careGrantRepo,
+		clinicianRepo: clinicianRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// InviteCaregiver membuat undangan akses dari pasien ke akun caregiver yang sudah terdaftar.
+// Identifier yang tidak terdaftar tidak menghasilkan error agar endpoint ini tidak bisa dipakai
+// untuk memeriksa apakah sebuah email atau username memiliki akun.
+func (s *CareService) InviteCaregiver(ownerID uint, req *request.InviteCaregiverRequest) error {
+	scope, err := parseCareGrantScope(req.Scope, entity.CareGrantScopeView)
+	if err != nil {
+		return err
+	}
+
+	caregiver, err := s.userRepo.GetUserByEmailOrUsername(strings.TrimSpace(req.Identifier))
+	if err != nil {
+		if err.Error() == "user tidak ditemukan" {
+			return nil
+		}
+		return err
+	}
+	if caregiver.ID == ownerID {
+		return errors.New("tidak dapat mengundang diri sendiri")
+	}
+
+	existing, err := s.careGrantRepo.GetOpenCareGrant(ownerID, caregiver.ID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.New("caregiver sudah memiliki akses atau undangan aktif")
+	}
+
+	grant := &entity.CareGrant{
+		OwnerID:     ownerID,
+		CaregiverID: caregiver.ID,
+		Scope:       scope,
+		Status:      entity.CareGrantStatusPending,
+	}
+	return s.careGrantRepo.CreateCareGrant(grant)
+}
+
+// GetGrantedAccess mengambil daftar caregiver yang diberi akses oleh pasien
+func (s *CareService) GetGrantedAccess(ownerID uint) ([]response.CareGrantResponse, error) {
+	grants, err := s.careGrantRepo.GetCareGrantsByOwnerID(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapCareGrantsToResponse(grants), nil
+}
+
+// GetReceivedAccess mengambil daftar pasien yang memberi akses (termasuk undangan pending) ke caregiver
+func (s *CareService) GetReceivedAccess(caregiverID uint) ([]response.CareGrantResponse, error) {
+	grants, err := s.careGrantRepo.GetCareGrantsByCaregiverID(caregiverID)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapCareGrantsToResponse(grants), nil
+}
+
+// UpdateGrantScope mengubah cakupan akses caregiver (hanya oleh pasien pemilik data)
+func (s *CareService) UpdateGrantScope(ownerID, grantID uint, req *request.UpdateCareGrantRequest) (*response.CareGrantResponse, error) {
+	scope, err := parseCareGrantScope(req.Scope, "")
+	if err != nil {
+		return nil, err
+	}
+
+	grant, err := s.getOpenGrant(grantID)
+	if err != nil {
+		return nil, err
+	}
+	if grant.OwnerID != ownerID {
+		return nil, errors.New("akses caregiver tidak ditemukan")
+	}
+
+	grant.Scope = scope
+	if err := s.careGrantRepo.UpdateCareGrant(grant); err != nil {
+		return nil, err
+	}
+
+	return s.getCareGrantResponse(grant.ID)
+}
+
+// RevokeGrant mencabut akses caregiver. Bisa dilakukan oleh pasien (mencabut)
+// atau oleh caregiver sendiri (melepas akses).
+func (s *CareService) RevokeGrant(userID, grantID uint) error {
+	grant, err := s.getOpenGrant(grantID)
+	if err != nil {
+		return err
+	}
+	if grant.OwnerID != userID && grant.CaregiverID != userID {
+		return errors.New("akses caregiver tidak ditemukan")
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	grant.Status = entity.CareGrantStatusRevoked
+	grant.RevokedAt = &now
+	return s.careGrantRepo.UpdateCareGrant(grant)
+}
+
+// AcceptInvitation menerima undangan akses (hanya oleh caregiver yang diundang)
+func (s *CareService) AcceptInvitation(caregiverID, grantID uint) (*response.CareGrantResponse, error) {
+	grant, err := s.getPendingInvitation(caregiverID, grantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	grant.Status = entity.CareGrantStatusActive
+	grant.AcceptedAt = &now
+	if err := s.careGrantRepo.UpdateCareGrant(grant); err != nil {
+		return nil, err
+	}
+
+	return s.getCareGrantResponse(grant.ID)
+}
+
+// DeclineInvitation menolak undangan akses (hanya oleh caregiver yang diundang)
+func (s *CareService) DeclineInvitation(caregiverID, grantID uint) error {
+	grant, err := s.getPendingInvitation(caregiverID, grantID)
+	if err != nil {
+		return err
+	}
+
+	grant.Status = entity.CareGrantStatusDeclined
+	return s.careGrantRepo.UpdateCareGrant(grant)
+}
+
+// CheckAccess memeriksa apakah caregiver boleh mengakses data pasien.
+// requireRecord bernilai true untuk operasi yang mengubah data (butuh scope record).
+// Clinician yang terhubung dengan pasien mendapat akses lihat saja.
+func (s *CareService) CheckAccess(caregiverID, patientID uint, requireRecord bool) error {
+	if caregiverID == patientID {
+		return nil
+	}
+
+	grant, err := s.careGrantRepo.GetActiveCareGrant(patientID, caregiverID)
+	if err != nil {
+		return err
+	}
+	if grant == nil {
+		assigned, err := s.clinicianRepo.IsPatientAssigned(caregiverID, patientID)
+		if err != nil {
+			return err
+		}
+		if !assigned {
+			return errors.New("tidak memiliki akses ke data pasien ini")
+		}
+		if requireRecord {
+			return errors.New("akses hanya untuk melihat data pasien")
+		}
+		return nil
+	}
+	if requireRecord && grant.Scope != entity.CareGrantScopeRecord {
+		return errors.New("akses hanya untuk melihat data pasien")
+	}
+	return nil
+}
+
+// getOpenGrant mengambil akses yang masih pending/aktif
+func (s *CareService) getOpenGrant(grantID uint) (*entity.CareGrant, error) {
+	grant, err := s.careGrantRepo.GetCareGrantByID(grantID)
+	if err != nil {
+		return nil, err
+	}
+	if grant.Status != entity.CareGrantStatusPending && grant.Status != entity.CareGrantStatusActive {
+		return nil, errors.New("akses caregiver sudah tidak aktif")
+	}
+	return grant, nil
+}
+
+// getPendingInvitation mengambil undangan pending milik caregiver
+func (s *CareService) getPendingInvitation(caregiverID, grantID uint) (*entity.CareGrant, error) {
+	grant, err := s.careGrantRepo.GetCareGrantByID(grantID)
+	if err != nil {
+		return nil, err
+	}
+	if grant.CaregiverID != caregiverID {
+		return nil, errors.New("akses caregiver tidak ditemukan")
+	}
+	if grant.Status != entity.CareGrantStatusPending {
+		return nil, errors.New("undangan sudah tidak pending")
+	}
+	return grant, nil
+}
+
+// getCareGrantResponse mengambil ulang akses beserta relasi user lalu mengubahnya ke response
+func (s *CareService) getCareGrantResponse(grantID uint) (*response.CareGrantResponse, error) {
+	grant, err := s.careGrantRepo.GetCareGrantByID(grantID)
+	if err != nil {
+		return nil, err
+	}
+	resp := s.mapCareGrantToResponse(grant)
+	return &resp, nil
+}
+
+// mapCareGrantsToResponse mengubah daftar entity akses ke response
+func (s *CareService) mapCareGrantsToResponse(grants []entity.CareGrant) []response.CareGrantResponse {
+	result := make([]response.CareGrantResponse, 0, len(grants))
+	for i := range grants {
+		result = append(result, s.mapCareGrantToResponse(&grants[i]))
+	}
+	return result
+}
+
+// mapCareGrantToResponse mengubah entity akses ke response
+func (s *CareService) mapCareGrantToResponse(grant *entity.CareGrant) response.CareGrantResponse {
+	return response.CareGrantResponse{
+		ID:         grant.ID,
+		Owner:      mapCareUserSummary(grant.Owner),
+		Caregiver:  mapCareUserSummary(grant.Caregiver),
+		Scope:      string(grant.Scope),
+		Status:     string(grant.Status),
+		AcceptedAt: toJakartaPtr(grant.AcceptedAt),
+		RevokedAt:  toJakartaPtr(grant.RevokedAt),
+		CreatedAt:  timezoneUtils.ToJakarta(grant.CreatedAt),
+		UpdatedAt:  timezoneUtils.ToJakarta(grant.UpdatedAt),
+	}
+}
+
+// mapCareUserSummary mengubah entity user ke ringkasan user (nil tetap nil)
+func mapCareUserSummary(user *entity.User) *response.CareUserSummary {
+	if user == nil {
+		return nil
+	}
+	return &response.CareUserSummary{
+		ID:       user.ID,
+		Nama:     user.Nama,
+		Username: user.Username,
+		Email:    user.Email,
+	}
+}
+
+// parseCareGrantScope memvalidasi scope akses; defaultScope dipakai jika scope kosong
+func parseCareGrantScope(scope string, defaultScope entity.CareGrantScope) (entity.CareGrantScope, error) {
+	parsed := entity.CareGrantScope(strings.ToLower(strings.TrimSpace(scope)))
+	if parsed == "" {
+		parsed = defaultScope
+	}
+	if !entity.IsValidCareGrantScope(parsed) {
+		return "", errors.New("scope harus salah satu dari view, record")
+	}
+	return parsed, nil
+}
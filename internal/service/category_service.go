@@ -0,0 +1,211 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// maxKategoriLength menyesuaikan panjang kolom kategori
+const maxKategoriLength = 100
+
+// CategoryService menangani business logic untuk manajemen kategori video edukasi
+type CategoryService struct {
+	categoryRepo *repository.CategoryRepository
+}
+
+// NewCategoryService membuat instance baru dari CategoryService
+func NewCategoryService(categoryRepo *repository.CategoryRepository) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// isDefaultCategory memeriksa apakah kategori merupakan kategori default hasil seed.
+// Kategori default dipakai oleh health alert untuk rekomendasi video (berdasarkan ID tetap atau nama)
+// dan di-seed ulang berdasarkan nama saat startup, sehingga tidak boleh diubah atau dihapus.
+func isDefaultCategory(category *entity.Category) bool {
+	switch category.ID {
+	case CategoryIDDiabetes, CategoryIDHipertensi, CategoryIDJantung, CategoryIDBeratBadan:
+		return true
+	}
+	return category.Kategori == entity.CategoryNamePernapasan || category.Kategori == entity.CategoryNameSuhuTubuh
+}
+
+// GetCategories mengambil semua kategori
+func (s *CategoryService) GetCategories() ([]response.CategoryResponse, error) {
+	categories, err := s.categoryRepo.GetAllCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.CategoryResponse, 0, len(categories))
+	for i := range categories {
+		result = append(result, s.mapCategoryToResponse(&categories[i]))
+	}
+	return result, nil
+}
+
+// CreateCategory menambahkan kategori baru
+func (s *CategoryService) CreateCategory(req *request.CategoryRequest) (*response.CategoryResponse, error) {
+	kategori := strings.TrimSpace(req.Kategori)
+	if kategori == "" {
+		return nil, errors.New("kategori tidak boleh kosong")
+	}
+
+	exists, err := s.categoryRepo.CheckKategoriExists(kategori, 0)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("kategori sudah ada")
+	}
+
+	category := &entity.Category{
+		Kategori: kategori,
+	}
+	if err := s.categoryRepo.CreateCategory(category); err != nil {
+		return nil, err
+	}
+
+	resp := s.mapCategoryToResponse(category)
+	return &resp, nil
+}
+
+// UpdateCategory mengubah nama kategori
+func (s *CategoryService) UpdateCategory(categoryIDStr string, req *request.CategoryRequest) (*response.CategoryResponse, error) {
+	categoryID, err := s.parseCategoryID(categoryIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	kategori := strings.TrimSpace(req.Kategori)
+	if kategori == "" {
+		return nil, errors.New("kategori tidak boleh kosong")
+	}
+
+	category, err := s.categoryRepo.GetCategoryByID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDefaultCategory(category) {
+		return nil, errors.New("kategori default tidak dapat diubah")
+	}
+
+	exists, err := s.categoryRepo.CheckKategoriExists(kategori, category.ID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("kategori sudah ada")
+	}
+
+	if category.Kategori != kategori {
+		if err := s.categoryRepo.UpdateCategoryKategori(category.ID, category.Kategori, kategori); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.categoryRepo.GetCategoryByID(category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := s.mapCategoryToResponse(updated)
+	return &resp, nil
+}
+
+// DeleteCategory menghapus kategori beserta relasinya ke video
+// Video yang terhubung tidak ikut dihapus
+func (s *CategoryService) DeleteCategory(categoryIDStr string) error {
+	categoryID, err := s.parseCategoryID(categoryIDStr)
+	if err != nil {
+		return err
+	}
+
+	category, err := s.categoryRepo.GetCategoryByID(categoryID)
+	if err != nil {
+		return err
+	}
+	if isDefaultCategory(category) {
+		return errors.New("kategori default tidak dapat dihapus")
+	}
+
+	return s.categoryRepo.DeleteCategory(categoryID)
+}
+
+// parseCategoryID memvalidasi dan mengubah ID kategori dari path parameter
+func (s *CategoryService) parseCategoryID(categoryIDStr string) (uint, error) {
+	categoryID, err := strconv.ParseUint(categoryIDStr, 10, 32)
+	if err != nil || categoryID == 0 {
+		return 0, errors.New("ID kategori tidak valid")
+	}
+	return uint(categoryID), nil
+}
+
+// mapCategoryToResponse mengubah entity kategori ke response
+func (s *CategoryService) mapCategoryToResponse(category *entity.Category) response.CategoryResponse {
+	return response.CategoryResponse{
+		ID:        category.ID,
+		Kategori:  category.Kategori,
+		CreatedAt: timezoneUtils.ToJakarta(category.CreatedAt),
+		UpdatedAt: timezoneUtils.ToJakarta(category.UpdatedAt),
+	}
+}
+
+// ExportCategories mengambil semua kategori dalam format ekspor
+func (s *CategoryService) ExportCategories() ([]response.CategoryExportItem, error) {
+	categories, err := s.categoryRepo.GetAllCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.CategoryExportItem, 0, len(categories))
+	for _, category := range categories {
+		items = append(items, response.CategoryExportItem{Kategori: category.Kategori})
+	}
+	return items, nil
+}
+
+// ImportCategories menambahkan kategori dari file impor
+// Kategori yang sudah ada (case-insensitive) dilewati sehingga impor aman diulang.
+// Data tidak valid dicatat per baris tanpa menghentikan impor; error database menghentikan impor.
+func (s *CategoryService) ImportCategories(items []request.CategoryRequest) (*response.ContentImportResponse, error) {
+	result := &response.ContentImportResponse{}
+	for i, item := range items {
+		kategori := strings.TrimSpace(item.Kategori)
+		if kategori == "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("baris %d: kategori tidak boleh kosong", i+1))
+			continue
+		}
+		if utf8.RuneCountInString(kategori) > maxKategoriLength {
+			result.Errors = append(result.Errors, fmt.Sprintf("baris %d: kategori maksimal %d karakter", i+1, maxKategoriLength))
+			continue
+		}
+
+		exists, err := s.categoryRepo.CheckKategoriExists(kategori, 0)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			result.Skipped++
+			continue
+		}
+
+		if err := s.categoryRepo.CreateCategory(&entity.Category{Kategori: kategori}); err != nil {
+			return nil, err
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// Sumber batas klinis yang berlaku
+const (
+	ThresholdSourceDefault = "default"
+	ThresholdSourcePatient = "patient"
+)
+
+// ClinicalThresholdService menangani batas klinis (default global dan penyesuaian per pasien)
+// serta menyediakan HealthClassifier untuk setiap user
+type ClinicalThresholdService struct {
+	thresholdRepo *repository.ClinicalThresholdRepository
+	clinicianRepo *repository.ClinicianRepository
+	userRepo      *repository.UserRepository
+}
+
+// NewClinicalThresholdService membuat instance baru dari ClinicalThresholdService
+func NewClinicalThresholdService(
+	thresholdRepo *repository.ClinicalThresholdRepository,
+	clinicianRepo *repository.ClinicianRepository,
+	userRepo *repository.UserRepository,
+) *ClinicalThresholdService {
+	return &ClinicalThresholdService{
+		thresholdRepo: thresholdRepo,
+		clinicianRepo: clinicianRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// GetClassifier mengambil classifier dengan batas klinis yang berlaku untuk user
+func (s *ClinicalThresholdService) GetClassifier(userID uint) (*HealthClassifier, error) {
+	defaults, err := s.thresholdRepo.GetDefaultThreshold()
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := s.thresholdRepo.GetUserThreshold(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHealthClassifier(defaults, override), nil
+}
+
+// GetClassifiers membuat classifier untuk banyak user sekaligus (dua query, tanpa query per user)
+func (s *ClinicalThresholdService) GetClassifiers(userIDs []uint) (map[uint]*HealthClassifier, error) {
+	defaults, err := s.thresholdRepo.GetDefaultThreshold()
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.thresholdRepo.GetUserThresholds(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	classifiers := make(map[uint]*HealthClassifier, len(userIDs))
+	for _, userID := range userIDs {
+		classifiers[userID] = NewHealthClassifier(defaults, overrides[userID])
+	}
+	return classifiers, nil
+}
+
+// GetThresholds mengambil batas klinis yang berlaku untuk user
+func (s *ClinicalThresholdService) GetThresholds(userID uint) (*response.ClinicalThresholdsResponse, error) {
+	defaults, err := s.thresholdRepo.GetDefaultThreshold()
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := s.thresholdRepo.GetUserThreshold(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if override != nil {
+		return s.mapThresholdsToResponse(NewHealthClassifier(defaults, override), ThresholdSourcePatient, override), nil
+	}
+	return s.mapThresholdsToResponse(NewHealthClassifier(defaults, nil), ThresholdSourceDefault, defaults), nil
+}
+
+// GetDefaultThresholds mengambil batas klinis default global
+func (s *ClinicalThresholdService) GetDefaultThresholds() (*response.ClinicalThresholdsResponse, error) {
+	defaults, err := s.thresholdRepo.GetDefaultThreshold()
+	if err != nil {
+		return nil, err
+	}
+	return s.mapThresholdsToResponse(NewHealthClassifier(defaults, nil), ThresholdSourceDefault, defaults), nil
+}
+
+// UpdateDefaultThresholds mengubah batas klinis default global (oleh admin)
+func (s *ClinicalThresholdService) UpdateDefaultThresholds(adminID uint, req *request.UpdateClinicalThresholdsRequest) (*response.ClinicalThresholdsResponse, error) {
+	defaults, err := s.thresholdRepo.GetDefaultThreshold()
+	if err != nil {
+		return nil, err
+	}
+	if defaults == nil {
+		who := entity.DefaultClinicalThreshold()
+		defaults = &who
+	}
+
+	applyThresholdRequest(defaults, req)
+	defaults.UpdatedBy = &adminID
+
+	classifier := NewHealthClassifier(defaults, nil)
+	if err := validateClassifierRanges(classifier); err != nil {
+		return nil, err
+	}
+
+	if err := s.thresholdRepo.SaveThreshold(defaults); err != nil {
+		return nil, err
+	}
+
+	return s.mapThresholdsToResponse(classifier, ThresholdSourceDefault, defaults), nil
+}
+
+// UpdatePatientThresholds membuat atau mengubah penyesuaian batas klinis pasien (oleh clinician yang terhubung)
+func (s *ClinicalThresholdService) UpdatePatientThresholds(clinicianID, patientID uint, req *request.UpdateClinicalThresholdsRequest) (*response.ClinicalThresholdsResponse, error) {
+	if err := s.ensureClinicianPatient(clinicianID, patientID); err != nil {
+		return nil, err
+	}
+
+	defaults, err := s.thresholdRepo.GetDefaultThreshold()
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := s.thresholdRepo.GetUserThreshold(patientID)
+	if err != nil {
+		return nil, err
+	}
+	if override == nil {
+		override = &entity.ClinicalThreshold{UserID: &patientID}
+	}
+
+	applyThresholdRequest(override, req)
+	override.UpdatedBy = &clinicianID
+
+	classifier := NewHealthClassifier(defaults, override)
+	if err := validateClassifierRanges(classifier); err != nil {
+		return nil, err
+	}
+
+	if err := s.thresholdRepo.SaveThreshold(override); err != nil {
+		return nil, err
+	}
+
+	return s.mapThresholdsToResponse(classifier, ThresholdSourcePatient, override), nil
+}
+
+// ResetPatientThresholds menghapus penyesuaian batas klinis pasien sehingga kembali ke default
+func (s *ClinicalThresholdService) ResetPatientThresholds(clinicianID, patientID uint) error {
+	if err := s.ensureClinicianPatient(clinicianID, patientID); err != nil {
+		return err
+	}
+	return s.thresholdRepo.DeleteUserThreshold(patientID)
+}
+
+// ensureClinicianPatient memastikan pasien terhubung dengan clinician
+func (s *ClinicalThresholdService) ensureClinicianPatient(clinicianID, patientID uint) error {
+	assigned, err := s.clinicianRepo.IsPatientAssigned(clinicianID, patientID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return errors.New("pasien tidak terhubung dengan clinician")
+	}
+	return nil
+}
+
+// applyThresholdRequest menyalin field yang dikirim di request ke entity
+func applyThresholdRequest(threshold *entity.ClinicalThreshold, req *request.UpdateClinicalThresholdsRequest) {
+	if req.SystolicMin != nil {
+		threshold.SystolicMin = req.SystolicMin
+	}
+	if req.SystolicMax != nil {
+		threshold.SystolicMax = req.SystolicMax
+	}
+	if req.DiastolicMin != nil {
+		threshold.DiastolicMin = req.DiastolicMin
+	}
+	if req.DiastolicMax != nil {
+		threshold.DiastolicMax = req.DiastolicMax
+	}
+	if req.BloodSugarMin != nil {
+		threshold.BloodSugarMin = req.BloodSugarMin
+	}
+	if req.BloodSugarMax != nil {
+		threshold.BloodSugarMax = req.BloodSugarMax
+	}
+	if req.BloodSugarFastingMin != nil {
+		threshold.BloodSugarFastingMin = req.BloodSugarFastingMin
+	}
+	if req.BloodSugarFastingMax != nil {
+		threshold.BloodSugarFastingMax = req.BloodSugarFastingMax
+	}
+	if req.BloodSugarPostMealMin != nil {
+		threshold.BloodSugarPostMealMin = req.BloodSugarPostMealMin
+	}
+	if req.BloodSugarPostMealMax != nil {
+		threshold.BloodSugarPostMealMax = req.BloodSugarPostMealMax
+	}
+	if req.BloodSugarBedtimeMin != nil {
+		threshold.BloodSugarBedtimeMin = req.BloodSugarBedtimeMin
+	}
+	if req.BloodSugarBedtimeMax != nil {
+		threshold.BloodSugarBedtimeMax = req.BloodSugarBedtimeMax
+	}
+	if req.HeartRateMin != nil {
+		threshold.HeartRateMin = req.HeartRateMin
+	}
+	if req.HeartRateMax != nil {
+		threshold.HeartRateMax = req.HeartRateMax
+	}
+	if req.OxygenSaturationMin != nil {
+		threshold.OxygenSaturationMin = req.OxygenSaturationMin
+	}
+	if req.OxygenSaturationCritical != nil {
+		threshold.OxygenSaturationCritical = req.OxygenSaturationCritical
+	}
+	if req.TemperatureMin != nil {
+		threshold.TemperatureMin = req.TemperatureMin
+	}
+	if req.TemperatureMax != nil {
+		threshold.TemperatureMax = req.TemperatureMax
+	}
+	if req.TemperatureCritical != nil {
+		threshold.TemperatureCritical = req.TemperatureCritical
+	}
+	if req.RespiratoryRateMin != nil {
+		threshold.RespiratoryRateMin = req.RespiratoryRateMin
+	}
+	if req.RespiratoryRateMax != nil {
+		threshold.RespiratoryRateMax = req.RespiratoryRateMax
+	}
+	if req.BMIMin != nil {
+		threshold.BMIMin = req.BMIMin
+	}
+	if req.BMIMax != nil {
+		threshold.BMIMax = req.BMIMax
+	}
+	if req.HbA1cMin != nil {
+		threshold.HbA1cMin = req.HbA1cMin
+	}
+	if req.HbA1cMax != nil {
+		threshold.HbA1cMax = req.HbA1cMax
+	}
+	if req.TotalCholesterolMin != nil {
+		threshold.TotalCholesterolMin = req.TotalCholesterolMin
+	}
+	if req.TotalCholesterolMax != nil {
+		threshold.TotalCholesterolMax = req.TotalCholesterolMax
+	}
+	if req.LDLMin != nil {
+		threshold.LDLMin = req.LDLMin
+	}
+	if req.LDLMax != nil {
+		threshold.LDLMax = req.LDLMax
+	}
+	if req.HDLMin != nil {
+		threshold.HDLMin = req.HDLMin
+	}
+	if req.HDLMax != nil {
+		threshold.HDLMax = req.HDLMax
+	}
+	if req.TriglyceridesMin != nil {
+		threshold.TriglyceridesMin = req.TriglyceridesMin
+	}
+	if req.TriglyceridesMax != nil {
+		threshold.TriglyceridesMax = req.TriglyceridesMax
+	}
+	if req.UricAcidMin != nil {
+		threshold.UricAcidMin = req.UricAcidMin
+	}
+	if req.UricAcidMax != nil {
+		threshold.UricAcidMax = req.UricAcidMax
+	}
+	if req.CreatinineMin != nil {
+		threshold.CreatinineMin = req.CreatinineMin
+	}
+	if req.CreatinineMax != nil {
+		threshold.CreatinineMax = req.CreatinineMax
+	}
+	if req.Note != nil {
+		note := strings.TrimSpace(*req.Note)
+		if note == "" {
+			threshold.Note = nil
+		} else {
+			threshold.Note = &note
+		}
+	}
+}
+
+// validateClassifierRanges memastikan setiap batas minimum lebih kecil dari batas maksimum
+func validateClassifierRanges(c *HealthClassifier) error {
+	ranges := []struct {
+		name     string
+		min, max float64
+	}{
+		{"sistolik", float64(c.systolicMin), float64(c.systolicMax)},
+		{"diastolik", float64(c.diastolicMin), float64(c.diastolicMax)},
+		{"gula darah sewaktu", float64(c.BloodSugarMin(entity.BloodSugarContextRandom)), float64(c.BloodSugarMax(entity.BloodSugarContextRandom))},
+		{"gula darah puasa", float64(c.BloodSugarMin(entity.BloodSugarContextFasting)), float64(c.BloodSugarMax(entity.BloodSugarContextFasting))},
+		{"gula darah setelah makan", float64(c.BloodSugarMin(entity.BloodSugarContextPostMeal)), float64(c.BloodSugarMax(entity.BloodSugarContextPostMeal))},
+		{"gula darah sebelum tidur", float64(c.BloodSugarMin(entity.BloodSugarContextBedtime)), float64(c.BloodSugarMax(entity.BloodSugarContextBedtime))},
+		{"detak jantung", float64(c.heartRateMin), float64(c.heartRateMax)},
+		{"suhu tubuh", c.temperatureMin, c.temperatureMax},
+		{"laju napas", float64(c.respiratoryRateMin), float64(c.respiratoryRateMax)},
+		{"BMI", c.bmiMin, c.bmiMax},
+	}
+	for _, r := range ranges {
+		if r.min >= r.max {
+			return fmt.Errorf("batas minimum %s harus lebih kecil dari batas maksimum", r.name)
+		}
+	}
+	if c.oxygenSaturationCritical >= c.oxygenSaturationMin {
+		return errors.New("batas kritis saturasi oksigen harus lebih kecil dari batas minimum normal")
+	}
+	if c.temperatureCritical <= c.temperatureMax {
+		return errors.New("batas kritis suhu tubuh harus lebih besar dari batas maksimum normal")
+	}
+
+	// Rentang rujukan lab boleh hanya memiliki satu batas
+	for _, def := range labTestDefinitions {
+		min, max := c.LabReferenceRange(def.testType)
+		if min != nil && max != nil && *min >= *max {
+			return fmt.Errorf("batas minimum rujukan %s harus lebih kecil dari batas maksimum", def.label)
+		}
+	}
+	return nil
+}
+
+// mapThresholdsToResponse mengubah batas yang berlaku ke response
+// source adalah baris yang menjadi sumber metadata (catatan dan waktu perubahan), boleh nil
+func (s *ClinicalThresholdService) mapThresholdsToResponse(c *HealthClassifier, sourceName string, source *entity.ClinicalThreshold) *response.ClinicalThresholdsResponse {
+	resp := &response.ClinicalThresholdsResponse{
+		SystolicMin:              c.systolicMin,
+		SystolicMax:              c.systolicMax,
+		DiastolicMin:             c.diastolicMin,
+		DiastolicMax:             c.diastolicMax,
+		BloodSugarMin:            c.BloodSugarMin(entity.BloodSugarContextRandom),
+		BloodSugarMax:            c.BloodSugarMax(entity.BloodSugarContextRandom),
+		HeartRateMin:             c.heartRateMin,
+		HeartRateMax:             c.heartRateMax,
+		OxygenSaturationMin:      c.oxygenSaturationMin,
+		OxygenSaturationCritical: c.oxygenSaturationCritical,
+		TemperatureMin:           c.temperatureMin,
+		TemperatureMax:           c.temperatureMax,
+		TemperatureCritical:      c.temperatureCritical,
+		RespiratoryRateMin:       c.respiratoryRateMin,
+		RespiratoryRateMax:       c.respiratoryRateMax,
+		BMIMin:                   c.bmiMin,
+		BMIMax:                   c.bmiMax,
+		Source:                   sourceName,
+		Customized:               c.IsCustomized(),
+
+		BloodSugarFastingMin:  c.BloodSugarMin(entity.BloodSugarContextFasting),
+		BloodSugarFastingMax:  c.BloodSugarMax(entity.BloodSugarContextFasting),
+		BloodSugarPostMealMin: c.BloodSugarMin(entity.BloodSugarContextPostMeal),
+		BloodSugarPostMealMax: c.BloodSugarMax(entity.BloodSugarContextPostMeal),
+		BloodSugarBedtimeMin:  c.BloodSugarMin(entity.BloodSugarContextBedtime),
+		BloodSugarBedtimeMax:  c.BloodSugarMax(entity.BloodSugarContextBedtime),
+	}
+
+	resp.HbA1cMin, resp.HbA1cMax = c.LabReferenceRange(entity.LabTestHbA1c)
+	resp.TotalCholesterolMin, resp.TotalCholesterolMax = c.LabReferenceRange(entity.LabTestTotalCholesterol)
+	resp.LDLMin, resp.LDLMax = c.LabReferenceRange(entity.LabTestLDL)
+	resp.HDLMin, resp.HDLMax = c.LabReferenceRange(entity.LabTestHDL)
+	resp.TriglyceridesMin, resp.TriglyceridesMax = c.LabReferenceRange(entity.LabTestTriglycerides)
+	resp.UricAcidMin, resp.UricAcidMax = c.LabReferenceRange(entity.LabTestUricAcid)
+	resp.CreatinineMin, resp.CreatinineMax = c.LabReferenceRange(entity.LabTestCreatinine)
+
+	if source != nil && source.ID != 0 {
+		updatedAt := timezoneUtils.ToJakarta(source.UpdatedAt)
+		resp.Note = source.Note
+		resp.UpdatedBy = source.UpdatedBy
+		resp.UpdatedAt = &updatedAt
+	}
+
+	return resp
+}
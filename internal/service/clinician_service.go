@@ -0,0 +1,360 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"sort"
+	"strings"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// Opsi pengurutan dashboard clinician
+const (
+	ClinicianSortRisk         = "risk"          // Skor risiko tertinggi di atas (default)
+	ClinicianSortName         = "name"          // Nama pasien A-Z
+	ClinicianSortLastMeasured = "last_measured" // Pasien yang paling lama tidak mengukur di atas
+)
+
+// Level risiko pasien pada dashboard clinician
+const (
+	RiskLevelTinggi = "TINGGI"
+	RiskLevelSedang = "SEDANG"
+	RiskLevelRendah = "RENDAH"
+
+	riskLevelTinggiMinScore = 6
+	riskLevelSedangMinScore = 3
+
+	// Pasien yang tidak mengukur lebih dari batas ini dianggap perlu ditindaklanjuti
+	staleMeasurementDays = 7
+	// Batas kontribusi alert belum dibaca pada skor risiko
+	maxUnreadAlertRiskScore = 3
+)
+
+// ClinicianService menangani relasi clinician-pasien dan dashboard clinician
+type ClinicianService struct {
+	clinicianRepo            *repository.ClinicianRepository
+	userRepo                 *repository.UserRepository
+	healthDataRepo           *repository.HealthDataRepository
+	healthAlertRepo          *repository.HealthAlertRepository
+	profileService           *ProfileService
+	clinicalThresholdService *ClinicalThresholdService
+}
+
+// NewClinicianService membuat instance baru dari ClinicianService
+func NewClinicianService(
+	clinicianRepo *repository.ClinicianRepository,
+	userRepo *repository.UserRepository,
+	healthDataRepo *repository.HealthDataRepository,
+	healthAlertRepo *repository.HealthAlertRepository,
+	profileService *ProfileService,
+	clinicalThresholdService *ClinicalThresholdService,
+) *ClinicianService {
+	return &ClinicianService{
+		clinicianRepo:            clinicianRepo,
+		userRepo:                 userRepo,
+		healthDataRepo:           healthDataRepo,
+		healthAlertRepo:          healthAlertRepo,
+		profileService:           profileService,
+		clinicalThresholdService: clinicalThresholdService,
+	}
+}
+
+// AssignPatient menghubungkan pasien ke clinician (oleh admin)
+func (s *ClinicianService) AssignPatient(adminID, clinicianID uint, req *request.AssignPatientRequest) error {
+	clinician, err := s.userRepo.GetUserByID(clinicianID)
+	if err != nil {
+		return err
+	}
+	if clinician.Role != entity.RoleClinician {
+		return errors.New("user bukan clinician")
+	}
+
+	patient, err := s.userRepo.GetUserByID(req.PatientID)
+	if err != nil {
+		return err
+	}
+	if patient.Role != entity.RolePatient {
+		return errors.New("user bukan pasien")
+	}
+
+	assigned, err := s.clinicianRepo.IsPatientAssigned(clinicianID, patient.ID)
+	if err != nil {
+		return err
+	}
+	if assigned {
+		return errors.New("pasien sudah terhubung dengan clinician")
+	}
+
+	return s.clinicianRepo.AssignPatient(&entity.ClinicianPatient{
+		ClinicianID: clinicianID,
+		PatientID:   patient.ID,
+		AssignedBy:  &adminID,
+	})
+}
+
+// UnassignPatient melepas hubungan pasien dari clinician (oleh admin)
+func (s *ClinicianService) UnassignPatient(clinicianID, patientID uint) error {
+	return s.clinicianRepo.UnassignPatient(clinicianID, patientID)
+}
+
+// GetAssignedPatients mengambil daftar pasien yang terhubung dengan clinician
+func (s *ClinicianService) GetAssignedPatients(clinicianID uint) ([]response.AdminUserResponse, error) {
+	if _, err := s.userRepo.GetUserByID(clinicianID); err != nil {
+		return nil, err
+	}
+
+	patients, err := s.clinicianRepo.GetPatientsByClinicianID(clinicianID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.AdminUserResponse, 0, len(patients))
+	for _, patient := range patients {
+		items = append(items, response.AdminUserResponse{
+			ID:            patient.ID,
+			Nama:          patient.Nama,
+			Username:      patient.Username,
+			Email:         patient.Email,
+			Role:          string(patient.Role),
+			EmailVerified: patient.IsEmailVerified(),
+			CreatedAt:     timezoneUtils.ToJakarta(patient.CreatedAt),
+		})
+	}
+	return items, nil
+}
+
+// GetDashboard mengambil ringkasan kondisi pasien clinician dengan pagination:
+// pembacaan terakhir, status per metrik, alert belum dibaca, tanggal pengukuran terakhir,
+// progres target, dan skor risiko untuk pengurutan.
+// Pembacaan terakhir, alert belum dibaca, dan batas klinis diambil untuk semua pasien dengan query
+// berkelompok (bukan per pasien) karena skor risiko dibutuhkan untuk mengurutkan sebelum pagination;
+// target hanya diambil untuk pasien di halaman yang diminta.
+func (s *ClinicianService) GetDashboard(clinicianID uint, req *request.ClinicianDashboardRequest) (*response.ClinicianDashboardResponse, error) {
+	sortBy := strings.ToLower(strings.TrimSpace(req.Sort))
+	if sortBy == "" {
+		sortBy = ClinicianSortRisk
+	}
+	if sortBy != ClinicianSortRisk && sortBy != ClinicianSortName && sortBy != ClinicianSortLastMeasured {
+		return nil, errors.New("sort harus salah satu dari risk, name, last_measured")
+	}
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	patients, err := s.clinicianRepo.GetPatientsByClinicianID(clinicianID)
+	if err != nil {
+		return nil, err
+	}
+
+	patientIDs := make([]uint, 0, len(patients))
+	for _, patient := range patients {
+		patientIDs = append(patientIDs, patient.ID)
+	}
+
+	snapshots, err := s.healthDataRepo.GetLatestVitalSnapshotsByUserIDs(patientIDs)
+	if err != nil {
+		return nil, err
+	}
+	unreadCounts, err := s.healthAlertRepo.CountUnreadHealthAlertsByUserIDs(patientIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Status dihitung dengan batas klinis pasien (termasuk penyesuaian oleh clinician)
+	classifiers, err := s.clinicalThresholdService.GetClassifiers(patientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]response.ClinicianPatientSummary, 0, len(patients))
+	for i := range patients {
+		patient := &patients[i]
+		summary := buildPatientSummary(patient, snapshots[patient.ID], classifiers[patient.ID], unreadCounts[patient.ID])
+		summaries = append(summaries, *summary)
+	}
+
+	sortClinicianPatients(summaries, sortBy)
+
+	total := len(summaries)
+	start := (page - 1) * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	pageSummaries := summaries[start:end]
+
+	for i := range pageSummaries {
+		targets, err := s.profileService.GetHealthTargets(pageSummaries[i].PatientID)
+		if err != nil {
+			return nil, err
+		}
+		if targets.BloodPressure != nil || targets.BloodSugar != nil || targets.Weight != nil {
+			pageSummaries[i].Targets = targets
+		}
+	}
+
+	return &response.ClinicianDashboardResponse{
+		TotalPatients: total,
+		Sort:          sortBy,
+		Patients:      pageSummaries,
+		Pagination:    newPaginationResponse(page, limit, int64(total)),
+	}, nil
+}
+
+// buildPatientSummary menyusun ringkasan kondisi satu pasien dari data yang sudah diambil
+// snapshot bernilai nil jika pasien belum memiliki data kesehatan
+func buildPatientSummary(patient *entity.User, snapshot *entity.HealthData, classifier *HealthClassifier, unreadCount int64) *response.ClinicianPatientSummary {
+	summary := &response.ClinicianPatientSummary{
+		PatientID: patient.ID,
+		Nama:      patient.Nama,
+		Username:  patient.Username,
+		Email:     patient.Email,
+	}
+
+	if snapshot != nil {
+		readings := &response.ClinicianLatestReadings{
+			Systolic:   snapshot.Systolic,
+			Diastolic:  snapshot.Diastolic,
+			BloodSugar: snapshot.BloodSugar,
+			Weight:     snapshot.Weight,
+			HeartRate:  snapshot.HeartRate,
+
+			OxygenSaturation: snapshot.OxygenSaturation,
+			Temperature:      snapshot.Temperature,
+			RespiratoryRate:  snapshot.RespiratoryRate,
+		}
+		if snapshot.BloodSugar != nil {
+			context := string(entity.ResolveBloodSugarContext(snapshot.BloodSugarContext))
+			readings.BloodSugarContext = &context
+		}
+
+		if snapshot.Systolic != nil && snapshot.Diastolic != nil {
+			summary.Status.BloodPressure = classifier.BloodPressureStatus(*snapshot.Systolic, *snapshot.Diastolic)
+		}
+		if snapshot.BloodSugar != nil {
+			summary.Status.BloodSugar = classifier.BloodSugarStatus(*snapshot.BloodSugar, entity.ResolveBloodSugarContext(snapshot.BloodSugarContext))
+		}
+		if snapshot.HeartRate != nil {
+			summary.Status.HeartRate = classifier.HeartRateStatus(*snapshot.HeartRate)
+		}
+		if snapshot.OxygenSaturation != nil {
+			summary.Status.OxygenSaturation = classifier.OxygenSaturationStatus(*snapshot.OxygenSaturation)
+		}
+		if snapshot.Temperature != nil {
+			summary.Status.Temperature = classifier.TemperatureStatus(*snapshot.Temperature)
+		}
+		if snapshot.RespiratoryRate != nil {
+			summary.Status.RespiratoryRate = classifier.RespiratoryRateStatus(*snapshot.RespiratoryRate)
+		}
+		if snapshot.Weight != nil && snapshot.HeightCM != nil {
+			bmi := roundTo2Decimals(calculateBMI(*snapshot.Weight, *snapshot.HeightCM))
+			if bmi > 0 {
+				readings.BMI = &bmi
+				summary.Status.BMI = classifier.BMIStatus(bmi)
+			}
+		}
+		summary.LatestReadings = readings
+
+		lastMeasuredAt := timezoneUtils.ToJakarta(snapshot.MeasuredAt)
+		daysSince := int(timezoneUtils.NowInJakarta().Sub(lastMeasuredAt).Hours() / 24)
+		summary.LastMeasuredAt = &lastMeasuredAt
+		summary.DaysSinceLastMeasurement = &daysSince
+	}
+
+	summary.UnreadAlertCount = unreadCount
+
+	summary.RiskScore = calculateRiskScore(summary)
+	summary.RiskLevel = getRiskLevel(summary.RiskScore)
+
+	return summary
+}
+
+// calculateRiskScore menghitung skor risiko pasien dari status metrik, alert belum dibaca,
+// dan keteraturan pengukuran. Semakin tinggi skor, semakin perlu diperhatikan.
+func calculateRiskScore(summary *response.ClinicianPatientSummary) int {
+	score := 0
+
+	switch summary.Status.BloodPressure {
+	case StatusTinggi:
+		score += 3
+	case StatusRendah:
+		score += 2
+	}
+
+	// Hipoglikemia sama berbahayanya dengan hiperglikemia
+	if summary.Status.BloodSugar == StatusTinggi || summary.Status.BloodSugar == StatusRendah {
+		score += 3
+	}
+
+	if summary.Status.HeartRate == StatusTinggi || summary.Status.HeartRate == StatusRendah {
+		score += 2
+	}
+
+	// Saturasi oksigen rendah memerlukan penanganan segera
+	if summary.Status.OxygenSaturation == StatusRendah {
+		score += 3
+	}
+
+	if summary.Status.Temperature == StatusTinggi || summary.Status.Temperature == StatusRendah {
+		score += 2
+	}
+
+	if summary.Status.RespiratoryRate == StatusTinggi || summary.Status.RespiratoryRate == StatusRendah {
+		score += 2
+	}
+
+	if summary.Status.BMI == StatusTinggi || summary.Status.BMI == StatusRendah {
+		score++
+	}
+
+	if summary.UnreadAlertCount > maxUnreadAlertRiskScore {
+		score += maxUnreadAlertRiskScore
+	} else {
+		score += int(summary.UnreadAlertCount)
+	}
+
+	// Belum pernah mengukur atau sudah lama tidak mengukur
+	if summary.DaysSinceLastMeasurement == nil || *summary.DaysSinceLastMeasurement > staleMeasurementDays {
+		score++
+	}
+
+	return score
+}
+
+// getRiskLevel mengubah skor risiko menjadi level risiko
+func getRiskLevel(score int) string {
+	if score >= riskLevelTinggiMinScore {
+		return RiskLevelTinggi
+	}
+	if score >= riskLevelSedangMinScore {
+		return RiskLevelSedang
+	}
+	return RiskLevelRendah
+}
+
+// sortClinicianPatients mengurutkan ringkasan pasien sesuai opsi sort
+func sortClinicianPatients(summaries []response.ClinicianPatientSummary, sortBy string) {
+	sort.SliceStable(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		switch sortBy {
+		case ClinicianSortName:
+			return strings.ToLower(a.Nama) < strings.ToLower(b.Nama)
+		case ClinicianSortLastMeasured:
+			// Pasien yang belum pernah mengukur ditempatkan paling atas
+			if a.LastMeasuredAt == nil || b.LastMeasuredAt == nil {
+				return a.LastMeasuredAt == nil && b.LastMeasuredAt != nil
+			}
+			return a.LastMeasuredAt.Before(*b.LastMeasuredAt)
+		default:
+			if a.RiskScore != b.RiskScore {
+				return a.RiskScore > b.RiskScore
+			}
+			return strings.ToLower(a.Nama) < strings.ToLower(b.Nama)
+		}
+	})
+}
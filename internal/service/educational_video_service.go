@@ -6,7 +6,9 @@ import (
 	"BE-PeriksaKesehatan/internal/model/entity"
 	"BE-PeriksaKesehatan/internal/repository"
 	"errors"
+	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -61,6 +63,61 @@ func (s *EducationalVideoService) AddEducationalVideo(req *request.EducationalVi
 	return resp, nil
 }
 
+// UpdateEducationalVideo memperbarui judul, URL, dan kategori video edukasi
+// Kategori video diganti seluruhnya dengan category_ids pada request
+func (s *EducationalVideoService) UpdateEducationalVideo(videoIDStr string, req *request.EducationalVideoRequest) (*response.AddEducationalVideoResponse, error) {
+	// Validasi dan parse ID
+	videoID, err := strconv.ParseUint(videoIDStr, 10, 32)
+	if err != nil {
+		return nil, errors.New("ID video tidak valid")
+	}
+
+	// Validasi (sudah termasuk validasi category_ids)
+	if err := s.validateVideoRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Pastikan video ada
+	video, err := s.educationalVideoRepo.GetEducationalVideoByID(uint(videoID))
+	if err != nil {
+		return nil, err
+	}
+
+	// Validasi semua kategori exists
+	categories, err := s.validateCategoriesExist(req.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	video.VideoTitle = strings.TrimSpace(req.VideoTitle)
+	video.VideoURL = strings.TrimSpace(req.VideoURL)
+	video.HealthCondition = categories[0].Kategori // Gunakan kategori pertama untuk health_condition (backward compatibility)
+	video.CategoryID = nil
+
+	// Simpan perubahan video beserta relasi kategori dengan transaksi atomic
+	if err := s.educationalVideoRepo.UpdateEducationalVideoWithCategories(video, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
+	return &response.AddEducationalVideoResponse{
+		ID:          video.ID,
+		VideoTitle:  video.VideoTitle,
+		VideoURL:    video.VideoURL,
+		CategoryIDs: req.CategoryIDs,
+	}, nil
+}
+
+// DeleteEducationalVideo menghapus video edukasi beserta relasi kategorinya
+func (s *EducationalVideoService) DeleteEducationalVideo(videoIDStr string) error {
+	// Validasi dan parse ID
+	videoID, err := strconv.ParseUint(videoIDStr, 10, 32)
+	if err != nil {
+		return errors.New("ID video tidak valid")
+	}
+
+	return s.educationalVideoRepo.DeleteEducationalVideo(uint(videoID))
+}
+
 // validateCategoriesExist memvalidasi bahwa semua category IDs ada di database
 func (s *EducationalVideoService) validateCategoriesExist(categoryIDs []uint) ([]entity.Category, error) {
 	if len(categoryIDs) == 0 {
@@ -113,6 +170,7 @@ func (s *EducationalVideoService) GetAllEducationalVideos() (*response.GetAllEdu
 		videoItems := make([]response.EducationalVideoItem, 0, len(videos))
 		for _, video := range videos {
 			videoItems = append(videoItems, response.EducationalVideoItem{
+				ID:    video.ID,
 				Title: video.VideoTitle,
 				URL:   video.VideoURL,
 			})
@@ -154,6 +212,7 @@ func (s *EducationalVideoService) GetEducationalVideosByCategoryID(categoryIDStr
 	videoItems := make([]response.EducationalVideoItem, 0, len(videos))
 	for _, video := range videos {
 		videoItems = append(videoItems, response.EducationalVideoItem{
+			ID:    video.ID,
 			Title: video.VideoTitle,
 			URL:   video.VideoURL,
 		})
@@ -194,3 +253,103 @@ func (s *EducationalVideoService) validateVideoRequest(req *request.EducationalV
 	return nil
 }
 
+// ExportEducationalVideos mengambil semua video edukasi dalam format ekspor (kategori berdasarkan nama)
+func (s *EducationalVideoService) ExportEducationalVideos() ([]response.EducationalVideoExportItem, error) {
+	videos, err := s.educationalVideoRepo.GetAllEducationalVideosWithCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.EducationalVideoExportItem, 0, len(videos))
+	for _, video := range videos {
+		categories := make([]string, 0, len(video.Categories)+1)
+		for _, category := range video.Categories {
+			categories = append(categories, category.Kategori)
+		}
+		// Data lama hanya memiliki category_id
+		if len(categories) == 0 && video.Category != nil {
+			categories = append(categories, video.Category.Kategori)
+		}
+
+		items = append(items, response.EducationalVideoExportItem{
+			VideoTitle: video.VideoTitle,
+			VideoURL:   video.VideoURL,
+			Categories: categories,
+		})
+	}
+	return items, nil
+}
+
+// ImportEducationalVideos menambahkan video edukasi dari file impor
+// Kategori dicocokkan berdasarkan nama (case-insensitive) dan harus sudah ada.
+// Video dengan URL yang sudah ada dilewati sehingga impor aman diulang.
+// Data tidak valid dicatat per baris tanpa menghentikan impor; error database menghentikan impor.
+func (s *EducationalVideoService) ImportEducationalVideos(items []request.EducationalVideoImportRequest) (*response.ContentImportResponse, error) {
+	categories, err := s.categoryRepo.GetAllCategories()
+	if err != nil {
+		return nil, err
+	}
+	categoriesByName := make(map[string]entity.Category, len(categories))
+	for _, category := range categories {
+		categoriesByName[strings.ToLower(category.Kategori)] = category
+	}
+
+	result := &response.ContentImportResponse{}
+	for i, item := range items {
+		req := request.EducationalVideoRequest{
+			VideoTitle: item.VideoTitle,
+			VideoURL:   item.VideoURL,
+		}
+
+		var firstCategory string
+		var unknownCategories []string
+		for _, name := range item.Categories {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			category, ok := categoriesByName[strings.ToLower(name)]
+			if !ok {
+				unknownCategories = append(unknownCategories, name)
+				continue
+			}
+			if !slices.Contains(req.CategoryIDs, category.ID) {
+				req.CategoryIDs = append(req.CategoryIDs, category.ID)
+			}
+			if firstCategory == "" {
+				firstCategory = category.Kategori
+			}
+		}
+
+		if len(unknownCategories) > 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("baris %d: kategori tidak ditemukan (%s)", i+1, strings.Join(unknownCategories, ", ")))
+			continue
+		}
+		if err := s.validateVideoRequest(&req); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("baris %d: %v", i+1, err))
+			continue
+		}
+
+		videoURL := strings.TrimSpace(req.VideoURL)
+		exists, err := s.educationalVideoRepo.CheckVideoURLExists(videoURL)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			result.Skipped++
+			continue
+		}
+
+		video := &entity.EducationalVideo{
+			VideoTitle:      strings.TrimSpace(req.VideoTitle),
+			VideoURL:        videoURL,
+			HealthCondition: firstCategory, // Kategori pertama untuk health_condition (backward compatibility)
+		}
+		if err := s.educationalVideoRepo.CreateEducationalVideoWithCategories(video, req.CategoryIDs); err != nil {
+			return nil, err
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
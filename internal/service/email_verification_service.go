@@ -0,0 +1,146 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"BE-PeriksaKesehatan/pkg/mailer"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+const (
+	// verificationResendCooldown adalah jeda minimal antar pengiriman email verifikasi
+	verificationResendCooldown = time.Minute
+	// maxVerificationEmailsPerHour adalah batas pengiriman email verifikasi per user dalam satu jam
+	maxVerificationEmailsPerHour = 5
+)
+
+// EmailVerificationService menangani pengiriman dan verifikasi token verifikasi email
+type EmailVerificationService struct {
+	userRepo        *repository.UserRepository
+	authRepo        *repository.AuthRepository
+	mailSender      mailer.Sender
+	verificationTTL time.Duration
+	verificationURL string
+}
+
+// NewEmailVerificationService membuat instance baru dari EmailVerificationService
+func NewEmailVerificationService(userRepo *repository.UserRepository, authRepo *repository.AuthRepository, mailSender mailer.Sender, verificationTTL time.Duration, verificationURL string) *EmailVerificationService {
+	return &EmailVerificationService{
+		userRepo:        userRepo,
+		authRepo:        authRepo,
+		mailSender:      mailSender,
+		verificationTTL: verificationTTL,
+		verificationURL: verificationURL,
+	}
+}
+
+// SendVerificationEmail membuat token verifikasi baru dan mengirimkannya ke email user.
+// Token sebelumnya yang belum dipakai dibatalkan.
+func (s *EmailVerificationService) SendVerificationEmail(user *entity.User) error {
+	if err := s.authRepo.InvalidateEmailVerificationTokens(user.ID); err != nil {
+		return err
+	}
+
+	token, err := generateSecureToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := timezoneUtils.NowInJakarta().Add(s.verificationTTL)
+	if err := s.authRepo.CreateEmailVerificationToken(&entity.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	return s.mailSender.Send(s.buildVerificationMessage(user, token, expiresAt))
+}
+
+// ResendVerificationEmail mengirim ulang email verifikasi untuk user yang sedang login.
+// Dibatasi satu kali per menit dan maksimal lima kali per jam.
+func (s *EmailVerificationService) ResendVerificationEmail(userID uint) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return errors.New("email sudah diverifikasi")
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	sentLastHour, lastSentAt, err := s.authRepo.GetEmailVerificationTokenStats(user.ID, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+
+	if lastSentAt != nil {
+		if wait := lastSentAt.Add(verificationResendCooldown).Sub(now); wait > 0 {
+			return &RateLimitError{Message: "email verifikasi baru saja dikirim, coba lagi nanti", RetryAfter: wait}
+		}
+	}
+	if sentLastHour >= maxVerificationEmailsPerHour {
+		return &RateLimitError{Message: "terlalu banyak permintaan email verifikasi, coba lagi nanti", RetryAfter: time.Hour}
+	}
+
+	return s.SendVerificationEmail(user)
+}
+
+// VerifyEmail memverifikasi email user menggunakan token dari email
+func (s *EmailVerificationService) VerifyEmail(token string) error {
+	verificationToken, err := s.authRepo.GetEmailVerificationTokenByHash(hashToken(strings.TrimSpace(token)))
+	if err != nil {
+		if err.Error() == "token verifikasi email tidak ditemukan" {
+			return errors.New("token verifikasi email tidak valid")
+		}
+		return err
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	if verificationToken.UsedAt != nil || !verificationToken.ExpiresAt.After(now) {
+		return errors.New("token verifikasi email tidak valid")
+	}
+
+	marked, err := s.authRepo.MarkEmailVerificationTokenUsed(verificationToken.ID)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return errors.New("token verifikasi email tidak valid")
+	}
+
+	if err := s.userRepo.MarkEmailVerified(verificationToken.UserID, now); err != nil {
+		return err
+	}
+
+	return s.authRepo.InvalidateEmailVerificationTokens(verificationToken.UserID)
+}
+
+// buildVerificationMessage menyusun email berisi link/token verifikasi email
+func (s *EmailVerificationService) buildVerificationMessage(user *entity.User, token string, expiresAt time.Time) mailer.Message {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Halo %s,\n\n", user.Nama)
+	body.WriteString("Terima kasih telah mendaftar di Periksa Kesehatan. Silakan verifikasi alamat email Anda.\n\n")
+
+	if s.verificationURL != "" {
+		fmt.Fprintf(&body, "Buka link berikut untuk memverifikasi email:\n%s\n\n", appendTokenToURL(s.verificationURL, token))
+	} else {
+		fmt.Fprintf(&body, "Gunakan token berikut untuk memverifikasi email:\n%s\n\n", token)
+	}
+
+	fmt.Fprintf(&body, "Link/token ini berlaku sampai %s WIB.\n", timezoneUtils.ToJakarta(expiresAt).Format("02-01-2006 15:04"))
+	body.WriteString("Jika Anda tidak merasa mendaftar, abaikan email ini.\n")
+
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "Verifikasi Email Periksa Kesehatan",
+		Body:    body.String(),
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// alertRecommendations adalah struktur rekomendasi yang disimpan di kolom recommendations (JSON)
+type alertRecommendations struct {
+	ImmediateActions []string `json:"immediate_actions"`
+	MedicalAttention []string `json:"medical_attention"`
+	ManagementTips   []string `json:"management_tips"`
+}
+
+// SyncHealthAlertsForDate mengevaluasi ulang semua pembacaan user pada satu tanggal dan
+// menyelaraskan alert tersimpan. Alert dideduplikasi per kategori per record_date: setiap
+// kategori menyimpan pembacaan tidak normal terakhir (berdasarkan measured_at) pada hari itu,
+// dan alert unread kategori yang sudah tidak memiliki pembacaan tidak normal dihapus.
+// Dipanggil setiap kali pembacaan dibuat, diubah, atau dihapus.
+func (s *HealthAlertService) SyncHealthAlertsForDate(userID uint, recordDate time.Time) error {
+	readings, err := s.healthDataRepo.GetHealthDataListByUserIDAndDate(userID, recordDate)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil pembacaan: %w", err)
+	}
+
+	classifier, err := s.clinicalThresholdService.GetClassifier(userID)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil batas klinis: %w", err)
+	}
+
+	// readings sudah terurut measured_at ASC, sehingga alert terakhir per kategori menang
+	type categoryAlert struct {
+		alert   response.HealthAlertResponse
+		reading entity.HealthData
+	}
+	latestByCategory := make(map[string]categoryAlert)
+	for _, reading := range readings {
+		evaluated := reading
+		// Pembacaan berat badan sering dikirim tanpa tinggi badan, gunakan tinggi badan terakhir
+		// yang tercatat sampai waktu pengukuran agar BMI tetap bisa dievaluasi. Tinggi badan yang
+		// dicatat setelah pembacaan ini tidak dipakai, sehingga sinkronisasi ulang hari lama tetap konsisten.
+		if evaluated.Weight != nil && evaluated.HeightCM == nil {
+			height, err := s.healthDataRepo.GetLatestHeightByUserID(userID, reading.MeasuredAt)
+			if err != nil {
+				return fmt.Errorf("gagal mengambil tinggi badan terakhir: %w", err)
+			}
+			evaluated.HeightCM = height
+		}
+		for _, alert := range s.evaluateHealthData(classifier, &evaluated) {
+			latestByCategory[alert.Category] = categoryAlert{alert: alert, reading: reading}
+		}
+	}
+
+	// Hapus alert kategori yang sudah tidak tidak normal (misalnya pembacaan dikoreksi atau dihapus).
+	// Hanya alert yang belum dibaca yang dihapus; alert yang sudah dibaca, dikonfirmasi, atau diselesaikan
+	// tetap disimpan sebagai riwayat tindak lanjut user.
+	existingAlerts, err := s.healthAlertRepo.GetHealthAlertsByUserIDAndDate(userID, recordDate)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil alert tersimpan: %w", err)
+	}
+	for _, existing := range existingAlerts {
+		if existing.State != entity.AlertStateUnread {
+			continue
+		}
+		if _, ok := latestByCategory[existing.Category]; !ok {
+			if err := s.healthAlertRepo.DeleteHealthAlert(existing.ID); err != nil {
+				return fmt.Errorf("gagal menghapus alert %s: %w", existing.Category, err)
+			}
+		}
+	}
+
+	for category, item := range latestByCategory {
+		if err := s.upsertHealthAlert(&item.reading, &item.alert); err != nil {
+			return fmt.Errorf("gagal menyimpan alert %s: %w", category, err)
+		}
+	}
+
+	return nil
+}
+
+// upsertHealthAlert membuat alert baru atau memperbarui alert harian yang sudah ada
+func (s *HealthAlertService) upsertHealthAlert(healthData *entity.HealthData, alert *response.HealthAlertResponse) error {
+	recommendations, err := json.Marshal(alertRecommendations{
+		ImmediateActions: alert.ImmediateActions,
+		MedicalAttention: alert.MedicalAttention,
+		ManagementTips:   alert.ManagementTips,
+	})
+	if err != nil {
+		return err
+	}
+
+	healthDataID := healthData.ID
+	healthAlert := &entity.HealthAlert{
+		UserID:          healthData.UserID,
+		HealthDataID:    &healthDataID,
+		AlertType:       alert.AlertType,
+		Category:        alert.Category,
+		Value:           alert.Value,
+		Label:           alert.Label,
+		Message:         alert.Explanation,
+		Status:          entity.AlertStatus(alert.Status),
+		Recommendations: string(recommendations),
+		RecordDate:      healthData.RecordDate,
+		RecordedAt:      alert.RecordedAt,
+		State:           entity.AlertStateUnread,
+	}
+	created, err := s.healthAlertRepo.UpsertHealthAlertLocked(healthAlert, func(existing *entity.HealthAlert) {
+		// Nilai baru yang berbeda membutuhkan perhatian ulang dari user,
+		// sehingga status tindak lanjut dikembalikan ke unread
+		if existing.Value != healthAlert.Value || existing.Status != healthAlert.Status {
+			resetHealthAlertState(existing)
+		}
+		existing.HealthDataID = healthAlert.HealthDataID
+		existing.AlertType = healthAlert.AlertType
+		existing.Value = healthAlert.Value
+		existing.Label = healthAlert.Label
+		existing.Message = healthAlert.Message
+		existing.Status = healthAlert.Status
+		existing.Recommendations = healthAlert.Recommendations
+		existing.RecordedAt = healthAlert.RecordedAt
+	})
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+
+	// Hanya alert baru yang dikirim ke inbox; pembaruan alert pada hari yang sama tidak dinotifikasi ulang
+	alertID := healthAlert.ID
+	s.notificationService.publish(NotificationMessage{
+		UserID: healthAlert.UserID,
+		Type:   entity.NotificationHealthAlert,
+		Params: map[string]string{
+			"category": healthAlert.Category,
+			"status":   string(healthAlert.Status),
+			"value":    healthAlert.Value,
+		},
+		ReferenceID: &alertID,
+		DedupKey:    fmt.Sprintf("alert:%d", healthAlert.ID),
+	})
+	return nil
+}
+
+// GetHealthAlertHistory mengambil riwayat alert tersimpan dengan filter dan pagination
+func (s *HealthAlertService) GetHealthAlertHistory(userID uint, req *request.HealthAlertHistoryRequest) (*response.HealthAlertHistoryResponse, error) {
+	filter, err := s.buildHealthAlertFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	alerts, total, err := s.healthAlertRepo.GetHealthAlertsWithFilter(userID, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCount, err := s.healthAlertRepo.CountUnreadHealthAlerts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.HealthAlertHistoryItem, 0, len(alerts))
+	for _, alert := range alerts {
+		items = append(items, s.mapHealthAlertToHistoryItem(&alert))
+	}
+
+	return &response.HealthAlertHistoryResponse{
+		Alerts:      items,
+		UnreadCount: unreadCount,
+		Pagination:  newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// buildHealthAlertFilter memvalidasi query parameter dan mengubahnya menjadi filter repository
+func (s *HealthAlertService) buildHealthAlertFilter(req *request.HealthAlertHistoryRequest) (repository.HealthAlertFilter, error) {
+	filter := repository.HealthAlertFilter{}
+
+	if req.Status != "" {
+		status := strings.ToUpper(strings.TrimSpace(req.Status))
+		if status != StatusRendah && status != StatusTinggi {
+			return filter, errors.New("status harus RENDAH atau TINGGI")
+		}
+		filter.Status = status
+	}
+
+	if req.Category != "" {
+		category := strings.ToLower(strings.TrimSpace(req.Category))
+		if !isKnownAlertCategory(category) {
+			return filter, errors.New("category harus salah satu dari diabetes, hipertensi, jantung, berat_badan, pernapasan, suhu_tubuh")
+		}
+		filter.Category = category
+	}
+
+	if req.State != "" {
+		state := entity.AlertState(strings.ToLower(strings.TrimSpace(req.State)))
+		if !isValidAlertState(state) {
+			return filter, errors.New("state harus salah satu dari unread, read, acknowledged, resolved")
+		}
+		filter.State = string(state)
+	}
+
+	if req.StartDate != nil {
+		startDate := timezoneUtils.ToJakarta(*req.StartDate)
+		filter.StartDate = &startDate
+	}
+	if req.EndDate != nil {
+		endDate := timezoneUtils.ToJakarta(*req.EndDate)
+		filter.EndDate = &endDate
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return filter, errors.New("start_date tidak boleh setelah end_date")
+	}
+
+	return filter, nil
+}
+
+// mapHealthAlertToHistoryItem mengubah entity alert ke response riwayat alert
+func (s *HealthAlertService) mapHealthAlertToHistoryItem(alert *entity.HealthAlert) response.HealthAlertHistoryItem {
+	recommendations := alertRecommendations{}
+	if alert.Recommendations != "" {
+		// Jika JSON tidak valid (data lama), rekomendasi dikembalikan sebagai array kosong
+		_ = json.Unmarshal([]byte(alert.Recommendations), &recommendations)
+	}
+	if recommendations.ImmediateActions == nil {
+		recommendations.ImmediateActions = []string{}
+	}
+	if recommendations.MedicalAttention == nil {
+		recommendations.MedicalAttention = []string{}
+	}
+	if recommendations.ManagementTips == nil {
+		recommendations.ManagementTips = []string{}
+	}
+
+	return response.HealthAlertHistoryItem{
+		ID:               alert.ID,
+		HealthDataID:     alert.HealthDataID,
+		AlertType:        alert.AlertType,
+		Category:         alert.Category,
+		Value:            alert.Value,
+		Label:            alert.Label,
+		Status:           string(alert.Status),
+		Explanation:      alert.Message,
+		ImmediateActions: recommendations.ImmediateActions,
+		MedicalAttention: recommendations.MedicalAttention,
+		ManagementTips:   recommendations.ManagementTips,
+		RecordDate:       timezoneUtils.ToJakarta(alert.RecordDate).Format("2006-01-02"),
+		RecordedAt:       timezoneUtils.ToJakarta(alert.RecordedAt),
+		State:            string(alert.State),
+		ReadAt:           toJakartaPtr(alert.ReadAt),
+		AcknowledgedAt:   toJakartaPtr(alert.AcknowledgedAt),
+		ResolvedAt:       toJakartaPtr(alert.ResolvedAt),
+		ResolutionNote:   alert.ResolutionNote,
+		CreatedAt:        timezoneUtils.ToJakarta(alert.CreatedAt),
+		UpdatedAt:        timezoneUtils.ToJakarta(alert.UpdatedAt),
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+	"strings"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// validAlertStates berisi state tindak lanjut alert yang dikenal.
+// Alur normal: unread -> read -> acknowledged -> resolved.
+var validAlertStates = map[entity.AlertState]bool{
+	entity.AlertStateUnread:       true,
+	entity.AlertStateRead:         true,
+	entity.AlertStateAcknowledged: true,
+	entity.AlertStateResolved:     true,
+}
+
+// isValidAlertState memeriksa apakah state alert dikenal
+func isValidAlertState(state entity.AlertState) bool {
+	return validAlertStates[state]
+}
+
+// resetHealthAlertState mengembalikan alert ke state unread dan menghapus jejak tindak lanjut sebelumnya
+func resetHealthAlertState(alert *entity.HealthAlert) {
+	alert.State = entity.AlertStateUnread
+	alert.ReadAt = nil
+	alert.AcknowledgedAt = nil
+	alert.ResolvedAt = nil
+	alert.ResolutionNote = nil
+}
+
+// toJakartaPtr mengonversi pointer waktu ke timezone Asia/Jakarta (nil tetap nil)
+func toJakartaPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	jakartaTime := timezoneUtils.ToJakarta(*t)
+	return &jakartaTime
+}
+
+// MarkHealthAlertRead menandai alert sebagai sudah dibaca
+// Alert yang sudah acknowledged/resolved tidak diturunkan state-nya
+func (s *HealthAlertService) MarkHealthAlertRead(userID, alertID uint) (*response.HealthAlertHistoryItem, error) {
+	alert, err := s.healthAlertRepo.GetHealthAlertByIDAndUserID(alertID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	if alert.ReadAt == nil {
+		alert.ReadAt = &now
+	}
+	if alert.State == entity.AlertStateUnread {
+		alert.State = entity.AlertStateRead
+	}
+
+	if err := s.healthAlertRepo.UpdateHealthAlert(alert); err != nil {
+		return nil, err
+	}
+
+	item := s.mapHealthAlertToHistoryItem(alert)
+	return &item, nil
+}
+
+// AcknowledgeHealthAlert menandai alert sebagai sudah dikonfirmasi oleh user
+func (s *HealthAlertService) AcknowledgeHealthAlert(userID, alertID uint) (*response.HealthAlertHistoryItem, error) {
+	alert, err := s.healthAlertRepo.GetHealthAlertByIDAndUserID(alertID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if alert.State == entity.AlertStateResolved {
+		return nil, errors.New("alert sudah diselesaikan")
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	if alert.ReadAt == nil {
+		alert.ReadAt = &now
+	}
+	if alert.AcknowledgedAt == nil {
+		alert.AcknowledgedAt = &now
+	}
+	alert.State = entity.AlertStateAcknowledged
+
+	if err := s.healthAlertRepo.UpdateHealthAlert(alert); err != nil {
+		return nil, err
+	}
+
+	item := s.mapHealthAlertToHistoryItem(alert)
+	return &item, nil
+}
+
+// ResolveHealthAlert menandai alert sebagai selesai dengan catatan opsional
+func (s *HealthAlertService) ResolveHealthAlert(userID, alertID uint, note *string) (*response.HealthAlertHistoryItem, error) {
+	alert, err := s.healthAlertRepo.GetHealthAlertByIDAndUserID(alertID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if alert.State == entity.AlertStateResolved {
+		return nil, errors.New("alert sudah diselesaikan")
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	if alert.ReadAt == nil {
+		alert.ReadAt = &now
+	}
+	alert.ResolvedAt = &now
+	alert.State = entity.AlertStateResolved
+
+	if note != nil {
+		trimmed := strings.TrimSpace(*note)
+		if trimmed != "" {
+			alert.ResolutionNote = &trimmed
+		}
+	}
+
+	if err := s.healthAlertRepo.UpdateHealthAlert(alert); err != nil {
+		return nil, err
+	}
+
+	item := s.mapHealthAlertToHistoryItem(alert)
+	return &item, nil
+}
+
+// MarkAllHealthAlertsRead menandai semua alert unread milik user sebagai sudah dibaca
+func (s *HealthAlertService) MarkAllHealthAlertsRead(userID uint) (*response.MarkAllHealthAlertsReadResponse, error) {
+	updated, err := s.healthAlertRepo.MarkAllHealthAlertsRead(userID, timezoneUtils.NowInJakarta())
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.MarkAllHealthAlertsReadResponse{
+		UpdatedCount: updated,
+	}, nil
+}
+
+// GetUnreadHealthAlertCount mengambil jumlah alert yang belum dibaca (untuk badge)
+func (s *HealthAlertService) GetUnreadHealthAlertCount(userID uint) (*response.HealthAlertUnreadCountResponse, error) {
+	count, err := s.healthAlertRepo.CountUnreadHealthAlerts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.HealthAlertUnreadCountResponse{
+		UnreadCount: count,
+	}, nil
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
 	"BE-PeriksaKesehatan/internal/repository"
 	"fmt"
+	"strings"
 	"time"
 
 	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
@@ -15,9 +17,12 @@ const (
 	CategoryHipertensi = "hipertensi"
 	CategoryJantung    = "jantung"
 	CategoryBeratBadan = "berat_badan"
+	CategoryPernapasan = "pernapasan"
+	CategorySuhuTubuh  = "suhu_tubuh"
 )
 
 // Mapping kategori alert ke category_id untuk video edukasi
+// Kategori pernapasan dan suhu tubuh tidak memiliki ID tetap; lihat entity.CategoryNamePernapasan dan entity.CategoryNameSuhuTubuh
 const (
 	CategoryIDDiabetes   = uint(1)
 	CategoryIDHipertensi = uint(2)
@@ -26,10 +31,12 @@ const (
 )
 
 type HealthAlertService struct {
-	healthAlertRepo      *repository.HealthAlertRepository
-	healthDataRepo       *repository.HealthDataRepository
-	educationalVideoRepo *repository.EducationalVideoRepository
-	categoryRepo         *repository.CategoryRepository
+	healthAlertRepo          *repository.HealthAlertRepository
+	healthDataRepo           *repository.HealthDataRepository
+	educationalVideoRepo     *repository.EducationalVideoRepository
+	categoryRepo             *repository.CategoryRepository
+	clinicalThresholdService *ClinicalThresholdService
+	notificationService      *NotificationService
 }
 
 func NewHealthAlertService(
@@ -37,19 +44,23 @@ func NewHealthAlertService(
 	healthDataRepo *repository.HealthDataRepository,
 	educationalVideoRepo *repository.EducationalVideoRepository,
 	categoryRepo *repository.CategoryRepository,
+	clinicalThresholdService *ClinicalThresholdService,
+	notificationService *NotificationService,
 ) *HealthAlertService {
 	return &HealthAlertService{
-		healthAlertRepo:      healthAlertRepo,
-		healthDataRepo:       healthDataRepo,
-		educationalVideoRepo: educationalVideoRepo,
-		categoryRepo:         categoryRepo,
+		healthAlertRepo:          healthAlertRepo,
+		healthDataRepo:           healthDataRepo,
+		educationalVideoRepo:     educationalVideoRepo,
+		categoryRepo:             categoryRepo,
+		clinicalThresholdService: clinicalThresholdService,
+		notificationService:      notificationService,
 	}
 }
 
 // CheckHealthAlerts mengambil data kesehatan terbaru dari database dan mengevaluasi alerts
 func (s *HealthAlertService) CheckHealthAlerts(userID uint) (*response.CheckHealthAlertsResponse, error) {
 	// Ambil data kesehatan terbaru dari database
-	latestHealthData, err := s.healthDataRepo.GetLatestHealthDataByUserID(userID)
+	latestHealthData, err := s.healthDataRepo.GetLatestHealthSnapshotByUserID(userID)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil data kesehatan: %w", err)
 	}
@@ -61,68 +72,107 @@ func (s *HealthAlertService) CheckHealthAlerts(userID uint) (*response.CheckHeal
 		}, nil
 	}
 
+	classifier, err := s.clinicalThresholdService.GetClassifier(userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil batas klinis: %w", err)
+	}
+
+	// Evaluasi semua kategori dari data kesehatan terbaru
+	alerts := s.evaluateHealthData(classifier, latestHealthData)
+
+	// Batch query education videos untuk semua kategori yang memerlukan (status RENDAH atau TINGGI)
+	// Kumpulkan kategori unik terlebih dahulu
+	categorySet := make(map[string]bool)
+	for i := range alerts {
+		if alerts[i].Status == StatusRendah || alerts[i].Status == StatusTinggi {
+			categorySet[alerts[i].Category] = true
+		}
+	}
+
+	// Batch query semua video untuk kategori yang diperlukan
+	videosByCategory := s.getEducationVideosByCategories(categorySet)
+
+	// Isi education_videos untuk setiap alert
+	for i := range alerts {
+		if alerts[i].Status == StatusRendah || alerts[i].Status == StatusTinggi {
+			alerts[i].EducationVideos = videosByCategory[alerts[i].Category]
+		}
+	}
+
+	return &response.CheckHealthAlertsResponse{
+		Alerts: alerts,
+	}, nil
+}
+
+// evaluateHealthData mengevaluasi semua kategori (hipertensi, diabetes, jantung, berat badan, pernapasan, suhu tubuh)
+// dari satu record data kesehatan dan mengembalikan alert untuk nilai yang tidak normal
+// berdasarkan batas klinis milik pemilik data (classifier)
+func (s *HealthAlertService) evaluateHealthData(classifier *HealthClassifier, healthData *entity.HealthData) []response.HealthAlertResponse {
 	// Inisialisasi slice agar tidak bernilai nil saat tidak ada alert
 	alerts := make([]response.HealthAlertResponse, 0)
 
 	// Evaluasi kategori hipertensi
-	if latestHealthData.Systolic != nil && latestHealthData.Diastolic != nil {
-		alert := s.evaluateBloodPressure(*latestHealthData.Systolic, *latestHealthData.Diastolic, latestHealthData.CreatedAt)
+	if healthData.Systolic != nil && healthData.Diastolic != nil {
+		alert := s.evaluateBloodPressure(classifier, *healthData.Systolic, *healthData.Diastolic, healthData.MeasuredAt)
 		if alert != nil {
 			alerts = append(alerts, *alert)
 		}
 	}
 
 	// Evaluasi kategori diabetes
-	if latestHealthData.BloodSugar != nil {
-		alert := s.evaluateBloodSugar(*latestHealthData.BloodSugar, latestHealthData.CreatedAt)
+	if healthData.BloodSugar != nil {
+		context := entity.ResolveBloodSugarContext(healthData.BloodSugarContext)
+		alert := s.evaluateBloodSugar(classifier, *healthData.BloodSugar, context, healthData.MeasuredAt)
 		if alert != nil {
 			alerts = append(alerts, *alert)
 		}
 	}
 
 	// Evaluasi kategori jantung
-	if latestHealthData.HeartRate != nil {
-		alert := s.evaluateHeartRate(*latestHealthData.HeartRate, latestHealthData.CreatedAt)
+	if healthData.HeartRate != nil {
+		alert := s.evaluateHeartRate(classifier, *healthData.HeartRate, healthData.MeasuredAt)
 		if alert != nil {
 			alerts = append(alerts, *alert)
 		}
 	}
 
 	// Evaluasi kategori berat badan berbasis BMI
-	if latestHealthData.Weight != nil && latestHealthData.HeightCM != nil {
-		alert := s.evaluateBMI(*latestHealthData.Weight, *latestHealthData.HeightCM, latestHealthData.CreatedAt)
+	if healthData.Weight != nil && healthData.HeightCM != nil {
+		alert := s.evaluateBMI(classifier, *healthData.Weight, *healthData.HeightCM, healthData.MeasuredAt)
 		if alert != nil {
 			alerts = append(alerts, *alert)
 		}
 	}
 
-	// Batch query education videos untuk semua kategori yang memerlukan (status RENDAH atau TINGGI)
-	// Kumpulkan kategori unik terlebih dahulu
-	categorySet := make(map[string]bool)
-	for i := range alerts {
-		if alerts[i].Status == StatusRendah || alerts[i].Status == StatusTinggi {
-			categorySet[alerts[i].Category] = true
+	// Evaluasi kategori pernapasan (saturasi oksigen dan laju napas)
+	if healthData.OxygenSaturation != nil {
+		alert := s.evaluateOxygenSaturation(classifier, *healthData.OxygenSaturation, healthData.MeasuredAt)
+		if alert != nil {
+			alerts = append(alerts, *alert)
 		}
 	}
 
-	// Batch query semua video untuk kategori yang diperlukan
-	videosByCategory := s.getEducationVideosByCategories(categorySet)
+	if healthData.RespiratoryRate != nil {
+		alert := s.evaluateRespiratoryRate(classifier, *healthData.RespiratoryRate, healthData.MeasuredAt)
+		if alert != nil {
+			alerts = append(alerts, *alert)
+		}
+	}
 
-	// Isi education_videos untuk setiap alert
-	for i := range alerts {
-		if alerts[i].Status == StatusRendah || alerts[i].Status == StatusTinggi {
-			alerts[i].EducationVideos = videosByCategory[alerts[i].Category]
+	// Evaluasi kategori suhu tubuh
+	if healthData.Temperature != nil {
+		alert := s.evaluateTemperature(classifier, *healthData.Temperature, healthData.MeasuredAt)
+		if alert != nil {
+			alerts = append(alerts, *alert)
 		}
 	}
 
-	return &response.CheckHealthAlertsResponse{
-		Alerts: alerts,
-	}, nil
+	return alerts
 }
 
 // evaluateBloodPressure mengevaluasi tekanan darah dan mengembalikan alert jika tidak normal
-func (s *HealthAlertService) evaluateBloodPressure(systolic, diastolic int, recordedAt time.Time) *response.HealthAlertResponse {
-	status := getBloodPressureStatusValue(systolic, diastolic)
+func (s *HealthAlertService) evaluateBloodPressure(classifier *HealthClassifier, systolic, diastolic int, recordedAt time.Time) *response.HealthAlertResponse {
+	status := classifier.BloodPressureStatus(systolic, diastolic)
 	if status == StatusNormal {
 		return nil
 	}
@@ -131,7 +181,7 @@ func (s *HealthAlertService) evaluateBloodPressure(systolic, diastolic int, reco
 	var alertType, label, explanation string
 	var immediateActions, medicalAttention, managementTips []string
 
-	if systolic >= 140 || diastolic >= 90 {
+	if status == StatusTinggi {
 		// Hipertensi
 		alertType = "Tekanan Darah Tinggi"
 		label = "Hipertensi"
@@ -152,7 +202,7 @@ func (s *HealthAlertService) evaluateBloodPressure(systolic, diastolic int, reco
 			"Pertahankan berat badan ideal",
 			"Hindari merokok dan alkohol",
 		}
-	} else if systolic < 90 || diastolic < 60 {
+	} else if status == StatusRendah {
 		// Hipotensi
 		alertType = "Tekanan Darah Rendah"
 		label = "Hipotensi"
@@ -192,27 +242,31 @@ func (s *HealthAlertService) evaluateBloodPressure(systolic, diastolic int, reco
 	}
 }
 
-// evaluateBloodSugar mengevaluasi gula darah dan mengembalikan alert jika tidak normal
-func (s *HealthAlertService) evaluateBloodSugar(bloodSugar int, recordedAt time.Time) *response.HealthAlertResponse {
-	status := getBloodSugarStatusValue(bloodSugar)
+// evaluateBloodSugar mengevaluasi gula darah sesuai konteks pengukurannya dan mengembalikan alert jika tidak normal
+func (s *HealthAlertService) evaluateBloodSugar(classifier *HealthClassifier, bloodSugar int, context entity.BloodSugarContext, recordedAt time.Time) *response.HealthAlertResponse {
+	status := classifier.BloodSugarStatus(bloodSugar, context)
 	if status == StatusNormal {
 		return nil
 	}
 
-	value := fmt.Sprintf("%d mg/dL", bloodSugar)
+	contextLabel := strings.ToLower(context.Label())
+	value := fmt.Sprintf("%d mg/dL (%s)", bloodSugar, contextLabel)
 	var alertType, label, explanation string
 	var immediateActions, medicalAttention, managementTips []string
 
-	if bloodSugar < 70 {
+	if status == StatusRendah {
 		// Hipoglikemia (RENDAH)
 		alertType = "Gula Darah Rendah"
-		label = "Gula Darah Rendah"
-		explanation = "Gula darah Anda berada di bawah batas normal (WHO: < 70 mg/dL). Kondisi ini memerlukan perhatian segera karena dapat menyebabkan pingsan, kejang, atau koma."
+		label = fmt.Sprintf("Gula Darah %s Rendah", context.Label())
+		explanation = fmt.Sprintf("Gula darah Anda berada di bawah batas normal (%s: < %d mg/dL untuk gula darah %s). Kondisi ini memerlukan perhatian segera karena dapat menyebabkan pingsan, kejang, atau koma.", classifier.BloodSugarSource(context), classifier.BloodSugarMin(context), contextLabel)
 		immediateActions = []string{
 			"Segera konsumsi 15-20 gram gula sederhana (permen, jus buah, atau tablet glukosa)",
 			"Tunggu 15 menit dan periksa kembali gula darah",
 			"Jika masih rendah, ulangi konsumsi gula",
 		}
+		if context == entity.BloodSugarContextBedtime {
+			immediateActions = append(immediateActions, "Jangan tidur sebelum gula darah kembali normal, lalu konsumsi camilan ringan sebelum tidur")
+		}
 		medicalAttention = []string{
 			"Jika tidak sadar atau tidak bisa menelan",
 			"Jika gula darah tidak naik setelah 2 kali konsumsi gula",
@@ -224,11 +278,11 @@ func (s *HealthAlertService) evaluateBloodSugar(bloodSugar int, recordedAt time.
 			"Monitor gula darah secara rutin",
 			"Konsultasi dengan dokter untuk penyesuaian obat",
 		}
-	} else if bloodSugar > 140 {
-		// Hiperglikemia (TINGGI) - sesuai standar WHO untuk gula darah sewaktu
+	} else if status == StatusTinggi {
+		// Hiperglikemia (TINGGI) - gula darah di atas batas normal untuk konteks pengukurannya
 		alertType = "Gula Darah Tinggi"
-		label = "Gula Darah Tinggi"
-		explanation = "Gula darah Anda berada di atas batas normal (WHO: > 140 mg/dL untuk gula darah sewaktu). Jika berlangsung lama, dapat meningkatkan risiko komplikasi kesehatan."
+		label = fmt.Sprintf("Gula Darah %s Tinggi", context.Label())
+		explanation = fmt.Sprintf("Gula darah Anda berada di atas batas normal (%s: > %d mg/dL untuk gula darah %s). Jika berlangsung lama, dapat meningkatkan risiko komplikasi kesehatan.", classifier.BloodSugarSource(context), classifier.BloodSugarMax(context), contextLabel)
 		immediateActions = []string{
 			"Hindari makanan dan minuman manis",
 			"Lakukan aktivitas fisik ringan jika memungkinkan",
@@ -239,6 +293,14 @@ func (s *HealthAlertService) evaluateBloodSugar(bloodSugar int, recordedAt time.
 			"Jika disertai gejala seperti sering haus, sering buang air kecil, atau lemas",
 			"Konsultasi dengan dokter untuk evaluasi",
 		}
+		switch context {
+		case entity.BloodSugarContextFasting:
+			medicalAttention = append(medicalAttention, "Gula darah puasa yang tinggi berulang dapat menjadi tanda diabetes, diskusikan pemeriksaan HbA1c dengan dokter")
+		case entity.BloodSugarContextPostMeal:
+			immediateActions = append(immediateActions, "Perhatikan porsi karbohidrat pada makanan terakhir Anda")
+		case entity.BloodSugarContextBedtime:
+			immediateActions = append(immediateActions, "Hindari camilan manis di malam hari")
+		}
 		managementTips = []string{
 			"Batasi konsumsi karbohidrat dan gula",
 			"Pilih karbohidrat kompleks (nasi merah, roti gandum)",
@@ -266,8 +328,8 @@ func (s *HealthAlertService) evaluateBloodSugar(bloodSugar int, recordedAt time.
 }
 
 // evaluateHeartRate mengevaluasi detak jantung dan mengembalikan alert jika tidak normal
-func (s *HealthAlertService) evaluateHeartRate(heartRate int, recordedAt time.Time) *response.HealthAlertResponse {
-	status := getHeartRateStatusValue(heartRate)
+func (s *HealthAlertService) evaluateHeartRate(classifier *HealthClassifier, heartRate int, recordedAt time.Time) *response.HealthAlertResponse {
+	status := classifier.HeartRateStatus(heartRate)
 	if status == StatusNormal {
 		return nil
 	}
@@ -276,7 +338,7 @@ func (s *HealthAlertService) evaluateHeartRate(heartRate int, recordedAt time.Ti
 	var alertType, label, explanation string
 	var immediateActions, medicalAttention, managementTips []string
 
-	if heartRate < 60 {
+	if status == StatusRendah {
 		// Bradikardia
 		alertType = "Detak Jantung Lambat"
 		label = "Bradikardia"
@@ -298,7 +360,7 @@ func (s *HealthAlertService) evaluateHeartRate(heartRate int, recordedAt time.Ti
 			"Olahraga ringan secara teratur",
 			"Monitor detak jantung secara rutin",
 		}
-	} else if heartRate > 100 {
+	} else if status == StatusTinggi {
 		// Takikardia
 		alertType = "Detak Jantung Cepat"
 		label = "Takikardia"
@@ -341,7 +403,7 @@ func (s *HealthAlertService) evaluateHeartRate(heartRate int, recordedAt time.Ti
 }
 
 // evaluateBMI mengevaluasi status BMI dan mengembalikan alert jika tidak normal
-func (s *HealthAlertService) evaluateBMI(weightKg float64, heightCM int, recordedAt time.Time) *response.HealthAlertResponse {
+func (s *HealthAlertService) evaluateBMI(classifier *HealthClassifier, weightKg float64, heightCM int, recordedAt time.Time) *response.HealthAlertResponse {
 	if weightKg <= 0 || heightCM <= 0 {
 		return nil
 	}
@@ -352,7 +414,7 @@ func (s *HealthAlertService) evaluateBMI(weightKg float64, heightCM int, recorde
 	}
 
 	bmiRounded := roundTo2Decimals(bmiValue)
-	status := getBMIStatusValue(bmiRounded)
+	status := classifier.BMIStatus(bmiRounded)
 	if status == StatusNormal {
 		return nil
 	}
@@ -418,7 +480,206 @@ func (s *HealthAlertService) evaluateBMI(weightKg float64, heightCM int, recorde
 	}
 }
 
+// evaluateOxygenSaturation mengevaluasi saturasi oksigen (SpO2) dan mengembalikan alert jika di bawah normal
+func (s *HealthAlertService) evaluateOxygenSaturation(classifier *HealthClassifier, oxygenSaturation int, recordedAt time.Time) *response.HealthAlertResponse {
+	status := classifier.OxygenSaturationStatus(oxygenSaturation)
+	if status != StatusRendah {
+		return nil
+	}
+
+	// Hipoksemia
+	immediateActions := []string{
+		"Duduk tegak dan bernapas perlahan dan dalam",
+		"Pastikan jari tidak dingin dan oximeter terpasang dengan benar, lalu ukur ulang",
+		"Hindari aktivitas fisik sampai saturasi oksigen kembali normal",
+	}
+	medicalAttention := []string{
+		"Jika saturasi oksigen tetap rendah setelah pengukuran ulang",
+		"Jika disertai sesak napas, nyeri dada, atau bibir dan ujung jari kebiruan",
+		"Jika disertai kebingungan atau penurunan kesadaran",
+	}
+	if classifier.IsOxygenSaturationCritical(oxygenSaturation) {
+		emergency := fmt.Sprintf("Segera ke unit gawat darurat karena saturasi oksigen di bawah %d%%", classifier.OxygenSaturationCritical())
+		medicalAttention = append([]string{emergency}, medicalAttention...)
+	}
+
+	return &response.HealthAlertResponse{
+		AlertType:        "Saturasi Oksigen Rendah",
+		Category:         CategoryPernapasan,
+		Value:            fmt.Sprintf("SpO2 %d%%", oxygenSaturation),
+		Label:            "Hipoksemia",
+		Status:           status,
+		RecordedAt:       timezoneUtils.ToJakarta(recordedAt),
+		Explanation:      fmt.Sprintf("Saturasi oksigen darah Anda berada di bawah batas normal (< %d%%). Kondisi ini menandakan tubuh tidak mendapatkan cukup oksigen dan dapat membahayakan organ vital.", classifier.OxygenSaturationMin()),
+		ImmediateActions: immediateActions,
+		MedicalAttention: medicalAttention,
+		ManagementTips: []string{
+			"Hindari merokok dan paparan asap rokok",
+			"Lakukan latihan pernapasan secara teratur",
+			"Pastikan ruangan memiliki sirkulasi udara yang baik",
+			"Monitor saturasi oksigen secara rutin, terutama saat sedang sakit",
+		},
+		EducationVideos: []response.EducationVideoItem{}, // Akan diisi di CheckHealthAlerts
+	}
+}
+
+// evaluateRespiratoryRate mengevaluasi laju napas dan mengembalikan alert jika tidak normal
+func (s *HealthAlertService) evaluateRespiratoryRate(classifier *HealthClassifier, respiratoryRate int, recordedAt time.Time) *response.HealthAlertResponse {
+	status := classifier.RespiratoryRateStatus(respiratoryRate)
+	if status == StatusNormal {
+		return nil
+	}
+
+	value := fmt.Sprintf("%d napas/menit", respiratoryRate)
+	var alertType, label, explanation string
+	var immediateActions, medicalAttention, managementTips []string
+
+	if status == StatusRendah {
+		// Bradipnea
+		alertType = "Laju Napas Lambat"
+		label = "Bradipnea"
+		explanation = "Laju napas Anda berada di bawah batas normal. Napas yang terlalu lambat dapat menyebabkan tubuh kekurangan oksigen, misalnya akibat efek obat penenang atau kelelahan berat."
+		immediateActions = []string{
+			"Pastikan Anda dalam keadaan sadar dan dapat bernapas dengan lega",
+			"Hitung ulang laju napas selama 1 menit penuh dalam keadaan istirahat",
+			"Minta orang terdekat untuk menemani dan memantau kondisi Anda",
+		}
+		medicalAttention = []string{
+			"Jika disertai rasa sangat mengantuk, kebingungan, atau sulit dibangunkan",
+			"Jika terjadi setelah mengonsumsi obat penenang atau obat nyeri",
+			"Jika disertai bibir atau ujung jari kebiruan",
+		}
+		managementTips = []string{
+			"Konsumsi obat sesuai dosis yang diresepkan dokter",
+			"Hindari alkohol, terutama bersamaan dengan obat penenang",
+			"Monitor laju napas dan saturasi oksigen secara rutin",
+		}
+	} else if status == StatusTinggi {
+		// Takipnea
+		alertType = "Laju Napas Cepat"
+		label = "Takipnea"
+		explanation = "Laju napas Anda berada di atas batas normal. Napas cepat dapat menjadi tanda infeksi paru, demam, kecemasan, atau gangguan jantung dan paru."
+		immediateActions = []string{
+			"Duduk tegak dan beristirahat",
+			"Lakukan pernapasan perlahan melalui hidung dan hembuskan melalui mulut",
+			"Ukur saturasi oksigen dan suhu tubuh jika memungkinkan",
+		}
+		medicalAttention = []string{
+			"Jika disertai sesak napas, nyeri dada, atau saturasi oksigen rendah",
+			"Jika disertai demam tinggi atau batuk berdahak",
+			"Jika laju napas tidak kembali normal setelah istirahat",
+		}
+		managementTips = []string{
+			"Kelola stres dan kecemasan dengan teknik relaksasi",
+			"Hindari merokok dan polusi udara",
+			"Olahraga teratur untuk menjaga kapasitas paru",
+			"Monitor laju napas secara rutin",
+		}
+	} else {
+		return nil
+	}
+
+	return &response.HealthAlertResponse{
+		AlertType:        alertType,
+		Category:         CategoryPernapasan,
+		Value:            value,
+		Label:            label,
+		Status:           status,
+		RecordedAt:       timezoneUtils.ToJakarta(recordedAt),
+		Explanation:      explanation,
+		ImmediateActions: immediateActions,
+		MedicalAttention: medicalAttention,
+		ManagementTips:   managementTips,
+		EducationVideos:  []response.EducationVideoItem{}, // Akan diisi di CheckHealthAlerts
+	}
+}
+
+// evaluateTemperature mengevaluasi suhu tubuh dan mengembalikan alert jika tidak normal
+func (s *HealthAlertService) evaluateTemperature(classifier *HealthClassifier, temperature float64, recordedAt time.Time) *response.HealthAlertResponse {
+	status := classifier.TemperatureStatus(temperature)
+	if status == StatusNormal {
+		return nil
+	}
+
+	value := fmt.Sprintf("%.1f °C", temperature)
+	var alertType, label, explanation string
+	var immediateActions, medicalAttention, managementTips []string
+
+	if status == StatusTinggi {
+		// Demam
+		alertType = "Suhu Tubuh Tinggi"
+		label = "Demam"
+		explanation = fmt.Sprintf("Suhu tubuh Anda berada di atas batas normal (> %.1f °C). Demam umumnya merupakan respons tubuh terhadap infeksi.", classifier.TemperatureMax())
+		immediateActions = []string{
+			"Istirahat yang cukup",
+			"Minum air putih lebih banyak untuk mencegah dehidrasi",
+			"Kenakan pakaian tipis dan kompres dengan air hangat",
+			"Konsumsi obat penurun panas sesuai aturan pakai jika diperlukan",
+		}
+		medicalAttention = []string{
+			"Jika demam berlangsung lebih dari 3 hari",
+			"Jika disertai sesak napas, kejang, ruam, atau penurunan kesadaran",
+			"Jika disertai nyeri kepala hebat atau kaku leher",
+		}
+		if classifier.IsTemperatureCritical(temperature) {
+			urgent := fmt.Sprintf("Segera konsultasi dengan dokter karena suhu tubuh %.1f °C atau lebih", classifier.TemperatureCritical())
+			medicalAttention = append([]string{urgent}, medicalAttention...)
+		}
+		managementTips = []string{
+			"Ukur suhu tubuh secara berkala hingga kembali normal",
+			"Konsumsi makanan bergizi dan mudah dicerna",
+			"Cuci tangan secara teratur untuk mencegah penularan infeksi",
+		}
+	} else if status == StatusRendah {
+		// Hipotermia
+		alertType = "Suhu Tubuh Rendah"
+		label = "Hipotermia"
+		explanation = fmt.Sprintf("Suhu tubuh Anda berada di bawah batas normal (< %.1f °C). Suhu tubuh yang terlalu rendah dapat mengganggu fungsi jantung dan sistem saraf.", classifier.TemperatureMin())
+		immediateActions = []string{
+			"Pindah ke ruangan yang hangat dan kering",
+			"Ganti pakaian basah dan gunakan selimut",
+			"Minum minuman hangat (bukan alkohol atau kafein)",
+		}
+		medicalAttention = []string{
+			"Jika disertai menggigil hebat, kebingungan, atau bicara tidak jelas",
+			"Jika suhu tubuh tidak naik setelah dihangatkan",
+			"Jika terjadi pada lansia atau disertai penyakit kronis",
+		}
+		managementTips = []string{
+			"Kenakan pakaian yang sesuai dengan cuaca",
+			"Jaga asupan makanan dan cairan yang cukup",
+			"Pastikan termometer digunakan dengan benar saat mengukur suhu",
+		}
+	} else {
+		return nil
+	}
+
+	return &response.HealthAlertResponse{
+		AlertType:        alertType,
+		Category:         CategorySuhuTubuh,
+		Value:            value,
+		Label:            label,
+		Status:           status,
+		RecordedAt:       timezoneUtils.ToJakarta(recordedAt),
+		Explanation:      explanation,
+		ImmediateActions: immediateActions,
+		MedicalAttention: medicalAttention,
+		ManagementTips:   managementTips,
+		EducationVideos:  []response.EducationVideoItem{}, // Akan diisi di CheckHealthAlerts
+	}
+}
+
+// isKnownAlertCategory memeriksa apakah kategori alert dikenal
+func isKnownAlertCategory(category string) bool {
+	switch category {
+	case CategoryDiabetes, CategoryHipertensi, CategoryJantung, CategoryBeratBadan, CategoryPernapasan, CategorySuhuTubuh:
+		return true
+	}
+	return false
+}
+
 // getCategoryIDByCategory mengembalikan category_id berdasarkan kategori alert
+// Kategori tanpa ID tetap dicari berdasarkan nama; mengembalikan false jika kategorinya belum ada
 func (s *HealthAlertService) getCategoryIDByCategory(category string) (uint, bool) {
 	switch category {
 	case CategoryDiabetes:
@@ -429,11 +690,24 @@ func (s *HealthAlertService) getCategoryIDByCategory(category string) (uint, boo
 		return CategoryIDJantung, true
 	case CategoryBeratBadan:
 		return CategoryIDBeratBadan, true
+	case CategoryPernapasan:
+		return s.getCategoryIDByName(entity.CategoryNamePernapasan)
+	case CategorySuhuTubuh:
+		return s.getCategoryIDByName(entity.CategoryNameSuhuTubuh)
 	default:
 		return 0, false
 	}
 }
 
+// getCategoryIDByName mengambil ID kategori video berdasarkan nama kategori default
+func (s *HealthAlertService) getCategoryIDByName(name string) (uint, bool) {
+	category, err := s.categoryRepo.GetCategoryByKategori(name)
+	if err != nil {
+		return 0, false
+	}
+	return category.ID, true
+}
+
 // getEducationVideosByCategories mengambil video edukasi untuk multiple kategori sekaligus (batch query)
 // Mengembalikan map category -> []EducationVideoItem untuk menghindari N+1 query
 func (s *HealthAlertService) getEducationVideosByCategories(categorySet map[string]bool) map[string][]response.EducationVideoItem {
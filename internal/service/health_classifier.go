@@ -0,0 +1,439 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"fmt"
+)
+
+// HealthClassifier mengklasifikasikan nilai pengukuran menjadi RENDAH/NORMAL/TINGGI
+// berdasarkan batas klinis yang berlaku untuk satu user (default global yang sudah
+// digabung dengan penyesuaian per pasien). Dipakai oleh status pembacaan, summary,
+// laporan, dashboard clinician, dan evaluasi alert agar klasifikasinya selalu sama.
+type HealthClassifier struct {
+	systolicMin  int
+	systolicMax  int
+	diastolicMin int
+	diastolicMax int
+	heartRateMin int
+	heartRateMax int
+	bmiMin       float64
+	bmiMax       float64
+
+	// Tanda vital tambahan
+	oxygenSaturationMin      int
+	oxygenSaturationCritical int
+	temperatureMin           float64
+	temperatureMax           float64
+	temperatureCritical      float64
+	respiratoryRateMin       int
+	respiratoryRateMax       int
+
+	// bloodSugar berisi rentang normal gula darah per konteks pengukuran
+	bloodSugar map[entity.BloodSugarContext]bloodSugarRange
+
+	// labReferences berisi rentang rujukan hasil laboratorium per jenis pemeriksaan
+	labReferences map[entity.LabTestType]labReferenceRange
+
+	// customized bernilai true jika ada batas yang berbeda dari standar WHO
+	customized bool
+}
+
+// bloodSugarRange adalah rentang normal gula darah untuk satu konteks pengukuran
+type bloodSugarRange struct {
+	min int
+	max int
+}
+
+// labReferenceRange adalah rentang rujukan satu jenis pemeriksaan lab; batas nil berarti tidak ada batas di sisi itu
+type labReferenceRange struct {
+	min *float64
+	max *float64
+}
+
+// NewHealthClassifier membuat classifier dari batas default dan penyesuaian user (boleh nil).
+// Field yang kosong pada keduanya diisi dengan standar WHO.
+func NewHealthClassifier(defaults, override *entity.ClinicalThreshold) *HealthClassifier {
+	who := entity.DefaultClinicalThreshold()
+	layers := []*entity.ClinicalThreshold{&who, defaults, override}
+
+	c := &HealthClassifier{}
+	var random, fasting, postMeal, bedtime bloodSugarRange
+	for _, layer := range layers {
+		if layer == nil {
+			continue
+		}
+		applyIntThreshold(&c.systolicMin, layer.SystolicMin)
+		applyIntThreshold(&c.systolicMax, layer.SystolicMax)
+		applyIntThreshold(&c.diastolicMin, layer.DiastolicMin)
+		applyIntThreshold(&c.diastolicMax, layer.DiastolicMax)
+		applyIntThreshold(&random.min, layer.BloodSugarMin)
+		applyIntThreshold(&random.max, layer.BloodSugarMax)
+		applyIntThreshold(&fasting.min, layer.BloodSugarFastingMin)
+		applyIntThreshold(&fasting.max, layer.BloodSugarFastingMax)
+		applyIntThreshold(&postMeal.min, layer.BloodSugarPostMealMin)
+		applyIntThreshold(&postMeal.max, layer.BloodSugarPostMealMax)
+		applyIntThreshold(&bedtime.min, layer.BloodSugarBedtimeMin)
+		applyIntThreshold(&bedtime.max, layer.BloodSugarBedtimeMax)
+		applyIntThreshold(&c.heartRateMin, layer.HeartRateMin)
+		applyIntThreshold(&c.heartRateMax, layer.HeartRateMax)
+		if layer.BMIMin != nil {
+			c.bmiMin = *layer.BMIMin
+		}
+		if layer.BMIMax != nil {
+			c.bmiMax = *layer.BMIMax
+		}
+		applyIntThreshold(&c.oxygenSaturationMin, layer.OxygenSaturationMin)
+		applyIntThreshold(&c.oxygenSaturationCritical, layer.OxygenSaturationCritical)
+		if layer.TemperatureMin != nil {
+			c.temperatureMin = *layer.TemperatureMin
+		}
+		if layer.TemperatureMax != nil {
+			c.temperatureMax = *layer.TemperatureMax
+		}
+		if layer.TemperatureCritical != nil {
+			c.temperatureCritical = *layer.TemperatureCritical
+		}
+		applyIntThreshold(&c.respiratoryRateMin, layer.RespiratoryRateMin)
+		applyIntThreshold(&c.respiratoryRateMax, layer.RespiratoryRateMax)
+	}
+	c.bloodSugar = map[entity.BloodSugarContext]bloodSugarRange{
+		entity.BloodSugarContextRandom:   random,
+		entity.BloodSugarContextFasting:  fasting,
+		entity.BloodSugarContextPostMeal: postMeal,
+		entity.BloodSugarContextBedtime:  bedtime,
+	}
+	c.labReferences = mergeLabReferences(layers)
+
+	c.customized = !c.isBloodPressureWHO() || !c.isAllBloodSugarWHO() ||
+		c.heartRateMin != *who.HeartRateMin || c.heartRateMax != *who.HeartRateMax ||
+		c.bmiMin != *who.BMIMin || c.bmiMax != *who.BMIMax ||
+		!c.isOxygenSaturationWHO() || !c.isTemperatureWHO() || !c.isRespiratoryRateWHO() ||
+		!c.isAllLabReferencesDefault()
+
+	return c
+}
+
+// applyIntThreshold mengganti nilai batas jika sumbernya terisi
+func applyIntThreshold(target *int, value *int) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+// mergeLabReferences menggabungkan rentang rujukan lab dari setiap lapisan batas klinis (lapisan terakhir menang)
+func mergeLabReferences(layers []*entity.ClinicalThreshold) map[entity.LabTestType]labReferenceRange {
+	var hba1c, totalCholesterol, ldl, hdl, triglycerides, uricAcid, creatinine labReferenceRange
+	for _, layer := range layers {
+		if layer == nil {
+			continue
+		}
+		hba1c.apply(layer.HbA1cMin, layer.HbA1cMax)
+		totalCholesterol.apply(layer.TotalCholesterolMin, layer.TotalCholesterolMax)
+		ldl.apply(layer.LDLMin, layer.LDLMax)
+		hdl.apply(layer.HDLMin, layer.HDLMax)
+		triglycerides.apply(layer.TriglyceridesMin, layer.TriglyceridesMax)
+		uricAcid.apply(layer.UricAcidMin, layer.UricAcidMax)
+		creatinine.apply(layer.CreatinineMin, layer.CreatinineMax)
+	}
+	return map[entity.LabTestType]labReferenceRange{
+		entity.LabTestHbA1c:            hba1c,
+		entity.LabTestTotalCholesterol: totalCholesterol,
+		entity.LabTestLDL:              ldl,
+		entity.LabTestHDL:              hdl,
+		entity.LabTestTriglycerides:    triglycerides,
+		entity.LabTestUricAcid:         uricAcid,
+		entity.LabTestCreatinine:       creatinine,
+	}
+}
+
+// apply mengganti batas rujukan lab yang terisi pada satu lapisan
+func (r *labReferenceRange) apply(min, max *float64) {
+	if min != nil {
+		value := *min
+		r.min = &value
+	}
+	if max != nil {
+		value := *max
+		r.max = &value
+	}
+}
+
+// BloodPressureStatus menentukan status tekanan darah berdasarkan kombinasi sistolik dan diastolik
+// RENDAH jika sistolik atau diastolik di bawah batas minimum
+// TINGGI jika sistolik atau diastolik di atas batas maksimum
+// NORMAL jika keduanya berada dalam rentang normal
+func (c *HealthClassifier) BloodPressureStatus(systolic, diastolic int) string {
+	if systolic < c.systolicMin || diastolic < c.diastolicMin {
+		return StatusRendah
+	}
+	if systolic > c.systolicMax || diastolic > c.diastolicMax {
+		return StatusTinggi
+	}
+	return StatusNormal
+}
+
+// BloodSugarStatus menentukan status gula darah sesuai konteks pengukurannya
+// Konteks kosong dianggap sebagai gula darah sewaktu
+func (c *HealthClassifier) BloodSugarStatus(bloodSugar int, context entity.BloodSugarContext) string {
+	r := c.bloodSugarRange(context)
+	if bloodSugar < r.min {
+		return StatusRendah
+	}
+	if bloodSugar > r.max {
+		return StatusTinggi
+	}
+	return StatusNormal
+}
+
+// HeartRateStatus menentukan status detak jantung
+func (c *HealthClassifier) HeartRateStatus(heartRate int) string {
+	if heartRate < c.heartRateMin {
+		return StatusRendah
+	}
+	if heartRate > c.heartRateMax {
+		return StatusTinggi
+	}
+	return StatusNormal
+}
+
+// BMIStatus menentukan status berdasarkan BMI
+// RENDAH jika BMI < min, TINGGI jika BMI ≥ max
+func (c *HealthClassifier) BMIStatus(bmi float64) string {
+	if bmi < c.bmiMin {
+		return StatusRendah
+	}
+	if bmi >= c.bmiMax {
+		return StatusTinggi
+	}
+	return StatusNormal
+}
+
+// OxygenSaturationStatus menentukan status saturasi oksigen (SpO2)
+// Hanya RENDAH atau NORMAL karena SpO2 tidak memiliki batas atas klinis
+func (c *HealthClassifier) OxygenSaturationStatus(oxygenSaturation int) string {
+	if oxygenSaturation < c.oxygenSaturationMin {
+		return StatusRendah
+	}
+	return StatusNormal
+}
+
+// IsOxygenSaturationCritical mengecek apakah saturasi oksigen berada di bawah batas kritis (gawat darurat)
+func (c *HealthClassifier) IsOxygenSaturationCritical(oxygenSaturation int) bool {
+	return oxygenSaturation < c.oxygenSaturationCritical
+}
+
+// TemperatureStatus menentukan status suhu tubuh
+func (c *HealthClassifier) TemperatureStatus(temperature float64) string {
+	if temperature < c.temperatureMin {
+		return StatusRendah
+	}
+	if temperature > c.temperatureMax {
+		return StatusTinggi
+	}
+	return StatusNormal
+}
+
+// IsTemperatureCritical mengecek apakah suhu tubuh mencapai batas demam tinggi
+func (c *HealthClassifier) IsTemperatureCritical(temperature float64) bool {
+	return temperature >= c.temperatureCritical
+}
+
+// RespiratoryRateStatus menentukan status laju napas
+func (c *HealthClassifier) RespiratoryRateStatus(respiratoryRate int) string {
+	if respiratoryRate < c.respiratoryRateMin {
+		return StatusRendah
+	}
+	if respiratoryRate > c.respiratoryRateMax {
+		return StatusTinggi
+	}
+	return StatusNormal
+}
+
+// BloodPressureNormalRange mengembalikan keterangan rentang normal tekanan darah
+func (c *HealthClassifier) BloodPressureNormalRange() string {
+	source := "WHO"
+	if !c.isBloodPressureWHO() {
+		source = "Disesuaikan"
+	}
+	return fmt.Sprintf("%d-%d / %d-%d mmHg (%s)", c.systolicMin, c.systolicMax, c.diastolicMin, c.diastolicMax, source)
+}
+
+// BloodSugarNormalRange mengembalikan keterangan rentang normal gula darah untuk konteks tertentu
+func (c *HealthClassifier) BloodSugarNormalRange(context entity.BloodSugarContext) string {
+	r := c.bloodSugarRange(context)
+	return fmt.Sprintf("%d-%d mg/dL (%s - Gula Darah %s)", r.min, r.max, c.BloodSugarSource(context), normalizeBloodSugarContext(context).Label())
+}
+
+// OxygenSaturationNormalRange mengembalikan keterangan rentang normal saturasi oksigen
+func (c *HealthClassifier) OxygenSaturationNormalRange() string {
+	return fmt.Sprintf("%d-100%% (%s)", c.oxygenSaturationMin, thresholdSource(c.isOxygenSaturationWHO()))
+}
+
+// TemperatureNormalRange mengembalikan keterangan rentang normal suhu tubuh
+func (c *HealthClassifier) TemperatureNormalRange() string {
+	return fmt.Sprintf("%.1f-%.1f °C (%s)", c.temperatureMin, c.temperatureMax, thresholdSource(c.isTemperatureWHO()))
+}
+
+// RespiratoryRateNormalRange mengembalikan keterangan rentang normal laju napas
+func (c *HealthClassifier) RespiratoryRateNormalRange() string {
+	return fmt.Sprintf("%d-%d napas/menit (%s)", c.respiratoryRateMin, c.respiratoryRateMax, thresholdSource(c.isRespiratoryRateWHO()))
+}
+
+// OxygenSaturationMin mengembalikan batas bawah saturasi oksigen normal (untuk teks penjelasan alert)
+func (c *HealthClassifier) OxygenSaturationMin() int {
+	return c.oxygenSaturationMin
+}
+
+// OxygenSaturationCritical mengembalikan batas kritis saturasi oksigen (untuk teks rekomendasi alert)
+func (c *HealthClassifier) OxygenSaturationCritical() int {
+	return c.oxygenSaturationCritical
+}
+
+// TemperatureMax mengembalikan batas atas suhu tubuh normal (untuk teks penjelasan alert)
+func (c *HealthClassifier) TemperatureMax() float64 {
+	return c.temperatureMax
+}
+
+// TemperatureCritical mengembalikan batas demam tinggi (untuk teks rekomendasi alert)
+func (c *HealthClassifier) TemperatureCritical() float64 {
+	return c.temperatureCritical
+}
+
+// TemperatureMin mengembalikan batas bawah suhu tubuh normal (untuk teks penjelasan alert)
+func (c *HealthClassifier) TemperatureMin() float64 {
+	return c.temperatureMin
+}
+
+// BloodSugarSource mengembalikan sumber batas gula darah untuk konteks tertentu: "WHO" atau "Disesuaikan"
+func (c *HealthClassifier) BloodSugarSource(context entity.BloodSugarContext) string {
+	return thresholdSource(c.isBloodSugarWHO(context))
+}
+
+// BloodSugarMin mengembalikan batas bawah gula darah normal untuk konteks tertentu (untuk teks penjelasan alert)
+func (c *HealthClassifier) BloodSugarMin(context entity.BloodSugarContext) int {
+	return c.bloodSugarRange(context).min
+}
+
+// BloodSugarMax mengembalikan batas atas gula darah normal untuk konteks tertentu (untuk teks penjelasan alert)
+func (c *HealthClassifier) BloodSugarMax(context entity.BloodSugarContext) int {
+	return c.bloodSugarRange(context).max
+}
+
+// LabReferenceRange mengembalikan rentang rujukan yang berlaku untuk satu jenis pemeriksaan lab
+// Batas nil berarti pemeriksaan tersebut tidak memiliki batas di sisi itu
+func (c *HealthClassifier) LabReferenceRange(testType entity.LabTestType) (min, max *float64) {
+	r := c.labReferences[testType]
+	return copyFloatPointer(r.min), copyFloatPointer(r.max)
+}
+
+// bloodSugarRange mengambil rentang normal gula darah untuk konteks tertentu
+func (c *HealthClassifier) bloodSugarRange(context entity.BloodSugarContext) bloodSugarRange {
+	return c.bloodSugar[normalizeBloodSugarContext(context)]
+}
+
+// IsCustomized mengecek apakah ada batas yang berbeda dari standar WHO
+func (c *HealthClassifier) IsCustomized() bool {
+	return c.customized
+}
+
+// isBloodPressureWHO mengecek apakah batas tekanan darah sama dengan standar WHO
+func (c *HealthClassifier) isBloodPressureWHO() bool {
+	who := entity.DefaultClinicalThreshold()
+	return c.systolicMin == *who.SystolicMin && c.systolicMax == *who.SystolicMax &&
+		c.diastolicMin == *who.DiastolicMin && c.diastolicMax == *who.DiastolicMax
+}
+
+// isOxygenSaturationWHO mengecek apakah batas saturasi oksigen sama dengan standar WHO
+func (c *HealthClassifier) isOxygenSaturationWHO() bool {
+	who := entity.DefaultClinicalThreshold()
+	return c.oxygenSaturationMin == *who.OxygenSaturationMin && c.oxygenSaturationCritical == *who.OxygenSaturationCritical
+}
+
+// isTemperatureWHO mengecek apakah batas suhu tubuh sama dengan standar WHO
+func (c *HealthClassifier) isTemperatureWHO() bool {
+	who := entity.DefaultClinicalThreshold()
+	return c.temperatureMin == *who.TemperatureMin && c.temperatureMax == *who.TemperatureMax &&
+		c.temperatureCritical == *who.TemperatureCritical
+}
+
+// isRespiratoryRateWHO mengecek apakah batas laju napas sama dengan standar WHO
+func (c *HealthClassifier) isRespiratoryRateWHO() bool {
+	who := entity.DefaultClinicalThreshold()
+	return c.respiratoryRateMin == *who.RespiratoryRateMin && c.respiratoryRateMax == *who.RespiratoryRateMax
+}
+
+// isBloodSugarWHO mengecek apakah batas gula darah untuk konteks tertentu sama dengan standar WHO
+func (c *HealthClassifier) isBloodSugarWHO(context entity.BloodSugarContext) bool {
+	return c.bloodSugarRange(context) == whoBloodSugarRange(normalizeBloodSugarContext(context))
+}
+
+// isAllBloodSugarWHO mengecek apakah batas gula darah semua konteks sama dengan standar WHO
+func (c *HealthClassifier) isAllBloodSugarWHO() bool {
+	for context := range c.bloodSugar {
+		if !c.isBloodSugarWHO(context) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAllLabReferencesDefault mengecek apakah rentang rujukan semua jenis pemeriksaan lab sama dengan standar bawaan
+func (c *HealthClassifier) isAllLabReferencesDefault() bool {
+	who := entity.DefaultClinicalThreshold()
+	standard := mergeLabReferences([]*entity.ClinicalThreshold{&who})
+	for testType, r := range c.labReferences {
+		expected := standard[testType]
+		if !equalFloatPointer(r.min, expected.min) || !equalFloatPointer(r.max, expected.max) {
+			return false
+		}
+	}
+	return true
+}
+
+// whoBloodSugarRange mengembalikan rentang normal gula darah standar WHO untuk konteks tertentu
+func whoBloodSugarRange(context entity.BloodSugarContext) bloodSugarRange {
+	who := entity.DefaultClinicalThreshold()
+	switch context {
+	case entity.BloodSugarContextFasting:
+		return bloodSugarRange{min: *who.BloodSugarFastingMin, max: *who.BloodSugarFastingMax}
+	case entity.BloodSugarContextPostMeal:
+		return bloodSugarRange{min: *who.BloodSugarPostMealMin, max: *who.BloodSugarPostMealMax}
+	case entity.BloodSugarContextBedtime:
+		return bloodSugarRange{min: *who.BloodSugarBedtimeMin, max: *who.BloodSugarBedtimeMax}
+	default:
+		return bloodSugarRange{min: *who.BloodSugarMin, max: *who.BloodSugarMax}
+	}
+}
+
+// copyFloatPointer menyalin nilai pointer float agar batas di classifier tidak dapat diubah dari luar
+func copyFloatPointer(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+// equalFloatPointer mengecek apakah dua pointer float bernilai sama (keduanya nil dianggap sama)
+func equalFloatPointer(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// thresholdSource mengembalikan sumber batas: "WHO" atau "Disesuaikan"
+func thresholdSource(isWHO bool) string {
+	if isWHO {
+		return "WHO"
+	}
+	return "Disesuaikan"
+}
+
+// normalizeBloodSugarContext mengubah konteks kosong/tidak dikenal menjadi gula darah sewaktu
+func normalizeBloodSugarContext(context entity.BloodSugarContext) entity.BloodSugarContext {
+	if !entity.IsValidBloodSugarContext(context) {
+		return entity.BloodSugarContextRandom
+	}
+	return context
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"testing"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+// classifierCase adalah satu klasifikasi yang diharapkan dari HealthClassifier
+type classifierCase struct {
+	name     string
+	classify func(c *HealthClassifier) string
+	want     string
+}
+
+func runClassifierCases(t *testing.T, c *HealthClassifier, tests []classifierCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.classify(c); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthClassifierWHODefaults(t *testing.T) {
+	c := NewHealthClassifier(nil, nil)
+
+	runClassifierCases(t, c, []classifierCase{
+		{"tekanan darah normal", func(c *HealthClassifier) string { return c.BloodPressureStatus(120, 80) }, StatusNormal},
+		{"tekanan darah batas atas inklusif", func(c *HealthClassifier) string { return c.BloodPressureStatus(139, 89) }, StatusNormal},
+		{"sistolik tinggi", func(c *HealthClassifier) string { return c.BloodPressureStatus(140, 80) }, StatusTinggi},
+		{"diastolik tinggi", func(c *HealthClassifier) string { return c.BloodPressureStatus(120, 90) }, StatusTinggi},
+		{"diastolik rendah", func(c *HealthClassifier) string { return c.BloodPressureStatus(120, 59) }, StatusRendah},
+		{"rendah menang atas tinggi", func(c *HealthClassifier) string { return c.BloodPressureStatus(85, 95) }, StatusRendah},
+		{"detak jantung normal", func(c *HealthClassifier) string { return c.HeartRateStatus(72) }, StatusNormal},
+		{"detak jantung rendah", func(c *HealthClassifier) string { return c.HeartRateStatus(59) }, StatusRendah},
+		{"detak jantung tinggi", func(c *HealthClassifier) string { return c.HeartRateStatus(101) }, StatusTinggi},
+		{"BMI batas bawah normal", func(c *HealthClassifier) string { return c.BMIStatus(18.5) }, StatusNormal},
+		{"BMI batas atas eksklusif", func(c *HealthClassifier) string { return c.BMIStatus(25) }, StatusTinggi},
+		{"BMI rendah", func(c *HealthClassifier) string { return c.BMIStatus(18.4) }, StatusRendah},
+		{"SpO2 normal", func(c *HealthClassifier) string { return c.OxygenSaturationStatus(95) }, StatusNormal},
+		{"SpO2 rendah", func(c *HealthClassifier) string { return c.OxygenSaturationStatus(94) }, StatusRendah},
+		{"suhu normal", func(c *HealthClassifier) string { return c.TemperatureStatus(36.8) }, StatusNormal},
+		{"suhu tinggi", func(c *HealthClassifier) string { return c.TemperatureStatus(37.6) }, StatusTinggi},
+		{"suhu rendah", func(c *HealthClassifier) string { return c.TemperatureStatus(36.0) }, StatusRendah},
+		{"laju napas normal", func(c *HealthClassifier) string { return c.RespiratoryRateStatus(16) }, StatusNormal},
+		{"laju napas tinggi", func(c *HealthClassifier) string { return c.RespiratoryRateStatus(21) }, StatusTinggi},
+	})
+
+	if c.IsCustomized() {
+		t.Error("classifier tanpa penyesuaian tidak boleh customized")
+	}
+	if got, want := c.BloodPressureNormalRange(), "90-139 / 60-89 mmHg (WHO)"; got != want {
+		t.Errorf("BloodPressureNormalRange() = %q, want %q", got, want)
+	}
+}
+
+func TestHealthClassifierGlobalDefaultOverride(t *testing.T) {
+	defaults := entity.DefaultClinicalThreshold()
+	defaults.SystolicMax = intPtr(129)
+	defaults.HeartRateMax = intPtr(90)
+	c := NewHealthClassifier(&defaults, nil)
+
+	runClassifierCases(t, c, []classifierCase{
+		{"sistolik di atas default baru", func(c *HealthClassifier) string { return c.BloodPressureStatus(135, 80) }, StatusTinggi},
+		{"sistolik di batas default baru", func(c *HealthClassifier) string { return c.BloodPressureStatus(129, 80) }, StatusNormal},
+		{"detak jantung di atas default baru", func(c *HealthClassifier) string { return c.HeartRateStatus(95) }, StatusTinggi},
+		{"batas lain tetap WHO", func(c *HealthClassifier) string { return c.TemperatureStatus(37.5) }, StatusNormal},
+	})
+
+	if !c.IsCustomized() {
+		t.Error("classifier dengan default global berbeda dari WHO harus customized")
+	}
+	if got, want := c.BloodPressureNormalRange(), "90-129 / 60-89 mmHg (Disesuaikan)"; got != want {
+		t.Errorf("BloodPressureNormalRange() = %q, want %q", got, want)
+	}
+}
+
+func TestHealthClassifierPatientOverride(t *testing.T) {
+	defaults := entity.DefaultClinicalThreshold()
+	defaults.SystolicMax = intPtr(129)
+	override := &entity.ClinicalThreshold{
+		SystolicMax:   intPtr(149),
+		BloodSugarMin: intPtr(63),
+		BloodSugarMax: intPtr(120),
+	}
+	c := NewHealthClassifier(&defaults, override)
+
+	runClassifierCases(t, c, []classifierCase{
+		{"penyesuaian pasien menang atas default global", func(c *HealthClassifier) string { return c.BloodPressureStatus(145, 80) }, StatusNormal},
+		{"di atas batas pasien", func(c *HealthClassifier) string { return c.BloodPressureStatus(150, 80) }, StatusTinggi},
+		{"field kosong mengikuti default global", func(c *HealthClassifier) string { return c.BloodPressureStatus(120, 90) }, StatusTinggi},
+		{"gula darah sewaktu di bawah batas pasien", func(c *HealthClassifier) string {
+			return c.BloodSugarStatus(130, entity.BloodSugarContextRandom)
+		}, StatusTinggi},
+		{"gula darah sewaktu dalam batas pasien", func(c *HealthClassifier) string {
+			return c.BloodSugarStatus(65, entity.BloodSugarContextRandom)
+		}, StatusNormal},
+		{"konteks lain tidak terpengaruh", func(c *HealthClassifier) string {
+			return c.BloodSugarStatus(130, entity.BloodSugarContextPostMeal)
+		}, StatusNormal},
+	})
+
+	if !c.IsCustomized() {
+		t.Error("classifier dengan penyesuaian pasien harus customized")
+	}
+}
+
+func TestHealthClassifierBloodSugarContexts(t *testing.T) {
+	c := NewHealthClassifier(nil, nil)
+
+	tests := []struct {
+		name       string
+		bloodSugar int
+		context    entity.BloodSugarContext
+		want       string
+	}{
+		{"puasa normal", 95, entity.BloodSugarContextFasting, StatusNormal},
+		{"puasa tinggi", 100, entity.BloodSugarContextFasting, StatusTinggi},
+		{"puasa rendah", 69, entity.BloodSugarContextFasting, StatusRendah},
+		{"setelah makan normal", 139, entity.BloodSugarContextPostMeal, StatusNormal},
+		{"setelah makan tinggi", 140, entity.BloodSugarContextPostMeal, StatusTinggi},
+		{"sebelum tidur rendah", 95, entity.BloodSugarContextBedtime, StatusRendah},
+		{"sebelum tidur normal", 120, entity.BloodSugarContextBedtime, StatusNormal},
+		{"sewaktu normal", 140, entity.BloodSugarContextRandom, StatusNormal},
+		{"sewaktu tinggi", 141, entity.BloodSugarContextRandom, StatusTinggi},
+		{"konteks kosong dianggap sewaktu", 140, "", StatusNormal},
+		{"konteks tidak dikenal dianggap sewaktu", 141, "tidak_dikenal", StatusTinggi},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.BloodSugarStatus(tt.bloodSugar, tt.context); got != tt.want {
+				t.Errorf("BloodSugarStatus(%d, %q) = %s, want %s", tt.bloodSugar, tt.context, got, tt.want)
+			}
+		})
+	}
+
+	if got, want := c.BloodSugarNormalRange(entity.BloodSugarContextFasting), "70-99 mg/dL (WHO - Gula Darah Puasa)"; got != want {
+		t.Errorf("BloodSugarNormalRange(puasa) = %q, want %q", got, want)
+	}
+}
+
+func TestHealthClassifierLabReferenceRange(t *testing.T) {
+	defaults := entity.DefaultClinicalThreshold()
+	defaults.LDLMax = floatPtr(129)
+	override := &entity.ClinicalThreshold{UricAcidMax: floatPtr(6)}
+	c := NewHealthClassifier(&defaults, override)
+
+	tests := []struct {
+		name     string
+		testType entity.LabTestType
+		wantMin  *float64
+		wantMax  *float64
+	}{
+		{"standar hanya batas atas", entity.LabTestHbA1c, nil, floatPtr(5.6)},
+		{"standar hanya batas bawah", entity.LabTestHDL, floatPtr(40), nil},
+		{"default global", entity.LabTestLDL, nil, floatPtr(129)},
+		{"penyesuaian pasien sebagian", entity.LabTestUricAcid, floatPtr(3.4), floatPtr(6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMin, gotMax := c.LabReferenceRange(tt.testType)
+			if !equalFloatPointer(gotMin, tt.wantMin) || !equalFloatPointer(gotMax, tt.wantMax) {
+				t.Errorf("LabReferenceRange(%s) = %v-%v, want %v-%v", tt.testType, deref(gotMin), deref(gotMax), deref(tt.wantMin), deref(tt.wantMax))
+			}
+		})
+	}
+}
+
+func deref(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func TestHealthClassifierOxygenSaturationCritical(t *testing.T) {
+	override := &entity.ClinicalThreshold{
+		OxygenSaturationMin:      intPtr(92),
+		OxygenSaturationCritical: intPtr(88),
+	}
+
+	tests := []struct {
+		name             string
+		override         *entity.ClinicalThreshold
+		oxygenSaturation int
+		want             bool
+	}{
+		{"standar di bawah 90 kritis", nil, 89, true},
+		{"standar tepat 90 tidak kritis", nil, 90, false},
+		{"penyesuaian pasien di atas batas kritis", override, 89, false},
+		{"penyesuaian pasien di bawah batas kritis", override, 87, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewHealthClassifier(nil, tt.override)
+			if got := c.IsOxygenSaturationCritical(tt.oxygenSaturation); got != tt.want {
+				t.Errorf("IsOxygenSaturationCritical(%d) = %v, want %v", tt.oxygenSaturation, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthClassifierTemperatureCritical(t *testing.T) {
+	defaults := entity.DefaultClinicalThreshold()
+	defaults.TemperatureCritical = floatPtr(38.8)
+	override := &entity.ClinicalThreshold{TemperatureCritical: floatPtr(38.5)}
+
+	tests := []struct {
+		name        string
+		defaults    *entity.ClinicalThreshold
+		override    *entity.ClinicalThreshold
+		temperature float64
+		want        bool
+	}{
+		{"standar tepat 39 kritis", nil, nil, 39.0, true},
+		{"standar di bawah 39 tidak kritis", nil, nil, 38.9, false},
+		{"default global diturunkan", &defaults, nil, 38.8, true},
+		{"penyesuaian pasien menang atas default global", &defaults, override, 38.5, true},
+		{"di bawah batas pasien tidak kritis", &defaults, override, 38.4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewHealthClassifier(tt.defaults, tt.override)
+			if got := c.IsTemperatureCritical(tt.temperature); got != tt.want {
+				t.Errorf("IsTemperatureCritical(%.1f) = %v, want %v", tt.temperature, got, tt.want)
+			}
+		})
+	}
+
+	if c := NewHealthClassifier(nil, override); !c.IsCustomized() {
+		t.Error("classifier dengan batas demam tinggi berbeda dari standar harus customized")
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultActivityWeightKg adalah berat badan acuan untuk estimasi kalori
+	// jika user belum pernah mencatat berat badan
+	defaultActivityWeightKg = 60.0
+
+	maxActivityDurationMinutes = 720
+	maxActivitySteps           = 100000
+	maxActivityDistanceKm      = 300.0
+)
+
+// activityMETs adalah nilai MET (Metabolic Equivalent of Task) per jenis dan intensitas aktivitas
+// Nilai mengacu pada Compendium of Physical Activities (Ainsworth dkk.)
+var activityMETs = map[entity.ActivityType]map[entity.ActivityIntensity]float64{
+	entity.ActivityTypeWalking:    {entity.ActivityIntensityLight: 2.8, entity.ActivityIntensityModerate: 3.5, entity.ActivityIntensityVigorous: 5.0},
+	entity.ActivityTypeRunning:    {entity.ActivityIntensityLight: 7.0, entity.ActivityIntensityModerate: 9.8, entity.ActivityIntensityVigorous: 11.5},
+	entity.ActivityTypeCycling:    {entity.ActivityIntensityLight: 4.0, entity.ActivityIntensityModerate: 6.8, entity.ActivityIntensityVigorous: 10.0},
+	entity.ActivityTypeSwimming:   {entity.ActivityIntensityLight: 5.8, entity.ActivityIntensityModerate: 7.0, entity.ActivityIntensityVigorous: 9.8},
+	entity.ActivityTypeAerobics:   {entity.ActivityIntensityLight: 4.0, entity.ActivityIntensityModerate: 5.0, entity.ActivityIntensityVigorous: 7.3},
+	entity.ActivityTypeYoga:       {entity.ActivityIntensityLight: 2.5, entity.ActivityIntensityModerate: 3.0, entity.ActivityIntensityVigorous: 4.0},
+	entity.ActivityTypeWeightLift: {entity.ActivityIntensityLight: 3.5, entity.ActivityIntensityModerate: 5.0, entity.ActivityIntensityVigorous: 6.0},
+	entity.ActivityTypeHousework:  {entity.ActivityIntensityLight: 2.3, entity.ActivityIntensityModerate: 3.3, entity.ActivityIntensityVigorous: 4.0},
+	entity.ActivityTypeOther:      {entity.ActivityIntensityLight: 3.0, entity.ActivityIntensityModerate: 4.5, entity.ActivityIntensityVigorous: 6.0},
+}
+
+// validateActivity memvalidasi dan menormalisasi aktivitas terstruktur (jika dikirim)
+// Intensitas yang tidak dikirim diisi default "sedang"
+func validateActivity(activity *request.ActivityRequest) error {
+	if activity == nil {
+		return nil
+	}
+
+	activityType := strings.ToLower(strings.TrimSpace(activity.Type))
+	if activityType == "" {
+		return errors.New("activity.type wajib diisi")
+	}
+	if !entity.IsValidActivityType(entity.ActivityType(activityType)) {
+		types := make([]string, 0, len(entity.ActivityTypes))
+		for _, t := range entity.ActivityTypes {
+			types = append(types, string(t))
+		}
+		return fmt.Errorf("activity.type harus salah satu dari %s", strings.Join(types, ", "))
+	}
+	activity.Type = activityType
+
+	if activity.DurationMinutes < 1 || activity.DurationMinutes > maxActivityDurationMinutes {
+		return fmt.Errorf("activity.duration_minutes harus antara 1 dan %d menit", maxActivityDurationMinutes)
+	}
+
+	intensity := string(entity.ActivityIntensityModerate)
+	if activity.Intensity != nil {
+		intensity = strings.ToLower(strings.TrimSpace(*activity.Intensity))
+		if !entity.IsValidActivityIntensity(entity.ActivityIntensity(intensity)) {
+			return errors.New("activity.intensity harus salah satu dari ringan, sedang, berat")
+		}
+	}
+	activity.Intensity = &intensity
+
+	if activity.Steps != nil && (*activity.Steps < 0 || *activity.Steps > maxActivitySteps) {
+		return fmt.Errorf("activity.steps harus antara 0 dan %d", maxActivitySteps)
+	}
+	if activity.DistanceKm != nil && (*activity.DistanceKm < 0 || *activity.DistanceKm > maxActivityDistanceKm) {
+		return fmt.Errorf("activity.distance_km harus antara 0 dan %.0f km", maxActivityDistanceKm)
+	}
+
+	return nil
+}
+
+// updateActivityFields mengisi field aktivitas terstruktur dari request yang sudah divalidasi
+// Kalori tidak diisi di sini karena membutuhkan berat badan user (lihat applyActivityCalories)
+func updateActivityFields(healthData *entity.HealthData, activity *request.ActivityRequest) {
+	activityType := entity.ActivityType(activity.Type)
+	intensity := entity.ActivityIntensity(*activity.Intensity)
+	duration := activity.DurationMinutes
+
+	healthData.ActivityType = &activityType
+	healthData.ActivityDuration = &duration
+	healthData.ActivityIntensity = &intensity
+	healthData.Steps = activity.Steps
+	if activity.DistanceKm != nil {
+		distance := roundTo2Decimals(*activity.DistanceKm)
+		healthData.DistanceKm = &distance
+	} else {
+		healthData.DistanceKm = nil
+	}
+}
+
+// applyActivityCalories menghitung estimasi kalori aktivitas pada pembacaan
+// Berat badan diambil dari pembacaan itu sendiri, lalu berat badan terakhir user sebelum waktu pengukuran,
+// dan terakhir berat badan acuan defaultActivityWeightKg
+func (s *HealthDataService) applyActivityCalories(target *entity.HealthData, userID uint, activity *entity.HealthData, weight *float64, measuredAt time.Time) {
+	if activity.ActivityType == nil || activity.ActivityDuration == nil {
+		return
+	}
+
+	weightKg := defaultActivityWeightKg
+	if weight != nil {
+		weightKg = *weight
+	} else if latestWeight, err := s.healthDataRepo.GetLatestWeightByUserID(userID, measuredAt); err != nil {
+		log.Printf("Warning: Gagal mengambil berat badan user %d untuk estimasi kalori: %v", userID, err)
+	} else if latestWeight != nil {
+		weightKg = *latestWeight
+	}
+
+	intensity := entity.ActivityIntensityModerate
+	if activity.ActivityIntensity != nil {
+		intensity = *activity.ActivityIntensity
+	}
+
+	calories := estimateActivityCalories(*activity.ActivityType, intensity, *activity.ActivityDuration, weightKg)
+	target.CaloriesBurned = &calories
+}
+
+// estimateActivityCalories menghitung estimasi kalori: MET x berat badan (kg) x durasi (jam)
+func estimateActivityCalories(activityType entity.ActivityType, intensity entity.ActivityIntensity, durationMinutes int, weightKg float64) float64 {
+	mets, ok := activityMETs[activityType]
+	if !ok {
+		mets = activityMETs[entity.ActivityTypeOther]
+	}
+	met, ok := mets[intensity]
+	if !ok {
+		met = mets[entity.ActivityIntensityModerate]
+	}
+	return roundTo1Decimal(met * weightKg * float64(durationMinutes) / 60)
+}
+
+// hasStructuredActivity memeriksa apakah pembacaan berisi aktivitas terstruktur
+func hasStructuredActivity(d *entity.HealthData) bool {
+	return d.ActivityType != nil
+}
+
+// activityTotals adalah akumulasi aktivitas terstruktur (langkah, kalori, durasi, jarak)
+type activityTotals struct {
+	steps    int
+	calories float64
+	minutes  int
+	distance float64
+	sessions int
+}
+
+// add menambahkan aktivitas pada satu pembacaan ke akumulasi
+// Pembacaan tanpa aktivitas terstruktur (termasuk teks aktivitas lama) tidak dihitung
+func (t *activityTotals) add(d *entity.HealthData) {
+	if !hasStructuredActivity(d) {
+		return
+	}
+	t.sessions++
+	if d.Steps != nil {
+		t.steps += *d.Steps
+	}
+	if d.CaloriesBurned != nil {
+		t.calories += *d.CaloriesBurned
+	}
+	if d.ActivityDuration != nil {
+		t.minutes += *d.ActivityDuration
+	}
+	if d.DistanceKm != nil {
+		t.distance += *d.DistanceKm
+	}
+}
+
+// mapActivityToResponse mengubah aktivitas pada pembacaan ke response
+// Mengembalikan nil jika pembacaan tidak berisi aktivitas
+func mapActivityToResponse(d *entity.HealthData) *response.ActivityResponse {
+	hasLegacyActivity := d.Activity != nil && *d.Activity != ""
+	if !hasStructuredActivity(d) && !hasLegacyActivity {
+		return nil
+	}
+
+	resp := &response.ActivityResponse{
+		DurationMinutes: d.ActivityDuration,
+		Steps:           d.Steps,
+		DistanceKm:      d.DistanceKm,
+		CaloriesBurned:  d.CaloriesBurned,
+	}
+	if hasStructuredActivity(d) {
+		activityType := string(*d.ActivityType)
+		label := d.ActivityType.Label()
+		resp.Type = &activityType
+		resp.TypeLabel = &label
+	}
+	if d.ActivityIntensity != nil {
+		intensity := string(*d.ActivityIntensity)
+		resp.Intensity = &intensity
+	}
+	if hasLegacyActivity {
+		resp.Description = d.Activity
+	}
+	return resp
+}
+
+// formatActivityReading menyusun teks aktivitas untuk riwayat pembacaan
+// mis. "Jalan Kaki 30 menit (sedang), 3.500 langkah, 2.4 km, 123 kkal"
+func formatActivityReading(d *entity.HealthData) string {
+	if !hasStructuredActivity(d) {
+		if d.Activity != nil {
+			return *d.Activity
+		}
+		return ""
+	}
+
+	text := d.ActivityType.Label()
+	if d.ActivityDuration != nil {
+		text += fmt.Sprintf(" %d menit", *d.ActivityDuration)
+	}
+	if d.ActivityIntensity != nil {
+		text += fmt.Sprintf(" (%s)", *d.ActivityIntensity)
+	}
+	if d.Steps != nil {
+		text += fmt.Sprintf(", %s langkah", formatNumber(*d.Steps))
+	}
+	if d.DistanceKm != nil {
+		text += fmt.Sprintf(", %.1f km", *d.DistanceKm)
+	}
+	if d.CaloriesBurned != nil {
+		text += fmt.Sprintf(", %.0f kkal", *d.CaloriesBurned)
+	}
+	return text
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"testing"
+)
+
+func TestEstimateActivityCalories(t *testing.T) {
+	tests := []struct {
+		name            string
+		activityType    entity.ActivityType
+		intensity       entity.ActivityIntensity
+		durationMinutes int
+		weightKg        float64
+		want            float64
+	}{
+		{"jalan kaki sedang 30 menit", entity.ActivityTypeWalking, entity.ActivityIntensityModerate, 30, 70, 122.5},
+		{"lari berat 45 menit", entity.ActivityTypeRunning, entity.ActivityIntensityVigorous, 45, 60, 517.5},
+		{"yoga ringan 60 menit", entity.ActivityTypeYoga, entity.ActivityIntensityLight, 60, 55, 137.5},
+		{"dibulatkan satu desimal", entity.ActivityTypeCycling, entity.ActivityIntensityModerate, 20, 65.3, 148},
+		{"intensitas kosong memakai sedang", entity.ActivityTypeSwimming, "", 60, 50, 350},
+		{"jenis tidak dikenal memakai lainnya", "tidak_dikenal", entity.ActivityIntensityLight, 60, 60, 180},
+		{"durasi nol", entity.ActivityTypeWalking, entity.ActivityIntensityModerate, 0, 70, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := estimateActivityCalories(tt.activityType, tt.intensity, tt.durationMinutes, tt.weightKg)
+			if got != tt.want {
+				t.Errorf("estimateActivityCalories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
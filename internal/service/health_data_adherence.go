@@ -0,0 +1,187 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"fmt"
+	"math"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// Minimal jumlah hari berpasangan (kepatuhan dan pengukuran) untuk menghitung koefisien korelasi
+const minAdherenceCorrelationDays = 3
+
+// adherenceCorrelationMetric mendefinisikan metrik kesehatan yang dibandingkan dengan kepatuhan minum obat
+type adherenceCorrelationMetric struct {
+	metric string
+	label  string
+	unit   string
+	// minDifference adalah selisih rata-rata minimal yang dianggap bermakna
+	minDifference float64
+	value         func(entity.HealthData) (float64, bool)
+}
+
+// adherenceCorrelationMetrics adalah metrik yang dikorelasikan dengan kepatuhan minum obat
+var adherenceCorrelationMetrics = []adherenceCorrelationMetric{
+	{
+		metric: "tekanan_darah", label: "tekanan darah sistolik", unit: "mmHg", minDifference: 5,
+		value: func(d entity.HealthData) (float64, bool) {
+			if d.Systolic == nil {
+				return 0, false
+			}
+			return float64(*d.Systolic), true
+		},
+	},
+	{
+		metric: "gula_darah", label: "gula darah", unit: "mg/dL", minDifference: 10,
+		value: func(d entity.HealthData) (float64, bool) {
+			if d.BloodSugar == nil {
+				return 0, false
+			}
+			return float64(*d.BloodSugar), true
+		},
+	},
+}
+
+// buildMedicationAdherence menghitung kepatuhan minum obat pada rentang riwayat kesehatan
+// beserta korelasinya dengan tekanan darah dan gula darah harian.
+// Mengembalikan nil jika user tidak memiliki obat terjadwal pada rentang tersebut
+// atau filter metrics dikirim tanpa "kepatuhan_obat"
+func (s *HealthDataService) buildMedicationAdherence(userID uint, req *request.HealthHistoryRequest, startDate, endDate time.Time, data []entity.HealthData) (*response.MedicationAdherenceCorrelation, error) {
+	if s.medicationService == nil {
+		return nil, nil
+	}
+	if len(req.Metrics) > 0 && !s.containsMetric(req.Metrics, "kepatuhan_obat") {
+		return nil, nil
+	}
+
+	adherence, err := s.medicationService.GetAdherenceForPeriod(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(adherence.Medications) == 0 {
+		return nil, nil
+	}
+
+	dailyAdherence := make(map[string]float64, len(adherence.Daily))
+	for _, daily := range adherence.Daily {
+		dailyAdherence[daily.Date] = daily.AdherencePercent
+	}
+
+	correlations := make([]response.AdherenceMetricCorrelation, 0, len(adherenceCorrelationMetrics))
+	for _, metric := range adherenceCorrelationMetrics {
+		correlations = append(correlations, correlateAdherence(metric, dailyAdherence, data))
+	}
+
+	return &response.MedicationAdherenceCorrelation{
+		AdherencePercent: adherence.AdherencePercent,
+		Status:           adherence.Status,
+		ExpectedDoses:    adherence.ExpectedDoses,
+		TakenDoses:       adherence.TakenDoses,
+		Medications:      adherence.Medications,
+		Daily:            adherence.Daily,
+		Correlations:     correlations,
+	}, nil
+}
+
+// correlateAdherence membandingkan rata-rata harian satu metrik pada hari patuh (>= 80%) dan tidak patuh,
+// serta menghitung koefisien korelasi Pearson antara kepatuhan harian dan rata-rata harian metrik
+func correlateAdherence(metric adherenceCorrelationMetric, dailyAdherence map[string]float64, data []entity.HealthData) response.AdherenceMetricCorrelation {
+	result := response.AdherenceMetricCorrelation{
+		Metric: metric.metric,
+		Unit:   metric.unit,
+	}
+
+	// Rata-rata harian metrik (hanya hari yang memiliki jadwal minum obat)
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, d := range data {
+		value, ok := metric.value(d)
+		if !ok {
+			continue
+		}
+		dateKey := timezoneUtils.ToJakarta(d.RecordDate).Format("2006-01-02")
+		if _, scheduled := dailyAdherence[dateKey]; !scheduled {
+			continue
+		}
+		sums[dateKey] += value
+		counts[dateKey]++
+	}
+
+	var adherentSum, nonAdherentSum float64
+	xs := make([]float64, 0, len(sums))
+	ys := make([]float64, 0, len(sums))
+	for dateKey, sum := range sums {
+		avg := sum / float64(counts[dateKey])
+		percent := dailyAdherence[dateKey]
+		xs = append(xs, percent)
+		ys = append(ys, avg)
+		if percent >= adherenceGoodPercent {
+			result.AdherentDays++
+			adherentSum += avg
+		} else {
+			result.NonAdherentDays++
+			nonAdherentSum += avg
+		}
+	}
+
+	if result.AdherentDays > 0 {
+		avg := roundTo1Decimal(adherentSum / float64(result.AdherentDays))
+		result.AvgOnAdherentDays = &avg
+	}
+	if result.NonAdherentDays > 0 {
+		avg := roundTo1Decimal(nonAdherentSum / float64(result.NonAdherentDays))
+		result.AvgOnNonAdherent = &avg
+	}
+	if result.AvgOnAdherentDays != nil && result.AvgOnNonAdherent != nil {
+		difference := roundTo1Decimal(*result.AvgOnNonAdherent - *result.AvgOnAdherentDays)
+		result.Difference = &difference
+	}
+	if len(xs) >= minAdherenceCorrelationDays {
+		result.Coefficient = pearsonCoefficient(xs, ys)
+	}
+
+	switch {
+	case len(xs) == 0:
+		result.Interpretation = fmt.Sprintf("Belum ada pengukuran %s pada hari dengan jadwal minum obat", metric.label)
+	case result.Difference == nil:
+		result.Interpretation = fmt.Sprintf("Belum cukup data untuk membandingkan %s pada hari patuh dan tidak patuh minum obat", metric.label)
+	case *result.Difference >= metric.minDifference:
+		result.Interpretation = fmt.Sprintf("Rata-rata %s %.1f %s lebih tinggi pada hari tidak patuh minum obat", metric.label, *result.Difference, metric.unit)
+	case *result.Difference <= -metric.minDifference:
+		result.Interpretation = fmt.Sprintf("Rata-rata %s %.1f %s lebih rendah pada hari tidak patuh minum obat", metric.label, -*result.Difference, metric.unit)
+	default:
+		result.Interpretation = fmt.Sprintf("Tidak tampak perbedaan bermakna pada %s antara hari patuh dan tidak patuh minum obat", metric.label)
+	}
+
+	return result
+}
+
+// pearsonCoefficient menghitung koefisien korelasi Pearson dua deret data
+// Mengembalikan nil jika salah satu deret tidak bervariasi
+func pearsonCoefficient(xs, ys []float64) *float64 {
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var covariance, varianceX, varianceY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return nil
+	}
+
+	coefficient := roundTo2Decimals(covariance / math.Sqrt(varianceX*varianceY))
+	return &coefficient
+}
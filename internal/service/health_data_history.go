@@ -12,7 +12,7 @@ import (
 
 func (s *HealthDataService) GetHealthHistory(userID uint, req *request.HealthHistoryRequest) (*response.HealthHistoryResponse, error) {
 	// Tentukan rentang waktu
-	startDate, endDate, err := s.parseTimeRange(req)
+	startDate, endDate, err := parseTimeRange(req)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +36,12 @@ func (s *HealthDataService) GetHealthHistory(userID uint, req *request.HealthHis
 	periodLength := endDate.Sub(startDate)
 	prevDataList, _ := s.healthDataRepo.GetHealthDataForComparison(userID, startDate, endDate, periodLength)
 
+	// Batas klinis yang berlaku untuk user
+	classifier, err := s.clinicalThresholdService.GetClassifier(userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Filter berdasarkan metrik jika ada
 	filteredData := s.filterByMetrics(healthDataList, req.Metrics)
 	filteredTrendData := s.filterByMetrics(trendDataList, req.Metrics)
@@ -44,19 +50,26 @@ func (s *HealthDataService) GetHealthHistory(userID uint, req *request.HealthHis
 	result := &response.HealthHistoryResponse{}
 
 	// Ringkasan statistik (gunakan data sesuai time range request)
-	result.Summary = s.calculateSummary(filteredData, prevDataList, req.Metrics)
+	result.Summary = s.calculateSummary(classifier, filteredData, prevDataList, req.Metrics)
 
 	// Grafik tren (gunakan data 90 hari)
 	result.TrendCharts = s.calculateTrendCharts(filteredTrendData, req.Metrics)
 
 	// Catatan pembacaan (gunakan data sesuai time range request)
-	result.ReadingHistory = s.buildReadingHistory(filteredData)
+	result.ReadingHistory = s.buildReadingHistory(classifier, filteredData)
+
+	// Kepatuhan minum obat dan korelasinya dengan tekanan darah & gula darah (data sesuai time range request)
+	result.MedicationAdherence, err = s.buildMedicationAdherence(userID, req, startDate, endDate, healthDataList)
+	if err != nil {
+		return nil, err
+	}
 
 	return result, nil
 }
 
 // parseTimeRange mengkonversi time_range ke startDate dan endDate
-func (s *HealthDataService) parseTimeRange(req *request.HealthHistoryRequest) (time.Time, time.Time, error) {
+// Dipakai bersama oleh riwayat kesehatan, laporan, dan kepatuhan minum obat
+func parseTimeRange(req *request.HealthHistoryRequest) (time.Time, time.Time, error) {
 	now := timezoneUtils.NowInJakarta()
 	// endDate adalah hari ini (akhir hari untuk memastikan semua data hari ini termasuk)
 	endDate := timezoneUtils.DateInJakarta(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0)
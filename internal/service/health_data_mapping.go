@@ -20,7 +20,13 @@ func (s *HealthDataService) MapHealthHistoryToAPIResponse(
 	internalResp *response.HealthHistoryResponse,
 ) (*response.HealthHistoryAPIResponse, error) {
 	// Tentukan rentang waktu untuk mendapatkan start_date dan end_date global
-	startDate, endDate, err := s.parseTimeRange(req)
+	startDate, endDate, err := parseTimeRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Batas klinis yang berlaku untuk user
+	classifier, err := s.clinicalThresholdService.GetClassifier(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -59,24 +65,25 @@ func (s *HealthDataService) MapHealthHistoryToAPIResponse(
 			StartDate: startDate.Format("2006-01-02"),
 			EndDate:   endDate.Format("2006-01-02"),
 		},
-		TrendCharts: internalResp.TrendCharts, // Tetap sama seperti sebelumnya
+		TrendCharts:         internalResp.TrendCharts, // Tetap sama seperti sebelumnya
+		MedicationAdherence: internalResp.MedicationAdherence,
 	}
 
 	// Summary untuk 7Days (tanpa weeks)
-	summary7Days := s.calculateSummary(filteredData7Days, []entity.HealthData{}, req.Metrics)
+	summary7Days := s.calculateSummary(classifier, filteredData7Days, []entity.HealthData{}, req.Metrics)
 	apiResp.Summary.Days7 = &summary7Days
 
 	// Summary untuk 1Month (dengan weeks)
-	apiResp.Summary.Month1 = s.buildSummaryWithWeeks(filteredData1Month, req.Metrics, startDate1Month)
+	apiResp.Summary.Month1 = s.buildSummaryWithWeeks(classifier, filteredData1Month, req.Metrics, startDate1Month)
 
 	// Summary untuk 3Months (dengan weeks)
-	apiResp.Summary.Months3 = s.buildSummaryWithWeeks(filteredData3Months, req.Metrics, startDate3Months)
+	apiResp.Summary.Months3 = s.buildSummaryWithWeeks(classifier, filteredData3Months, req.Metrics, startDate3Months)
 
 	// Reading history untuk 7Days
-	apiResp.ReadingHistory.Days7 = s.buildReadingHistory(filteredData7Days)
+	apiResp.ReadingHistory.Days7 = s.buildReadingHistory(classifier, filteredData7Days)
 
 	// Reading history untuk 1Month (dengan grouping)
-	readingHistory1Month := s.buildReadingHistory(filteredData1Month)
+	readingHistory1Month := s.buildReadingHistory(classifier, filteredData1Month)
 	apiResp.ReadingHistory.Month1 = &response.ReadingHistoryGrouped{
 		StartDate: startDate1Month.Format("2006-01-02"),
 		EndDate:   endDateGlobal.Format("2006-01-02"),
@@ -84,7 +91,7 @@ func (s *HealthDataService) MapHealthHistoryToAPIResponse(
 	}
 
 	// Reading history untuk 3Months (dengan grouping)
-	readingHistory3Months := s.buildReadingHistory(filteredData3Months)
+	readingHistory3Months := s.buildReadingHistory(classifier, filteredData3Months)
 	apiResp.ReadingHistory.Months3 = &response.ReadingHistoryGrouped{
 		StartDate: startDate3Months.Format("2006-01-02"),
 		EndDate:   endDateGlobal.Format("2006-01-02"),
@@ -97,12 +104,13 @@ func (s *HealthDataService) MapHealthHistoryToAPIResponse(
 // buildSummaryWithWeeks membangun summary dengan agregasi per minggu
 // Menggunakan data yang sudah ada dan logic calculateSummary yang sudah ada
 func (s *HealthDataService) buildSummaryWithWeeks(
+	classifier *HealthClassifier,
 	data []entity.HealthData,
 	metrics []string,
 	rangeStartDate time.Time,
 ) *response.HealthSummaryWithWeeks {
 	// Hitung summary keseluruhan menggunakan logic yang sudah ada
-	overallSummary := s.calculateSummary(data, []entity.HealthData{}, metrics)
+	overallSummary := s.calculateSummary(classifier, data, []entity.HealthData{}, metrics)
 
 	// Kelompokkan data per minggu menggunakan logic yang sama dengan getWeekRange
 	// Tapi menghitung week number dengan benar untuk range berapa pun (tidak dibatasi Week 4)
@@ -137,7 +145,7 @@ func (s *HealthDataService) buildSummaryWithWeeks(
 		weekDates := weekDateMap[weekKey]
 
 		// Hitung summary untuk minggu ini menggunakan logic yang sudah ada
-		weekSummary := s.calculateSummary(weekData, []entity.HealthData{}, metrics)
+		weekSummary := s.calculateSummary(classifier, weekData, []entity.HealthData{}, metrics)
 
 		weeks = append(weeks, response.HealthSummaryWeek{
 			Week:      weekKey,
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxReadingNotesLength = 500
+	maxReadingSymptoms    = 10
+)
+
+// validateReadingNotes memvalidasi dan menormalisasi catatan, gejala, dan konteks pembacaan (jika dikirim)
+// String kosong (atau array gejala kosong) berarti menghapus nilai saat update
+func validateReadingNotes(req *request.HealthDataRequest) error {
+	if req.Notes != nil {
+		notes := strings.TrimSpace(*req.Notes)
+		if utf8.RuneCountInString(notes) > maxReadingNotesLength {
+			return fmt.Errorf("notes maksimal %d karakter", maxReadingNotesLength)
+		}
+		req.Notes = &notes
+	}
+
+	if req.BodyPosition != nil {
+		position := strings.ToLower(strings.TrimSpace(*req.BodyPosition))
+		if position != "" && !entity.IsValidBodyPosition(entity.BodyPosition(position)) {
+			return errors.New("body_position harus salah satu dari duduk, berdiri, berbaring")
+		}
+		req.BodyPosition = &position
+	}
+
+	if req.MeasurementArm != nil {
+		arm := strings.ToLower(strings.TrimSpace(*req.MeasurementArm))
+		if arm != "" && !entity.IsValidMeasurementArm(entity.MeasurementArm(arm)) {
+			return errors.New("measurement_arm harus salah satu dari kiri, kanan")
+		}
+		req.MeasurementArm = &arm
+	}
+
+	if req.MeasurementContext != nil {
+		context := strings.ToLower(strings.TrimSpace(*req.MeasurementContext))
+		if context != "" && !entity.IsValidMeasurementContext(entity.MeasurementContext(context)) {
+			return errors.New("measurement_context harus salah satu dari bangun_tidur, istirahat, setelah_aktivitas, sebelum_minum_obat, setelah_minum_obat, stres, sedang_sakit")
+		}
+		req.MeasurementContext = &context
+	}
+
+	if req.Symptoms != nil {
+		symptoms, err := normalizeSymptoms(*req.Symptoms)
+		if err != nil {
+			return err
+		}
+		req.Symptoms = &symptoms
+	}
+
+	return nil
+}
+
+// normalizeSymptoms menormalisasi tag gejala (huruf kecil, spasi menjadi garis bawah, tanpa duplikat)
+func normalizeSymptoms(symptoms []string) ([]string, error) {
+	normalized := make([]string, 0, len(symptoms))
+	for _, symptom := range symptoms {
+		code := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(symptom)), " ", "_")
+		if code == "" || containsString(normalized, code) {
+			continue
+		}
+		if _, ok := entity.SymptomLabel(code); !ok {
+			codes := make([]string, 0, len(entity.Symptoms))
+			for _, known := range entity.Symptoms {
+				codes = append(codes, known.Code)
+			}
+			return nil, fmt.Errorf("symptoms berisi gejala tidak dikenal (%s), gunakan salah satu dari %s", symptom, strings.Join(codes, ", "))
+		}
+		normalized = append(normalized, code)
+	}
+	if len(normalized) > maxReadingSymptoms {
+		return nil, fmt.Errorf("symptoms maksimal %d gejala", maxReadingSymptoms)
+	}
+	return normalized, nil
+}
+
+// hasBloodPressurePlacement memeriksa apakah request mengirim posisi tubuh atau lengan pengukuran
+func hasBloodPressurePlacement(req *request.HealthDataRequest) bool {
+	return (req.BodyPosition != nil && *req.BodyPosition != "") ||
+		(req.MeasurementArm != nil && *req.MeasurementArm != "")
+}
+
+// hasReadingNotes memeriksa apakah request mengirim catatan, gejala, atau konteks pembacaan
+func hasReadingNotes(req *request.HealthDataRequest) bool {
+	return req.Notes != nil || req.BodyPosition != nil || req.MeasurementArm != nil ||
+		req.Symptoms != nil || req.MeasurementContext != nil
+}
+
+// updateReadingNotesFields mengisi catatan, gejala, dan konteks pembacaan dari request yang sudah divalidasi
+// Nilai kosong tetap diisi (pointer ke string kosong) agar repository dapat mengosongkan kolom saat update
+func updateReadingNotesFields(healthData *entity.HealthData, req *request.HealthDataRequest) {
+	if req.Notes != nil {
+		healthData.Notes = req.Notes
+	}
+	if req.BodyPosition != nil {
+		position := entity.BodyPosition(*req.BodyPosition)
+		healthData.BodyPosition = &position
+	}
+	if req.MeasurementArm != nil {
+		arm := entity.MeasurementArm(*req.MeasurementArm)
+		healthData.MeasurementArm = &arm
+	}
+	if req.Symptoms != nil {
+		symptoms := strings.Join(*req.Symptoms, ",")
+		healthData.Symptoms = &symptoms
+	}
+	if req.MeasurementContext != nil {
+		context := entity.MeasurementContext(*req.MeasurementContext)
+		healthData.MeasurementContext = &context
+	}
+}
+
+// clearEmptyReadingNotes mengubah nilai kosong menjadi NULL untuk pembacaan baru
+func clearEmptyReadingNotes(healthData *entity.HealthData) {
+	if healthData.Notes != nil && *healthData.Notes == "" {
+		healthData.Notes = nil
+	}
+	if healthData.BodyPosition != nil && *healthData.BodyPosition == "" {
+		healthData.BodyPosition = nil
+	}
+	if healthData.MeasurementArm != nil && *healthData.MeasurementArm == "" {
+		healthData.MeasurementArm = nil
+	}
+	if healthData.Symptoms != nil && *healthData.Symptoms == "" {
+		healthData.Symptoms = nil
+	}
+	if healthData.MeasurementContext != nil && *healthData.MeasurementContext == "" {
+		healthData.MeasurementContext = nil
+	}
+}
+
+// readingContextLabel menyusun teks konteks satu baris riwayat pembacaan
+// baseLabels adalah konteks khusus metrik (mis. konteks gula darah atau posisi dan lengan tekanan darah),
+// lalu ditambah kondisi saat pengukuran. Mengembalikan nil jika tidak ada konteks
+func readingContextLabel(d *entity.HealthData, baseLabels ...string) *string {
+	labels := make([]string, 0, len(baseLabels)+1)
+	for _, label := range baseLabels {
+		if label != "" {
+			labels = append(labels, label)
+		}
+	}
+	if d.MeasurementContext != nil {
+		labels = append(labels, d.MeasurementContext.Label())
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	context := strings.Join(labels, ", ")
+	return &context
+}
+
+// bloodPressurePlacementLabels mengembalikan label posisi tubuh dan lengan pengukuran tekanan darah
+func bloodPressurePlacementLabels(d *entity.HealthData) []string {
+	var labels []string
+	if d.BodyPosition != nil {
+		labels = append(labels, d.BodyPosition.Label())
+	}
+	if d.MeasurementArm != nil {
+		labels = append(labels, d.MeasurementArm.Label())
+	}
+	return labels
+}
+
+// symptomLabels mengembalikan label gejala pembacaan
+func symptomLabels(symptoms []string) []string {
+	labels := make([]string, 0, len(symptoms))
+	for _, symptom := range symptoms {
+		if label, ok := entity.SymptomLabel(symptom); ok {
+			labels = append(labels, label)
+		} else {
+			labels = append(labels, symptom)
+		}
+	}
+	return labels
+}
+
+// applyReadingNotesToHistory mengisi catatan, gejala, dan konteks pengukuran pada satu baris riwayat
+func applyReadingNotesToHistory(item *response.ReadingHistoryResponse, d *entity.HealthData) {
+	item.Notes = d.Notes
+	item.Symptoms = d.SymptomList()
+	if d.MeasurementContext != nil {
+		context := string(*d.MeasurementContext)
+		item.MeasurementContext = &context
+	}
+}
+
+// formatReadingNotes menyusun kolom catatan laporan dari gejala dan catatan user
+// mis. "Gejala: Pusing, Mual. Setelah begadang"
+func formatReadingNotes(record response.ReadingHistoryResponse) string {
+	var parts []string
+	if len(record.Symptoms) > 0 {
+		parts = append(parts, "Gejala: "+strings.Join(symptomLabels(record.Symptoms), ", "))
+	}
+	if record.Notes != nil && *record.Notes != "" {
+		parts = append(parts, *record.Notes)
+	}
+	return strings.Join(parts, ". ")
+}
+
+// SearchReadings mencari pembacaan user berdasarkan kata kunci catatan/gejala, tag gejala,
+// konteks pengukuran, dan rentang tanggal, dengan pagination
+func (s *HealthDataService) SearchReadings(userID uint, req *request.ReadingSearchRequest) (*response.ReadingSearchResponse, error) {
+	filter := repository.HealthDataSearchFilter{
+		Query: strings.TrimSpace(req.Query),
+	}
+
+	if req.Symptom != "" {
+		symptoms, err := normalizeSymptoms([]string{req.Symptom})
+		if err != nil {
+			return nil, errors.New("symptom harus berupa tag gejala yang dikenal")
+		}
+		filter.Symptom = symptoms[0]
+	}
+
+	if req.MeasurementContext != "" {
+		context := entity.MeasurementContext(strings.ToLower(strings.TrimSpace(req.MeasurementContext)))
+		if !entity.IsValidMeasurementContext(context) {
+			return nil, errors.New("measurement_context harus salah satu dari bangun_tidur, istirahat, setelah_aktivitas, sebelum_minum_obat, setelah_minum_obat, stres, sedang_sakit")
+		}
+		filter.MeasurementContext = context
+	}
+
+	if req.StartDate != nil {
+		startDate := recordDateOf(*req.StartDate)
+		filter.StartDate = &startDate
+	}
+	if req.EndDate != nil {
+		endDate := recordDateOf(*req.EndDate)
+		filter.EndDate = &endDate
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return nil, errors.New("start_date tidak boleh setelah end_date")
+	}
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	data, total, err := s.healthDataRepo.SearchHealthData(userID, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make([]response.HealthDataResponse, 0, len(data))
+	for i := range data {
+		readings = append(readings, *s.MapHealthDataToResponse(&data[i]))
+	}
+
+	return &response.ReadingSearchResponse{
+		Readings:   readings,
+		Pagination: newPaginationResponse(page, limit, total),
+	}, nil
+}
@@ -11,27 +11,30 @@ import (
 
 // buildReadingHistory membangun catatan pembacaan kronologis dengan nullable-aware
 // Hanya menambahkan history untuk metrik yang benar-benar ada (tidak nil)
-func (s *HealthDataService) buildReadingHistory(data []entity.HealthData) []response.ReadingHistoryResponse {
+// Status dihitung dengan batas klinis milik pemilik data (classifier)
+func (s *HealthDataService) buildReadingHistory(classifier *HealthClassifier, data []entity.HealthData) []response.ReadingHistoryResponse {
 	var history []response.ReadingHistoryResponse
 
-	// Sort by created_at DESC (terbaru ke terlama)
+	// Sort by measured_at DESC (terbaru ke terlama)
 	sortedData := make([]entity.HealthData, len(data))
 	copy(sortedData, data)
 	sort.Slice(sortedData, func(i, j int) bool {
-		return sortedData[i].CreatedAt.After(sortedData[j].CreatedAt)
+		return sortedData[i].MeasuredAt.After(sortedData[j].MeasuredAt)
 	})
 
 		for _, d := range sortedData {
+		readingStart := len(history)
+
 		if d.Systolic != nil && d.Diastolic != nil {
 			systolic := *d.Systolic
 			diastolic := *d.Diastolic
 			history = append(history, response.ReadingHistoryResponse{
 				ID:         d.ID,
-				DateTime:   timezoneUtils.ToJakarta(d.CreatedAt),
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
 				MetricType: "tekanan_darah",
 				Value:      fmt.Sprintf("%d/%d mmHg", systolic, diastolic),
 				Context:    nil,
-				Status:     s.getBloodPressureStatus(systolic, diastolic),
+				Status:     classifier.BloodPressureStatus(systolic, diastolic),
 				Notes:      nil,
 			})
 		}
@@ -39,13 +42,15 @@ func (s *HealthDataService) buildReadingHistory(data []entity.HealthData) []resp
 		// Gula darah (hanya jika ada)
 		if d.BloodSugar != nil {
 			bloodSugar := *d.BloodSugar
+			bloodSugarContext := entity.ResolveBloodSugarContext(d.BloodSugarContext)
+			contextLabel := bloodSugarContext.Label()
 			history = append(history, response.ReadingHistoryResponse{
 				ID:         d.ID,
-				DateTime:   timezoneUtils.ToJakarta(d.CreatedAt),
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
 				MetricType: "gula_darah",
 				Value:      fmt.Sprintf("%d mg/dL", bloodSugar),
-				Context:    nil,
-				Status:     s.getBloodSugarStatus(bloodSugar),
+				Context:    &contextLabel,
+				Status:     classifier.BloodSugarStatus(bloodSugar, bloodSugarContext),
 				Notes:      nil,
 			})
 		}
@@ -55,10 +60,10 @@ func (s *HealthDataService) buildReadingHistory(data []entity.HealthData) []resp
 			weight := *d.Weight
 			heightCM := *d.HeightCM
 			bmi := calculateBMI(weight, heightCM)
-			bmiStatus := s.getBMIStatus(bmi)
+			bmiStatus := classifier.BMIStatus(bmi)
 			history = append(history, response.ReadingHistoryResponse{
 				ID:         d.ID,
-				DateTime:   timezoneUtils.ToJakarta(d.CreatedAt),
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
 				MetricType: "berat_badan",
 				Value:      fmt.Sprintf("%.2f kg (BMI: %.2f)", weight, roundTo2Decimals(bmi)),
 				Context:    nil,
@@ -70,7 +75,7 @@ func (s *HealthDataService) buildReadingHistory(data []entity.HealthData) []resp
 			weight := *d.Weight
 			history = append(history, response.ReadingHistoryResponse{
 				ID:         d.ID,
-				DateTime:   timezoneUtils.ToJakarta(d.CreatedAt),
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
 				MetricType: "berat_badan",
 				Value:      fmt.Sprintf("%.2f kg", weight),
 				Context:    nil,
@@ -84,27 +89,92 @@ func (s *HealthDataService) buildReadingHistory(data []entity.HealthData) []resp
 			heartRate := *d.HeartRate
 			history = append(history, response.ReadingHistoryResponse{
 				ID:         d.ID,
-				DateTime:   timezoneUtils.ToJakarta(d.CreatedAt),
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
 				MetricType: "detak_jantung",
 				Value:      fmt.Sprintf("%d bpm", heartRate),
 				Context:    nil,
-				Status:     s.getHeartRateStatus(heartRate),
+				Status:     classifier.HeartRateStatus(heartRate),
+				Notes:      nil,
+			})
+		}
+
+		// Saturasi oksigen (hanya jika ada)
+		if d.OxygenSaturation != nil {
+			oxygenSaturation := *d.OxygenSaturation
+			history = append(history, response.ReadingHistoryResponse{
+				ID:         d.ID,
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
+				MetricType: "saturasi_oksigen",
+				Value:      fmt.Sprintf("%d%%", oxygenSaturation),
+				Context:    nil,
+				Status:     classifier.OxygenSaturationStatus(oxygenSaturation),
+				Notes:      nil,
+			})
+		}
+
+		// Suhu tubuh (hanya jika ada)
+		if d.Temperature != nil {
+			temperature := *d.Temperature
+			history = append(history, response.ReadingHistoryResponse{
+				ID:         d.ID,
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
+				MetricType: "suhu_tubuh",
+				Value:      fmt.Sprintf("%.1f °C", temperature),
+				Context:    nil,
+				Status:     classifier.TemperatureStatus(temperature),
 				Notes:      nil,
 			})
 		}
 
-		// Aktivitas (jika ada dan tidak kosong)
-		if d.Activity != nil && *d.Activity != "" {
+		// Laju napas (hanya jika ada)
+		if d.RespiratoryRate != nil {
+			respiratoryRate := *d.RespiratoryRate
 			history = append(history, response.ReadingHistoryResponse{
 				ID:         d.ID,
-				DateTime:   timezoneUtils.ToJakarta(d.CreatedAt),
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
+				MetricType: "laju_napas",
+				Value:      fmt.Sprintf("%d napas/menit", respiratoryRate),
+				Context:    nil,
+				Status:     classifier.RespiratoryRateStatus(respiratoryRate),
+				Notes:      nil,
+			})
+		}
+
+		// Aktivitas (terstruktur, atau teks aktivitas lama jika tidak kosong)
+		if activity := formatActivityReading(&d); activity != "" {
+			history = append(history, response.ReadingHistoryResponse{
+				ID:         d.ID,
+				DateTime:   timezoneUtils.ToJakarta(d.MeasuredAt),
 				MetricType: "aktivitas",
-				Value:      *d.Activity,
+				Value:      activity,
 				Context:    nil,
 				Status:     StatusNormal,
 				Notes:      nil,
 			})
 		}
+
+		// Catatan, gejala, dan kondisi pengukuran berlaku untuk semua metrik pada pembacaan ini
+		for i := readingStart; i < len(history); i++ {
+			item := &history[i]
+			applyReadingNotesToHistory(item, &d)
+
+			var baseLabel []string
+			if item.Context != nil {
+				baseLabel = append(baseLabel, *item.Context)
+			}
+			if item.MetricType == "tekanan_darah" {
+				baseLabel = append(baseLabel, bloodPressurePlacementLabels(&d)...)
+				if d.BodyPosition != nil {
+					position := string(*d.BodyPosition)
+					item.BodyPosition = &position
+				}
+				if d.MeasurementArm != nil {
+					arm := string(*d.MeasurementArm)
+					item.MeasurementArm = &arm
+				}
+			}
+			item.Context = readingContextLabel(&d, baseLabel...)
+		}
 	}
 
 	return history
@@ -2,6 +2,7 @@ package service
 
 import (
 	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
@@ -13,6 +14,50 @@ import (
 	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
 )
 
+// Format laporan yang didukung endpoint download
+const (
+	ReportFormatPDF  = "pdf"
+	ReportFormatCSV  = "csv"
+	ReportFormatJSON = "json"
+)
+
+// reportContentTypes memetakan format laporan ke Content-Type response
+var reportContentTypes = map[string]string{
+	ReportFormatPDF:  "application/pdf",
+	ReportFormatCSV:  "text/csv; charset=utf-8",
+	ReportFormatJSON: "application/json; charset=utf-8",
+}
+
+// IsValidReportFormat memeriksa apakah format laporan didukung
+func IsValidReportFormat(format string) bool {
+	_, ok := reportContentTypes[format]
+	return ok
+}
+
+// GenerateReport menghasilkan laporan sesuai format (pdf, csv, json)
+// Mengembalikan isi file, nama file, dan Content-Type
+func (s *HealthDataService) GenerateReport(userID uint, req *request.HealthHistoryRequest, format string) (*bytes.Buffer, string, string, error) {
+	var buf *bytes.Buffer
+	var filename string
+	var err error
+
+	switch format {
+	case ReportFormatCSV:
+		buf, filename, err = s.GenerateReportCSV(userID, req)
+	case ReportFormatJSON:
+		buf, filename, err = s.GenerateReportJSON(userID, req)
+	case ReportFormatPDF:
+		buf, filename, err = s.GenerateReportPDF(userID, req)
+	default:
+		return nil, "", "", fmt.Errorf("format laporan %s tidak didukung", format)
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return buf, filename, reportContentTypes[format], nil
+}
+
 // UserProfileInfo berisi informasi profil user untuk laporan
 type UserProfileInfo struct {
 	Name   string
@@ -39,7 +84,7 @@ func (s *HealthDataService) getUserProfileInfo(userID uint) (*UserProfileInfo, e
 	}
 
 	// Ambil tinggi badan dari health data terbaru
-	latestHealthData, err := s.healthDataRepo.GetLatestHealthDataByUserID(userID)
+	latestHealthData, err := s.healthDataRepo.GetLatestHealthSnapshotByUserID(userID)
 	if err == nil && latestHealthData != nil && latestHealthData.HeightCM != nil {
 		profile.Height = latestHealthData.HeightCM
 	}
@@ -77,7 +122,7 @@ func (s *HealthDataService) GenerateReportCSV(userID uint, req *request.HealthHi
 	writer := csv.NewWriter(&buf)
 
 	// Tentukan rentang waktu untuk nama file
-	startDate, endDate, _ := s.parseTimeRange(req)
+	startDate, endDate, _ := parseTimeRange(req)
 	timeRangeStr := fmt.Sprintf("%s_to_%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
 	filename := fmt.Sprintf("riwayat_kesehatan_%s.csv", timeRangeStr)
 
@@ -101,6 +146,7 @@ func (s *HealthDataService) GenerateReportCSV(userID uint, req *request.HealthHi
 		"Status",
 		"Konteks",
 		"Catatan",
+		"Gejala",
 	}
 	if err := writer.Write(headers); err != nil {
 		return nil, "", err
@@ -124,6 +170,7 @@ func (s *HealthDataService) GenerateReportCSV(userID uint, req *request.HealthHi
 			record.Status,
 			context,
 			notes,
+			strings.Join(symptomLabels(record.Symptoms), ", "),
 		}
 		if err := writer.Write(row); err != nil {
 			return nil, "", err
@@ -154,6 +201,9 @@ func (s *HealthDataService) GenerateReportCSV(userID uint, req *request.HealthHi
 		writer.Write([]string{"Persentase Perubahan", fmt.Sprintf("%.2f%%", historyResp.Summary.BloodSugar.ChangePercent)})
 		writer.Write([]string{"Status", historyResp.Summary.BloodSugar.Status})
 		writer.Write([]string{"Rentang Normal", historyResp.Summary.BloodSugar.NormalRange})
+		for _, ctx := range historyResp.Summary.BloodSugar.ByContext {
+			writer.Write([]string{fmt.Sprintf("Gula Darah %s", ctx.Label), fmt.Sprintf("%.2f mg/dL (%d pembacaan)", ctx.AvgValue, ctx.Count), ctx.Status, ctx.NormalRange})
+		}
 	}
 
 	// Berat Badan
@@ -174,9 +224,93 @@ func (s *HealthDataService) GenerateReportCSV(userID uint, req *request.HealthHi
 		writer.Write([]string{"AKTIVITAS"})
 		writer.Write([]string{"Total Langkah", fmt.Sprintf("%d", historyResp.Summary.Activity.TotalSteps)})
 		writer.Write([]string{"Total Kalori", fmt.Sprintf("%.2f", historyResp.Summary.Activity.TotalCalories)})
+		writer.Write([]string{"Total Durasi", fmt.Sprintf("%d menit", historyResp.Summary.Activity.TotalDurationMinutes)})
+		writer.Write([]string{"Total Jarak", fmt.Sprintf("%.2f km", historyResp.Summary.Activity.TotalDistanceKm)})
+		writer.Write([]string{"Hari Aktif", fmt.Sprintf("%d", historyResp.Summary.Activity.ActiveDays)})
+		writer.Write([]string{"Jumlah Aktivitas", fmt.Sprintf("%d", historyResp.Summary.Activity.SessionCount)})
 		writer.Write([]string{"Persentase Perubahan", fmt.Sprintf("%.2f%%", historyResp.Summary.Activity.ChangePercent)})
 	}
 
+	// Tanda Vital
+	vitalSigns := []struct {
+		title   string
+		unit    string
+		summary *response.VitalSignSummary
+	}{
+		{"SATURASI OKSIGEN (SpO2)", "%", historyResp.Summary.OxygenSaturation},
+		{"SUHU TUBUH", "°C", historyResp.Summary.Temperature},
+		{"LAJU NAPAS", "napas/menit", historyResp.Summary.RespiratoryRate},
+	}
+	for _, vital := range vitalSigns {
+		if vital.summary == nil {
+			continue
+		}
+		writer.Write([]string{""})
+		writer.Write([]string{vital.title})
+		writer.Write([]string{"Rata-rata", fmt.Sprintf("%.2f %s", vital.summary.AvgValue, vital.unit)})
+		writer.Write([]string{"Terendah", fmt.Sprintf("%.1f %s", vital.summary.MinValue, vital.unit)})
+		writer.Write([]string{"Tertinggi", fmt.Sprintf("%.1f %s", vital.summary.MaxValue, vital.unit)})
+		writer.Write([]string{"Persentase Perubahan", fmt.Sprintf("%.2f%%", vital.summary.ChangePercent)})
+		writer.Write([]string{"Status", vital.summary.Status})
+		writer.Write([]string{"Rentang Normal", vital.summary.NormalRange})
+	}
+
+	// Hasil Laboratorium
+	labResults, err := s.getReportLabResults(userID, req, startDate, endDate)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(labResults) > 0 {
+		writer.Write([]string{""})
+		writer.Write([]string{"=== HASIL LABORATORIUM ==="})
+		writer.Write([]string{"Tanggal Pemeriksaan", "Pemeriksaan", "Nilai", "Rentang Rujukan", "Status", "Interpretasi", "Laboratorium"})
+		for _, labResult := range labResults {
+			writer.Write([]string{
+				labResult.TestedAt.Format("2006-01-02 15:04:05"),
+				labResult.Label,
+				fmt.Sprintf("%s %s", formatLabValue(labResult.Value), labResult.Unit),
+				labResult.NormalRange,
+				labResult.Status,
+				stringValue(labResult.Interpretation),
+				stringValue(labResult.LabName),
+			})
+		}
+	}
+
+	// Kepatuhan Minum Obat
+	if adherence := historyResp.MedicationAdherence; adherence != nil {
+		writer.Write([]string{""})
+		writer.Write([]string{"=== KEPATUHAN MINUM OBAT ==="})
+		writer.Write([]string{"Kepatuhan", formatAdherencePercent(adherence.AdherencePercent), adherence.Status})
+		writer.Write([]string{"Dosis Diminum", fmt.Sprintf("%d dari %d jadwal", adherence.TakenDoses, adherence.ExpectedDoses)})
+		writer.Write([]string{""})
+		writer.Write([]string{"Obat", "Dosis", "Jadwal", "Diminum", "Terlewat", "Tidak Dicatat", "Kepatuhan", "Status"})
+		for _, medication := range adherence.Medications {
+			writer.Write([]string{
+				medication.Name,
+				medication.Dose,
+				fmt.Sprintf("%d", medication.ExpectedDoses),
+				fmt.Sprintf("%d", medication.TakenDoses),
+				fmt.Sprintf("%d", medication.MissedDoses),
+				fmt.Sprintf("%d", medication.UnloggedDoses),
+				formatAdherencePercent(medication.AdherencePercent),
+				medication.Status,
+			})
+		}
+		writer.Write([]string{""})
+		writer.Write([]string{"Metrik", "Rata-rata Hari Patuh", "Rata-rata Hari Tidak Patuh", "Selisih", "Koefisien Korelasi", "Interpretasi"})
+		for _, correlation := range adherence.Correlations {
+			writer.Write([]string{
+				correlation.Metric,
+				formatOptionalMetric(correlation.AvgOnAdherentDays, correlation.Unit),
+				formatOptionalMetric(correlation.AvgOnNonAdherent, correlation.Unit),
+				formatOptionalMetric(correlation.Difference, correlation.Unit),
+				formatOptionalCoefficient(correlation.Coefficient),
+				correlation.Interpretation,
+			})
+		}
+	}
+
 	writer.Flush()
 	return &buf, filename, nil
 }
@@ -193,7 +327,7 @@ func (s *HealthDataService) GenerateReportJSON(userID uint, req *request.HealthH
 	profileInfo, _ := s.getUserProfileInfo(userID)
 
 	// Tentukan rentang waktu untuk nama file
-	startDate, endDate, _ := s.parseTimeRange(req)
+	startDate, endDate, _ := parseTimeRange(req)
 	timeRangeStr := fmt.Sprintf("%s_to_%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
 	filename := fmt.Sprintf("riwayat_kesehatan_%s.json", timeRangeStr)
 
@@ -222,6 +356,20 @@ func (s *HealthDataService) GenerateReportJSON(userID uint, req *request.HealthH
 		"generated_at":        timezoneUtils.NowInJakarta().Format("2006-01-02 15:04:05"),
 	}
 
+	// Hasil laboratorium pada periode laporan
+	labResults, err := s.getReportLabResults(userID, req, startDate, endDate)
+	if err != nil {
+		return nil, "", err
+	}
+	if labResults != nil {
+		report["hasil_laboratorium"] = labResults
+	}
+
+	// Kepatuhan minum obat dan korelasinya dengan tekanan darah & gula darah
+	if historyResp.MedicationAdherence != nil {
+		report["kepatuhan_minum_obat"] = historyResp.MedicationAdherence
+	}
+
 	// Marshal ke JSON dengan indent
 	jsonData, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
@@ -246,7 +394,7 @@ func (s *HealthDataService) GenerateReportPDF(userID uint, req *request.HealthHi
 	profileInfo, _ := s.getUserProfileInfo(userID)
 
 	// Tentukan rentang waktu untuk nama file
-	startDate, endDate, _ := s.parseTimeRange(req)
+	startDate, endDate, _ := parseTimeRange(req)
 	timeRangeStr := fmt.Sprintf("%s_to_%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
 	filename := fmt.Sprintf("riwayat_kesehatan_%s.pdf", timeRangeStr)
 
@@ -274,6 +422,14 @@ func (s *HealthDataService) GenerateReportPDF(userID uint, req *request.HealthHi
 
 	// Helper function untuk draw tabel formal dengan border
 	drawFormalTable := func(headers []string, rows [][]string, colWidths []float64) {
+		// Konversi teks UTF-8 (mis. simbol derajat pada suhu tubuh) ke encoding font inti PDF
+		tr := pdf.UnicodeTranslatorFromDescriptor("")
+		for i := range rows {
+			for j := range rows[i] {
+				rows[i][j] = tr(rows[i][j])
+			}
+		}
+
 		startX := pdf.GetX()
 		startY := pdf.GetY()
 		headerHeight := 10.0
@@ -529,6 +685,14 @@ func (s *HealthDataService) GenerateReportPDF(userID uint, req *request.HealthHi
 			"",
 			"",
 		})
+		for _, ctx := range historyResp.Summary.BloodSugar.ByContext {
+			summaryRows = append(summaryRows, []string{
+				"",
+				fmt.Sprintf("%s: %.1f mg/dL", ctx.Label, ctx.AvgValue),
+				fmt.Sprintf("Status: %s", ctx.Status),
+				fmt.Sprintf("Rentang Normal: %s", ctx.NormalRange),
+			})
+		}
 		summaryRows = append(summaryRows, []string{"", "", "", ""}) // Spacer
 	}
 
@@ -561,6 +725,41 @@ func (s *HealthDataService) GenerateReportPDF(userID uint, req *request.HealthHi
 			fmt.Sprintf("Total Kalori: %.0f kkal", historyResp.Summary.Activity.TotalCalories),
 			fmt.Sprintf("Perubahan: %.1f%%", historyResp.Summary.Activity.ChangePercent),
 		})
+		summaryRows = append(summaryRows, []string{
+			"",
+			fmt.Sprintf("Total Durasi: %s menit", formatNumber(historyResp.Summary.Activity.TotalDurationMinutes)),
+			fmt.Sprintf("Total Jarak: %.1f km", historyResp.Summary.Activity.TotalDistanceKm),
+			fmt.Sprintf("Hari Aktif: %d", historyResp.Summary.Activity.ActiveDays),
+		})
+	}
+
+	// Tanda Vital
+	vitalSigns := []struct {
+		name    string
+		unit    string
+		summary *response.VitalSignSummary
+	}{
+		{"Saturasi Oksigen", "%", historyResp.Summary.OxygenSaturation},
+		{"Suhu Tubuh", "°C", historyResp.Summary.Temperature},
+		{"Laju Napas", "napas/menit", historyResp.Summary.RespiratoryRate},
+	}
+	for _, vital := range vitalSigns {
+		if vital.summary == nil {
+			continue
+		}
+		summaryRows = append(summaryRows, []string{"", "", "", ""}) // Spacer
+		summaryRows = append(summaryRows, []string{
+			vital.name,
+			fmt.Sprintf("Rata-rata: %.1f %s", vital.summary.AvgValue, vital.unit),
+			fmt.Sprintf("Status: %s", vital.summary.Status),
+			fmt.Sprintf("Rentang Normal: %s", vital.summary.NormalRange),
+		})
+		summaryRows = append(summaryRows, []string{
+			"",
+			fmt.Sprintf("Min-Maks: %.1f-%.1f %s", vital.summary.MinValue, vital.summary.MaxValue, vital.unit),
+			fmt.Sprintf("Perubahan: %.1f%%", vital.summary.ChangePercent),
+			"",
+		})
 	}
 
 	// Draw tabel ringkasan
@@ -571,6 +770,98 @@ func (s *HealthDataService) GenerateReportPDF(userID uint, req *request.HealthHi
 		pdf.Ln(10)
 	}
 
+	// ========== HASIL LABORATORIUM ==========
+	labResults, err := s.getReportLabResults(userID, req, startDate, endDate)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(labResults) > 0 {
+		// Cek jika perlu halaman baru
+		if pdf.GetY() > 250 {
+			pdf.AddPage()
+		} else {
+			pdf.Ln(5)
+		}
+
+		pdf.SetFont("Arial", "B", 14)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Cell(170, 10, "HASIL LABORATORIUM")
+		pdf.Ln(12)
+
+		var labRows [][]string
+		for _, labResult := range labResults {
+			labRows = append(labRows, []string{
+				labResult.TestedAt.Format("02/01/2006"),
+				labResult.Label,
+				fmt.Sprintf("%s %s", formatLabValue(labResult.Value), labResult.Unit),
+				labResult.NormalRange,
+				labResult.Status,
+				stringValue(labResult.Interpretation),
+			})
+		}
+
+		headers := []string{"Tanggal", "Pemeriksaan", "Nilai", "Rentang Rujukan", "Status", "Interpretasi"}
+		colWidths := []float64{25, 35, 27, 33, 22, 28}
+		drawFormalTable(headers, labRows, colWidths)
+		pdf.Ln(10)
+	}
+
+	// ========== KEPATUHAN MINUM OBAT ==========
+	if adherence := historyResp.MedicationAdherence; adherence != nil {
+		// Cek jika perlu halaman baru
+		if pdf.GetY() > 230 {
+			pdf.AddPage()
+		} else {
+			pdf.Ln(5)
+		}
+
+		pdf.SetFont("Arial", "B", 14)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Cell(170, 10, "KEPATUHAN MINUM OBAT")
+		pdf.Ln(10)
+
+		pdf.SetFont("Arial", "", 10)
+		pdf.Cell(170, 6, fmt.Sprintf("Kepatuhan keseluruhan: %s (%s) - %d dari %d jadwal diminum",
+			formatAdherencePercent(adherence.AdherencePercent), adherence.Status, adherence.TakenDoses, adherence.ExpectedDoses))
+		pdf.Ln(9)
+
+		var medicationRows [][]string
+		for _, medication := range adherence.Medications {
+			medicationRows = append(medicationRows, []string{
+				medication.Name,
+				medication.Dose,
+				fmt.Sprintf("%d/%d", medication.TakenDoses, medication.ExpectedDoses),
+				formatAdherencePercent(medication.AdherencePercent),
+				medication.Status,
+			})
+		}
+		headers := []string{"Obat", "Dosis", "Diminum/Jadwal", "Kepatuhan", "Status"}
+		colWidths := []float64{50, 30, 32, 28, 30}
+		drawFormalTable(headers, medicationRows, colWidths)
+		pdf.Ln(6)
+
+		var correlationRows [][]string
+		for _, correlation := range adherence.Correlations {
+			correlationRows = append(correlationRows, []string{
+				correlation.Metric,
+				formatOptionalMetric(correlation.AvgOnAdherentDays, correlation.Unit),
+				formatOptionalMetric(correlation.AvgOnNonAdherent, correlation.Unit),
+				formatOptionalMetric(correlation.Difference, correlation.Unit),
+				formatOptionalCoefficient(correlation.Coefficient),
+			})
+		}
+		headers = []string{"Metrik", "Hari Patuh", "Hari Tidak Patuh", "Selisih", "Korelasi"}
+		colWidths = []float64{34, 34, 34, 34, 34}
+		drawFormalTable(headers, correlationRows, colWidths)
+		pdf.Ln(4)
+
+		pdf.SetFont("Arial", "", 9)
+		for _, correlation := range adherence.Correlations {
+			pdf.MultiCell(170, 5, "- "+correlation.Interpretation, "", "L", false)
+		}
+		pdf.Ln(6)
+	}
+
 	// ========== CATATAN PEMBACAAN ==========
 	if len(historyResp.ReadingHistory) > 0 {
 		// Cek jika perlu halaman baru
@@ -597,10 +888,7 @@ func (s *HealthDataService) GenerateReportPDF(userID uint, req *request.HealthHi
 				context = *record.Context
 			}
 
-			notes := ""
-			if record.Notes != nil && *record.Notes != "" {
-				notes = *record.Notes
-			}
+			notes := formatReadingNotes(record)
 
 			readingRows = append(readingRows, []string{
 				dateTime,
@@ -614,7 +902,7 @@ func (s *HealthDataService) GenerateReportPDF(userID uint, req *request.HealthHi
 
 		// Draw tabel formal
 		headers := []string{"Tanggal & Waktu", "Jenis Metrik", "Nilai", "Status", "Konteks", "Catatan"}
-		colWidths := []float64{38, 32, 25, 28, 22, 25} // Lebar kolom disesuaikan agar teks tidak bocor
+		colWidths := []float64{28, 26, 25, 20, 33, 38} // Lebar kolom disesuaikan agar teks tidak bocor (konteks dan catatan dapat multi-baris)
 		drawFormalTable(headers, readingRows, colWidths)
 	}
 
@@ -637,6 +925,50 @@ func (s *HealthDataService) GenerateReportPDF(userID uint, req *request.HealthHi
 	return &buf, filename, nil
 }
 
+// getReportLabResults mengambil hasil laboratorium pada periode laporan
+// Hasil lab tidak disertakan jika filter metrics dikirim tanpa "hasil_lab"
+func (s *HealthDataService) getReportLabResults(userID uint, req *request.HealthHistoryRequest, startDate, endDate time.Time) ([]response.LabResultResponse, error) {
+	if s.labResultService == nil {
+		return nil, nil
+	}
+	if len(req.Metrics) > 0 && !s.containsMetric(req.Metrics, "hasil_lab") {
+		return nil, nil
+	}
+	return s.labResultService.GetLabResultsForPeriod(userID, startDate, endDate)
+}
+
+// formatAdherencePercent memformat persentase kepatuhan minum obat untuk laporan
+func formatAdherencePercent(percent *float64) string {
+	if percent == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *percent)
+}
+
+// formatOptionalMetric memformat nilai metrik opsional beserta satuannya untuk laporan
+func formatOptionalMetric(value *float64, unit string) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f %s", *value, unit)
+}
+
+// formatOptionalCoefficient memformat koefisien korelasi opsional untuk laporan
+func formatOptionalCoefficient(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *value)
+}
+
+// stringValue mengembalikan isi string opsional atau string kosong jika nil
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
 // formatNumber memformat angka dengan separator ribuan
 func formatNumber(n int) string {
 	str := fmt.Sprintf("%d", n)
@@ -6,106 +6,316 @@ import (
 	"BE-PeriksaKesehatan/internal/model/entity"
 	"BE-PeriksaKesehatan/internal/repository"
 	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"BE-PeriksaKesehatan/pkg/utils"
 	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
 )
 
+const (
+	// maxBackdateDays adalah batas maksimal input pembacaan ke belakang (hari)
+	maxBackdateDays = 365
+
+	// measuredAtClockSkew adalah toleransi perbedaan jam perangkat user dengan server
+	measuredAtClockSkew = 5 * time.Minute
+)
+
 // HealthDataService menangani business logic untuk data kesehatan
 type HealthDataService struct {
-	healthDataRepo   *repository.HealthDataRepository
-	personalInfoRepo *repository.PersonalInfoRepository
+	healthDataRepo           *repository.HealthDataRepository
+	personalInfoRepo         *repository.PersonalInfoRepository
+	healthAlertService       *HealthAlertService
+	clinicalThresholdService *ClinicalThresholdService
+	labResultService         *LabResultService
+	medicationService        *MedicationService
+	healthTargetRepo         *repository.HealthTargetRepository
+	notificationService      *NotificationService
 }
 
 // NewHealthDataService membuat instance baru dari HealthDataService
-func NewHealthDataService(healthDataRepo *repository.HealthDataRepository, personalInfoRepo *repository.PersonalInfoRepository) *HealthDataService {
+func NewHealthDataService(healthDataRepo *repository.HealthDataRepository, personalInfoRepo *repository.PersonalInfoRepository, healthAlertService *HealthAlertService, clinicalThresholdService *ClinicalThresholdService, labResultService *LabResultService, medicationService *MedicationService, healthTargetRepo *repository.HealthTargetRepository, notificationService *NotificationService) *HealthDataService {
 	return &HealthDataService{
-		healthDataRepo:   healthDataRepo,
-		personalInfoRepo: personalInfoRepo,
+		healthDataRepo:           healthDataRepo,
+		personalInfoRepo:         personalInfoRepo,
+		healthAlertService:       healthAlertService,
+		clinicalThresholdService: clinicalThresholdService,
+		labResultService:         labResultService,
+		medicationService:        medicationService,
+		healthTargetRepo:         healthTargetRepo,
+		notificationService:      notificationService,
 	}
 }
 
+// CreateHealthData menyimpan satu pembacaan data kesehatan baru
+// Setiap request membuat record baru (tidak menimpa pembacaan sebelumnya di hari yang sama),
+// sehingga pengukuran pagi dan malam tersimpan sebagai pembacaan terpisah.
 func (s *HealthDataService) CreateHealthData(userID uint, req *request.HealthDataRequest) (*response.HealthDataResponse, error) {
-	// Ambil CURRENT_DATE (hari ini) dalam timezone Asia/Jakarta
-	currentDate := timezoneUtils.NowInJakarta()
-	
-	// Cari record dengan record_date = hari ini
-	existingData, err := s.healthDataRepo.GetHealthDataByUserIDAndDate(userID, currentDate)
+	// Pembacaan kosong tidak disimpan: minimal satu metrik atau aktivitas harus diisi
+	if req.Activity == nil {
+		if err := utils.RequireAtLeastOneHealthMetric(
+			req.Systolic, req.Diastolic, req.BloodSugar, nil, req.HeartRate, req.Weight, req.Height,
+			req.OxygenSaturation, req.RespiratoryRate, req.Temperature,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validasi field yang dikirim
+	if err := s.validateHealthDataFields(req); err != nil {
+		return nil, err
+	}
+	if req.BloodSugarContext != nil && req.BloodSugar == nil {
+		return nil, errors.New("blood_sugar_context harus dikirim bersamaan dengan blood_sugar")
+	}
+	if hasBloodPressurePlacement(req) && req.Systolic == nil {
+		return nil, errors.New("body_position dan measurement_arm harus dikirim bersamaan dengan systolic dan diastolic")
+	}
+
+	// Waktu pengukuran: dari request (backdate) atau sekarang dalam timezone Asia/Jakarta
+	measuredAt, err := s.resolveMeasuredAt(req.MeasuredAt)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validasi field yang dikirim (hanya sekali untuk INSERT dan UPDATE)
-	if err := s.validateHealthDataFields(req); err != nil {
+	healthData := &entity.HealthData{
+		UserID:     userID,
+		MeasuredAt: measuredAt,
+		RecordDate: recordDateOf(measuredAt),
+	}
+
+	// Set field yang dikirim (field yang tidak dikirim tetap NULL)
+	s.updateHealthDataFields(healthData, req)
+	clearEmptyReadingNotes(healthData)
+	if healthData.BloodSugar != nil && healthData.BloodSugarContext == nil {
+		context := entity.BloodSugarContextRandom
+		healthData.BloodSugarContext = &context
+	}
+	s.applyActivityCalories(healthData, userID, healthData, healthData.Weight, measuredAt)
+
+	if err := s.healthDataRepo.CreateHealthData(healthData); err != nil {
 		return nil, err
 	}
 
-	var healthData *entity.HealthData
+	s.syncHealthAlerts(userID, healthData.RecordDate)
+	s.notifyTargetAchievements(healthData)
 
-	if existingData != nil {
-		// UPDATE: Partial update pada record hari ini
-		// Field yang tidak dikirim (nil) TIDAK DIUBAH
-		healthData = existingData
+	return s.MapHealthDataToResponse(healthData), nil
+}
 
-		// Update hanya field yang dikirim (tidak nil)
-		s.updateHealthDataFields(healthData, req)
+// UpdateHealthData mengubah pembacaan milik user (partial update)
+// Field yang tidak dikirim (nil) tidak diubah. Jika measured_at berubah ke hari lain,
+// alert pada tanggal lama dan tanggal baru dievaluasi ulang.
+func (s *HealthDataService) UpdateHealthData(userID, healthDataID uint, req *request.HealthDataRequest) (*response.HealthDataResponse, error) {
+	healthData, err := s.healthDataRepo.GetHealthDataByIDAndUserID(healthDataID, userID)
+	if err != nil {
+		return nil, err
+	}
 
-		// Lakukan update (partial update - hanya field yang tidak nil)
-		if err := s.healthDataRepo.UpdateHealthData(healthData); err != nil {
+	// Pembacaan boleh diubah tanpa metrik jika hanya aktivitas, waktu, konteks, atau catatan yang dikoreksi
+	if req.Activity == nil && req.MeasuredAt == nil && req.BloodSugarContext == nil && !hasReadingNotes(req) {
+		if err := utils.RequireAtLeastOneHealthMetric(
+			req.Systolic, req.Diastolic, req.BloodSugar, nil, req.HeartRate, req.Weight, req.Height,
+			req.OxygenSaturation, req.RespiratoryRate, req.Temperature,
+		); err != nil {
 			return nil, err
 		}
+	}
 
-		// Reload data untuk mendapatkan updated_at terbaru
-		healthData, err = s.healthDataRepo.GetHealthDataByID(healthData.ID)
+	if err := s.validateHealthDataFields(req); err != nil {
+		return nil, err
+	}
+	// Konteks gula darah boleh diubah sendiri jika pembacaan sudah berisi gula darah
+	if req.BloodSugarContext != nil && req.BloodSugar == nil && healthData.BloodSugar == nil {
+		return nil, errors.New("blood_sugar_context harus dikirim bersamaan dengan blood_sugar")
+	}
+	// Posisi tubuh dan lengan boleh diubah sendiri jika pembacaan sudah berisi tekanan darah
+	if hasBloodPressurePlacement(req) && req.Systolic == nil && healthData.Systolic == nil {
+		return nil, errors.New("body_position dan measurement_arm harus dikirim bersamaan dengan systolic dan diastolic")
+	}
+
+	previousRecordDate := healthData.RecordDate
+
+	// Kosongkan struct agar repository hanya meng-update field yang dikirim
+	changes := &entity.HealthData{ID: healthData.ID}
+	s.updateHealthDataFields(changes, req)
+	if req.MeasuredAt != nil {
+		measuredAt, err := s.resolveMeasuredAt(req.MeasuredAt)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		// INSERT: Buat record baru untuk hari ini
-		// User boleh mengirim 1 field saja atau bahkan kosong (semua NULL)
-
-		// Set record_date = hari ini
-		// Set expired_at = hari ini 23:59:59 (dalam timezone Asia/Jakarta)
-		expiredAt := timezoneUtils.DateInJakarta(currentDate.Year(), currentDate.Month(), currentDate.Day(), 23, 59, 59, 0)
+		changes.MeasuredAt = measuredAt
+	}
 
-		healthData = &entity.HealthData{
-			UserID:     userID,
-			RecordDate: currentDate,
-			ExpiredAt:  &expiredAt,
+	// Kalori aktivitas dihitung ulang jika aktivitas atau berat badan pada pembacaan berubah
+	if req.Activity != nil || (req.Weight != nil && hasStructuredActivity(healthData)) {
+		activity := healthData
+		if req.Activity != nil {
+			activity = changes
 		}
+		weight := healthData.Weight
+		if req.Weight != nil {
+			weight = req.Weight
+		}
+		measuredAt := healthData.MeasuredAt
+		if !changes.MeasuredAt.IsZero() {
+			measuredAt = changes.MeasuredAt
+		}
+		s.applyActivityCalories(changes, userID, activity, weight, measuredAt)
+	}
 
-		// Set field yang dikirim (field yang tidak dikirim tetap NULL)
-		s.updateHealthDataFields(healthData, req)
+	if err := s.healthDataRepo.UpdateHealthData(changes); err != nil {
+		return nil, err
+	}
 
-		if err := s.healthDataRepo.CreateHealthData(healthData); err != nil {
-			return nil, err
+	// Reload data untuk mendapatkan nilai dan updated_at terbaru
+	healthData, err = s.healthDataRepo.GetHealthDataByID(healthData.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syncHealthAlerts(userID, previousRecordDate, healthData.RecordDate)
+
+	return s.MapHealthDataToResponse(healthData), nil
+}
+
+// DeleteHealthData menghapus pembacaan milik user dan mengevaluasi ulang alert pada tanggal tersebut
+func (s *HealthDataService) DeleteHealthData(userID, healthDataID uint) error {
+	healthData, err := s.healthDataRepo.GetHealthDataByIDAndUserID(healthDataID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.healthDataRepo.DeleteHealthData(healthData.ID); err != nil {
+		return err
+	}
+
+	s.syncHealthAlerts(userID, healthData.RecordDate)
+
+	return nil
+}
+
+// syncHealthAlerts menyelaraskan alert tersimpan untuk tanggal-tanggal yang terdampak perubahan data.
+// Kegagalan menyimpan alert tidak membatalkan perubahan data kesehatan.
+func (s *HealthDataService) syncHealthAlerts(userID uint, recordDates ...time.Time) {
+	if s.healthAlertService == nil {
+		return
+	}
+
+	synced := make(map[string]bool)
+	for _, recordDate := range recordDates {
+		dateStr := timezoneUtils.ToJakarta(recordDate).Format("2006-01-02")
+		if synced[dateStr] {
+			continue
+		}
+		synced[dateStr] = true
+
+		if err := s.healthAlertService.SyncHealthAlertsForDate(userID, recordDate); err != nil {
+			log.Printf("[HealthAlert] Warning: gagal menyelaraskan alert user %d tanggal %s: %v", userID, dateStr, err)
 		}
 	}
+}
 
-	// Build response
-	resp := &response.HealthDataResponse{
-		ID:        healthData.ID,
-		UserID:    healthData.UserID,
-		CreatedAt: timezoneUtils.ToJakarta(healthData.CreatedAt),
+// resolveMeasuredAt menentukan waktu pengukuran dari request
+// Jika tidak dikirim, gunakan waktu saat ini. Waktu pengukuran tidak boleh di masa depan
+// dan maksimal maxBackdateDays hari ke belakang.
+func (s *HealthDataService) resolveMeasuredAt(measuredAt *time.Time) (time.Time, error) {
+	now := timezoneUtils.NowInJakarta()
+	if measuredAt == nil {
+		return now, nil
 	}
 
-	// Set semua field (termasuk yang nil) untuk response
-	resp.Systolic = healthData.Systolic
-	resp.Diastolic = healthData.Diastolic
-	resp.BloodSugar = healthData.BloodSugar
-	resp.Weight = healthData.Weight
-	resp.Height = healthData.HeightCM
-	resp.HeartRate = healthData.HeartRate
-	resp.Activity = healthData.Activity
+	t := timezoneUtils.ToJakarta(*measuredAt)
+	if t.After(now.Add(measuredAtClockSkew)) {
+		return time.Time{}, errors.New("measured_at tidak boleh di masa depan")
+	}
+	if t.Before(now.AddDate(0, 0, -maxBackdateDays)) {
+		return time.Time{}, fmt.Errorf("measured_at maksimal %d hari ke belakang", maxBackdateDays)
+	}
 
-	return resp, nil
+	return t, nil
 }
 
-// GetHealthDataByUserID mengembalikan 1 record health data untuk hari ini milik user
-// Menggunakan daily record system: mengembalikan record dengan record_date = CURRENT_DATE
+// recordDateOf mengembalikan tanggal (00:00 Asia/Jakarta) dari waktu pengukuran
+func recordDateOf(measuredAt time.Time) time.Time {
+	t := timezoneUtils.ToJakarta(measuredAt)
+	return timezoneUtils.DateInJakarta(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0)
+}
+
+// GetHealthDataByUserID mengembalikan snapshot data kesehatan terbaru milik user
+// Setiap metrik berisi nilai terakhir yang pernah dicatat (bisa dari pembacaan yang berbeda)
 func (s *HealthDataService) GetHealthDataByUserID(userID uint) (*entity.HealthData, error) {
-	currentDate := timezoneUtils.NowInJakarta()
-	return s.healthDataRepo.GetHealthDataByUserIDAndDate(userID, currentDate)
+	return s.healthDataRepo.GetLatestHealthSnapshotByUserID(userID)
+}
+
+// GetHealthReadingsByDate mengembalikan semua pembacaan user pada tanggal tertentu
+// Jika date nil, gunakan tanggal hari ini (Asia/Jakarta)
+func (s *HealthDataService) GetHealthReadingsByDate(userID uint, date *time.Time) (*response.HealthReadingsResponse, error) {
+	targetDate := timezoneUtils.NowInJakarta()
+	if date != nil {
+		targetDate = timezoneUtils.ToJakarta(*date)
+	}
+
+	readings, err := s.healthDataRepo.GetHealthDataListByUserIDAndDate(userID, targetDate)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.HealthReadingsResponse{
+		Date:     targetDate.Format("2006-01-02"),
+		Readings: make([]response.HealthDataResponse, 0, len(readings)),
+	}
+	for i := range readings {
+		resp.Readings = append(resp.Readings, *s.MapHealthDataToResponse(&readings[i]))
+	}
+
+	return resp, nil
+}
+
+// MapHealthDataToResponse mengubah entity HealthData ke response DTO
+func (s *HealthDataService) MapHealthDataToResponse(healthData *entity.HealthData) *response.HealthDataResponse {
+	var bloodSugarContext *string
+	if healthData.BloodSugarContext != nil {
+		context := string(*healthData.BloodSugarContext)
+		bloodSugarContext = &context
+	}
+	var bodyPosition, measurementArm, measurementContext *string
+	if healthData.BodyPosition != nil {
+		position := string(*healthData.BodyPosition)
+		bodyPosition = &position
+	}
+	if healthData.MeasurementArm != nil {
+		arm := string(*healthData.MeasurementArm)
+		measurementArm = &arm
+	}
+	if healthData.MeasurementContext != nil {
+		context := string(*healthData.MeasurementContext)
+		measurementContext = &context
+	}
+
+	return &response.HealthDataResponse{
+		ID:         healthData.ID,
+		UserID:     healthData.UserID,
+		Systolic:   healthData.Systolic,
+		Diastolic:  healthData.Diastolic,
+		BloodSugar: healthData.BloodSugar,
+		BloodSugarContext: bloodSugarContext,
+		Weight:     healthData.Weight,
+		Height:     healthData.HeightCM,
+		HeartRate:  healthData.HeartRate,
+		OxygenSaturation: healthData.OxygenSaturation,
+		Temperature:      healthData.Temperature,
+		RespiratoryRate:  healthData.RespiratoryRate,
+		Activity:   mapActivityToResponse(healthData),
+		Notes:      healthData.Notes,
+		BodyPosition:       bodyPosition,
+		MeasurementArm:     measurementArm,
+		Symptoms:           healthData.SymptomList(),
+		MeasurementContext: measurementContext,
+		MeasuredAt: timezoneUtils.ToJakarta(healthData.MeasuredAt),
+		CreatedAt:  timezoneUtils.ToJakarta(healthData.CreatedAt),
+	}
 }
 
 // validateHealthDataFields melakukan validasi field health data yang dikirim
@@ -133,6 +343,9 @@ func (s *HealthDataService) validateHealthDataFields(req *request.HealthDataRequ
 			return err
 		}
 	}
+	if err := validateBloodSugarContext(req); err != nil {
+		return err
+	}
 	if req.Weight != nil {
 		if err := utils.ValidateNullableFloat64(req.Weight, "weight", 20.0, 200.0); err != nil {
 			return err
@@ -148,6 +361,27 @@ func (s *HealthDataService) validateHealthDataFields(req *request.HealthDataRequ
 			return err
 		}
 	}
+	if req.OxygenSaturation != nil {
+		if err := utils.ValidateNullableInt(req.OxygenSaturation, "oxygen_saturation", 50, 100); err != nil {
+			return err
+		}
+	}
+	if req.Temperature != nil {
+		if err := utils.ValidateNullableFloat64(req.Temperature, "temperature", 30.0, 45.0); err != nil {
+			return err
+		}
+	}
+	if req.RespiratoryRate != nil {
+		if err := utils.ValidateNullableInt(req.RespiratoryRate, "respiratory_rate", 5, 60); err != nil {
+			return err
+		}
+	}
+	if err := validateActivity(req.Activity); err != nil {
+		return err
+	}
+	if err := validateReadingNotes(req); err != nil {
+		return err
+	}
 	
 	return nil
 }
@@ -164,6 +398,10 @@ func (s *HealthDataService) updateHealthDataFields(healthData *entity.HealthData
 	if req.BloodSugar != nil {
 		healthData.BloodSugar = req.BloodSugar
 	}
+	if req.BloodSugarContext != nil {
+		context := entity.BloodSugarContext(*req.BloodSugarContext)
+		healthData.BloodSugarContext = &context
+	}
 	if req.Weight != nil {
 		healthData.Weight = req.Weight
 	}
@@ -173,7 +411,18 @@ func (s *HealthDataService) updateHealthDataFields(healthData *entity.HealthData
 	if req.HeartRate != nil {
 		healthData.HeartRate = req.HeartRate
 	}
+	if req.OxygenSaturation != nil {
+		healthData.OxygenSaturation = req.OxygenSaturation
+	}
+	if req.Temperature != nil {
+		temperature := roundTo1Decimal(*req.Temperature)
+		healthData.Temperature = &temperature
+	}
+	if req.RespiratoryRate != nil {
+		healthData.RespiratoryRate = req.RespiratoryRate
+	}
 	if req.Activity != nil {
-		healthData.Activity = req.Activity
+		updateActivityFields(healthData, req.Activity)
 	}
+	updateReadingNotesFields(healthData, req)
 }
@@ -15,7 +15,8 @@ import (
 // - Menghitung change_percent periode sebagai:
 //   ((nilai_terakhir - nilai_pertama) / nilai_pertama) * 100
 //   dengan aturan edge-case yang sudah ditentukan.
-func (s *HealthDataService) calculateSummary(data, _ []entity.HealthData, metrics []string) response.HealthSummaryResponse {
+// Status dan rentang normal mengikuti batas klinis milik pemilik data (classifier).
+func (s *HealthDataService) calculateSummary(classifier *HealthClassifier, data, _ []entity.HealthData, metrics []string) response.HealthSummaryResponse {
 	summary := response.HealthSummaryResponse{}
 
 	// Cek apakah metrik diminta atau tidak ada filter
@@ -26,11 +27,11 @@ func (s *HealthDataService) calculateSummary(data, _ []entity.HealthData, metric
 	includeActivity := includeAll || s.containsMetric(metrics, "aktivitas")
 
 	if includeBP && len(data) > 0 {
-		summary.BloodPressure = s.calculateBloodPressureSummary(data)
+		summary.BloodPressure = s.calculateBloodPressureSummary(classifier, data)
 	}
 
 	if includeBS && len(data) > 0 {
-		summary.BloodSugar = s.calculateBloodSugarSummary(data)
+		summary.BloodSugar = s.calculateBloodSugarSummary(classifier, data)
 	}
 
 	if includeWeight && len(data) > 0 {
@@ -41,6 +42,18 @@ func (s *HealthDataService) calculateSummary(data, _ []entity.HealthData, metric
 		summary.Activity = s.calculateActivitySummary(data)
 	}
 
+	if (includeAll || s.containsMetric(metrics, oxygenSaturationVitalSign.metric)) && len(data) > 0 {
+		summary.OxygenSaturation = s.calculateVitalSignSummary(classifier, data, oxygenSaturationVitalSign)
+	}
+
+	if (includeAll || s.containsMetric(metrics, temperatureVitalSign.metric)) && len(data) > 0 {
+		summary.Temperature = s.calculateVitalSignSummary(classifier, data, temperatureVitalSign)
+	}
+
+	if (includeAll || s.containsMetric(metrics, respiratoryRateVitalSign.metric)) && len(data) > 0 {
+		summary.RespiratoryRate = s.calculateVitalSignSummary(classifier, data, respiratoryRateVitalSign)
+	}
+
 	return summary
 }
 
@@ -66,7 +79,7 @@ func calculatePeriodChangePercent(values []float64) float64 {
 
 // calculateBloodPressureSummary menghitung ringkasan tekanan darah dengan nullable-aware,
 // berbasis agregasi harian (1 nilai per hari).
-func (s *HealthDataService) calculateBloodPressureSummary(data []entity.HealthData) *response.BloodPressureSummary {
+func (s *HealthDataService) calculateBloodPressureSummary(classifier *HealthClassifier, data []entity.HealthData) *response.BloodPressureSummary {
 	if len(data) == 0 {
 		return nil
 	}
@@ -141,7 +154,7 @@ func (s *HealthDataService) calculateBloodPressureSummary(data []entity.HealthDa
 	// Hitung status berdasarkan rata-rata (menggunakan kombinasi sistolik dan diastolik)
 	avgSystolicInt := int(avgSystolic)
 	avgDiastolicInt := int(avgDiastolic)
-	status := s.getBloodPressureStatus(avgSystolicInt, avgDiastolicInt)
+	status := classifier.BloodPressureStatus(avgSystolicInt, avgDiastolicInt)
 
 	return &response.BloodPressureSummary{
 		AvgSystolic:    roundTo2Decimals(avgSystolic),
@@ -149,13 +162,13 @@ func (s *HealthDataService) calculateBloodPressureSummary(data []entity.HealthDa
 		ChangePercent:  roundTo2Decimals(changePercent),
 		SystolicStatus: status,  // Status berdasarkan kombinasi sistolik dan diastolik
 		DiastolicStatus: status, // Status sama karena menggunakan kombinasi
-		NormalRange:    "90-139 / 60-89 mmHg (WHO)",
+		NormalRange:    classifier.BloodPressureNormalRange(),
 	}
 }
 
 // calculateBloodSugarSummary menghitung ringkasan gula darah dengan nullable-aware
 // Berbasis agregasi harian (1 nilai rata-rata per hari).
-func (s *HealthDataService) calculateBloodSugarSummary(data []entity.HealthData) *response.BloodSugarSummary {
+func (s *HealthDataService) calculateBloodSugarSummary(classifier *HealthClassifier, data []entity.HealthData) *response.BloodSugarSummary {
 	if len(data) == 0 {
 		return nil
 	}
@@ -168,9 +181,17 @@ func (s *HealthDataService) calculateBloodSugarSummary(data []entity.HealthData)
 
 	dailyMap := make(map[time.Time]*agg)
 	var dates []time.Time
+	contextMap := make(map[entity.BloodSugarContext]*agg)
 
 	for _, d := range data {
 		if d.BloodSugar != nil {
+			context := entity.ResolveBloodSugarContext(d.BloodSugarContext)
+			if _, ok := contextMap[context]; !ok {
+				contextMap[context] = &agg{}
+			}
+			contextMap[context].sum += float64(*d.BloodSugar)
+			contextMap[context].count++
+
 			recordDateJakarta := timezoneUtils.ToJakarta(d.RecordDate)
 			day := timezoneUtils.DateInJakarta(recordDateJakarta.Year(), recordDateJakarta.Month(), recordDateJakarta.Day(), 0, 0, 0, 0)
 			if _, ok := dailyMap[day]; !ok {
@@ -216,12 +237,62 @@ func (s *HealthDataService) calculateBloodSugarSummary(data []entity.HealthData)
 	// Hitung persentase perubahan periode dari nilai harian
 	changePercent := calculatePeriodChangePercent(dailyValues)
 
-	avgValueInt := int(avgValue)
+	// Status dan rentang normal dihitung per konteks pengukuran karena batas normal
+	// gula darah puasa, setelah makan, sewaktu, dan sebelum tidur berbeda.
+	// Status keseluruhan mengikuti status konteks yang paling perlu diperhatikan.
+	byContext := make([]response.BloodSugarContextSummary, 0, len(contextMap))
+	status := StatusNormal
+	normalRange := "Berbeda per konteks pengukuran (lihat by_context)"
+	for _, context := range bloodSugarContextOrder {
+		a, ok := contextMap[context]
+		if !ok {
+			continue
+		}
+		contextAvg := a.sum / float64(a.count)
+		contextStatus := classifier.BloodSugarStatus(int(contextAvg), context)
+		byContext = append(byContext, response.BloodSugarContextSummary{
+			Context:     string(context),
+			Label:       context.Label(),
+			AvgValue:    roundTo2Decimals(contextAvg),
+			Count:       a.count,
+			Status:      contextStatus,
+			NormalRange: classifier.BloodSugarNormalRange(context),
+		})
+		if bloodSugarStatusSeverity(contextStatus) > bloodSugarStatusSeverity(status) {
+			status = contextStatus
+		}
+	}
+	if len(byContext) == 1 {
+		normalRange = byContext[0].NormalRange
+	}
+
 	return &response.BloodSugarSummary{
 		AvgValue:      roundTo2Decimals(avgValue),
 		ChangePercent: roundTo2Decimals(changePercent),
-		Status:        s.getBloodSugarStatus(avgValueInt),
-		NormalRange:   "70-140 mg/dL (WHO - Gula Darah Sewaktu)",
+		Status:        status,
+		NormalRange:   normalRange,
+		ByContext:     byContext,
+	}
+}
+
+// bloodSugarContextOrder adalah urutan konteks gula darah pada ringkasan
+var bloodSugarContextOrder = []entity.BloodSugarContext{
+	entity.BloodSugarContextFasting,
+	entity.BloodSugarContextPostMeal,
+	entity.BloodSugarContextRandom,
+	entity.BloodSugarContextBedtime,
+}
+
+// bloodSugarStatusSeverity mengembalikan tingkat keparahan status gula darah
+// RENDAH dianggap paling parah karena hipoglikemia memerlukan penanganan segera
+func bloodSugarStatusSeverity(status string) int {
+	switch status {
+	case StatusRendah:
+		return 2
+	case StatusTinggi:
+		return 1
+	default:
+		return 0
 	}
 }
 
@@ -336,41 +407,49 @@ func (s *HealthDataService) calculateWeightSummary(data []entity.HealthData) *re
 	}
 }
 
-// calculateActivitySummary menghitung ringkasan aktivitas
-// Berbasis agregasi harian (1 estimasi aktivitas per hari).
-func (s *HealthDataService) calculateActivitySummary(data []entity.HealthData) *response.ActivitySummary {
+// calculateVitalSignSummary menghitung ringkasan tanda vital (saturasi oksigen, suhu tubuh, laju napas)
+// Berbasis agregasi harian (1 nilai rata-rata per hari). Nilai minimum dan maksimum diambil dari
+// pembacaan individual agar penurunan/kenaikan sesaat tetap terlihat.
+func (s *HealthDataService) calculateVitalSignSummary(classifier *HealthClassifier, data []entity.HealthData, sign vitalSign) *response.VitalSignSummary {
 	if len(data) == 0 {
 		return nil
 	}
 
-	// Kelompokkan per hari berdasarkan RecordDate, hanya data dengan activity tidak kosong
+	// Kelompokkan per hari berdasarkan RecordDate, hanya data yang memiliki tanda vital ini
 	type agg struct {
+		sum   float64
 		count int
 	}
 
 	dailyMap := make(map[time.Time]*agg)
 	var dates []time.Time
+	var minValue, maxValue float64
 
 	for _, d := range data {
-		if d.Activity != nil && *d.Activity != "" {
-			recordDateJakarta := timezoneUtils.ToJakarta(d.RecordDate)
-			day := timezoneUtils.DateInJakarta(recordDateJakarta.Year(), recordDateJakarta.Month(), recordDateJakarta.Day(), 0, 0, 0, 0)
-			if _, ok := dailyMap[day]; !ok {
-				dailyMap[day] = &agg{}
-				dates = append(dates, day)
-			}
-			a := dailyMap[day]
-			a.count++
+		value, ok := sign.value(d)
+		if !ok {
+			continue
+		}
+		if len(dates) == 0 || value < minValue {
+			minValue = value
+		}
+		if len(dates) == 0 || value > maxValue {
+			maxValue = value
 		}
+
+		recordDateJakarta := timezoneUtils.ToJakarta(d.RecordDate)
+		day := timezoneUtils.DateInJakarta(recordDateJakarta.Year(), recordDateJakarta.Month(), recordDateJakarta.Day(), 0, 0, 0, 0)
+		if _, ok := dailyMap[day]; !ok {
+			dailyMap[day] = &agg{}
+			dates = append(dates, day)
+		}
+		a := dailyMap[day]
+		a.sum += value
+		a.count++
 	}
 
 	if len(dailyMap) == 0 {
-		// Tidak ada aktivitas valid, tetap kembalikan 0 dengan changePercent 0
-		return &response.ActivitySummary{
-			TotalSteps:    0,
-			TotalCalories: 0,
-			ChangePercent: 0,
-		}
+		return nil // Tidak ada data tanda vital yang valid
 	}
 
 	// Urutkan tanggal ASC
@@ -378,32 +457,84 @@ func (s *HealthDataService) calculateActivitySummary(data []entity.HealthData) *
 		return dates[i].Before(dates[j])
 	})
 
-	// Asumsi: setiap entri aktivitas dalam satu hari merepresentasikan satu "aktivitas".
-	// Estimasi asli: 1 aktivitas = 1000 langkah & 200 kalori.
-	var totalSteps int
-	var totalCalories float64
-	var dailySteps []float64
-
+	var dailyValues []float64
+	var total float64
 	for _, day := range dates {
 		a := dailyMap[day]
-		if a.count == 0 {
+		avg := a.sum / float64(a.count)
+		dailyValues = append(dailyValues, avg)
+		total += avg
+	}
+
+	avgValue := total / float64(len(dailyValues))
+
+	// Hitung persentase perubahan periode dari nilai harian
+	changePercent := calculatePeriodChangePercent(dailyValues)
+
+	return &response.VitalSignSummary{
+		AvgValue:      roundTo2Decimals(avgValue),
+		MinValue:      minValue,
+		MaxValue:      maxValue,
+		ChangePercent: roundTo2Decimals(changePercent),
+		Status:        sign.status(classifier, avgValue),
+		NormalRange:   sign.normalRange(classifier),
+	}
+}
+
+// calculateActivitySummary menghitung ringkasan aktivitas terstruktur
+// Total langkah, kalori, durasi, dan jarak dijumlahkan dari semua aktivitas pada periode.
+// Pembacaan dengan teks aktivitas lama (tanpa jenis dan durasi) tidak dihitung.
+func (s *HealthDataService) calculateActivitySummary(data []entity.HealthData) *response.ActivitySummary {
+	if len(data) == 0 {
+		return nil
+	}
+
+	// Kelompokkan per hari berdasarkan RecordDate, hanya pembacaan dengan aktivitas terstruktur
+	dailyMap := make(map[time.Time]*activityTotals)
+	var dates []time.Time
+	var totals activityTotals
+
+	for i := range data {
+		d := &data[i]
+		if !hasStructuredActivity(d) {
 			continue
 		}
-		stepsForDay := a.count * 1000
-		caloriesForDay := float64(a.count) * 200.0
+		recordDateJakarta := timezoneUtils.ToJakarta(d.RecordDate)
+		day := timezoneUtils.DateInJakarta(recordDateJakarta.Year(), recordDateJakarta.Month(), recordDateJakarta.Day(), 0, 0, 0, 0)
+		if _, ok := dailyMap[day]; !ok {
+			dailyMap[day] = &activityTotals{}
+			dates = append(dates, day)
+		}
+		dailyMap[day].add(d)
+		totals.add(d)
+	}
+
+	if len(dailyMap) == 0 {
+		// Tidak ada aktivitas terstruktur, tetap kembalikan 0 dengan changePercent 0
+		return &response.ActivitySummary{}
+	}
 
-		totalSteps += stepsForDay
-		totalCalories += caloriesForDay
-		dailySteps = append(dailySteps, float64(stepsForDay))
+	// Urutkan tanggal ASC
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	dailyCalories := make([]float64, 0, len(dates))
+	for _, day := range dates {
+		dailyCalories = append(dailyCalories, dailyMap[day].calories)
 	}
 
-	// Hitung persentase perubahan periode dari nilai harian (berdasarkan langkah)
-	changePercent := calculatePeriodChangePercent(dailySteps)
+	// Hitung persentase perubahan periode dari kalori harian
+	changePercent := calculatePeriodChangePercent(dailyCalories)
 
 	return &response.ActivitySummary{
-		TotalSteps:    totalSteps,
-		TotalCalories: roundTo2Decimals(totalCalories),
-		ChangePercent: roundTo2Decimals(changePercent),
+		TotalSteps:           totals.steps,
+		TotalCalories:        roundTo2Decimals(totals.calories),
+		TotalDurationMinutes: totals.minutes,
+		TotalDistanceKm:      roundTo2Decimals(totals.distance),
+		ActiveDays:           len(dates),
+		SessionCount:         totals.sessions,
+		ChangePercent:        roundTo2Decimals(changePercent),
 	}
 }
 
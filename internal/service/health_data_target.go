@@ -0,0 +1,70 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"fmt"
+	"math"
+	"strings"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// targetWeightTolerance adalah selisih berat badan (kg) dari target yang masih dianggap tercapai
+const targetWeightTolerance = 0.5
+
+// notifyTargetAchievements mengirim notifikasi ke inbox jika pembacaan baru memenuhi target kesehatan user
+// Hanya pembacaan hari ini yang dinotifikasi (input backdate tidak memicu notifikasi),
+// dan setiap metrik maksimal dinotifikasi sekali per hari
+func (s *HealthDataService) notifyTargetAchievements(healthData *entity.HealthData) {
+	if s.notificationService == nil || s.healthTargetRepo == nil {
+		return
+	}
+	if !healthData.RecordDate.Equal(recordDateOf(timezoneUtils.NowInJakarta())) {
+		return
+	}
+
+	target, err := s.healthTargetRepo.GetHealthTargetByUserID(healthData.UserID)
+	if err != nil {
+		// User tanpa target kesehatan tidak perlu dinotifikasi
+		return
+	}
+
+	dateKey := healthData.RecordDate.Format("2006-01-02")
+	publish := func(metric, value, targetValue string) {
+		s.notificationService.publish(NotificationMessage{
+			UserID: healthData.UserID,
+			Type:   entity.NotificationTargetAchieved,
+			Params: map[string]string{
+				"metric": metric,
+				"value":  value,
+				"target": targetValue,
+			},
+			DedupKey: fmt.Sprintf("target:%s:%s", metric, dateKey),
+		})
+	}
+
+	if healthData.Systolic != nil && healthData.Diastolic != nil && target.TargetSystolic != nil && target.TargetDiastolic != nil &&
+		*healthData.Systolic <= *target.TargetSystolic && *healthData.Diastolic <= *target.TargetDiastolic {
+		publish("tekanan_darah",
+			fmt.Sprintf("%d/%d mmHg", *healthData.Systolic, *healthData.Diastolic),
+			fmt.Sprintf("%d/%d mmHg", *target.TargetSystolic, *target.TargetDiastolic))
+	}
+
+	if healthData.BloodSugar != nil && target.TargetBloodSugar != nil && *healthData.BloodSugar <= *target.TargetBloodSugar {
+		publish("gula_darah",
+			fmt.Sprintf("%d mg/dL", *healthData.BloodSugar),
+			fmt.Sprintf("%d mg/dL", *target.TargetBloodSugar))
+	}
+
+	if healthData.Weight != nil && target.TargetWeight != nil && math.Abs(*healthData.Weight-*target.TargetWeight) <= targetWeightTolerance {
+		publish("berat_badan",
+			formatTargetWeight(*healthData.Weight),
+			formatTargetWeight(*target.TargetWeight))
+	}
+}
+
+// formatTargetWeight memformat berat badan tanpa angka nol di belakang koma (mis. 65 kg, 65.5 kg)
+func formatTargetWeight(weight float64) string {
+	value := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.1f", weight), "0"), ".")
+	return value + " kg"
+}
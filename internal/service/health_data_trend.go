@@ -38,6 +38,18 @@ func (s *HealthDataService) calculateTrendCharts(data []entity.HealthData, metri
 		charts.Activity = s.buildActivityTrend(data)
 	}
 
+	if includeAll || s.containsMetric(metrics, oxygenSaturationVitalSign.metric) {
+		charts.OxygenSaturation = s.buildVitalSignTrend(data, oxygenSaturationVitalSign)
+	}
+
+	if includeAll || s.containsMetric(metrics, temperatureVitalSign.metric) {
+		charts.Temperature = s.buildVitalSignTrend(data, temperatureVitalSign)
+	}
+
+	if includeAll || s.containsMetric(metrics, respiratoryRateVitalSign.metric) {
+		charts.RespiratoryRate = s.buildVitalSignTrend(data, respiratoryRateVitalSign)
+	}
+
 	return charts
 }
 
@@ -69,17 +81,17 @@ func (s *HealthDataService) filterDataByTimeRange(data []entity.HealthData, days
 }
 
 // getLatestDataPerDay mengambil 1 data terakhir per hari dari data yang sudah di-group
-// Jika dalam 1 hari ada banyak data, gunakan yang terakhir (berdasarkan created_at)
+// Jika dalam 1 hari ada banyak pembacaan, gunakan yang terakhir (berdasarkan measured_at)
 func (s *HealthDataService) getLatestDataPerDay(dayData []entity.HealthData) *entity.HealthData {
 	if len(dayData) == 0 {
 		return nil
 	}
 
-	// Sort by created_at DESC untuk mendapatkan yang terakhir
+	// Sort by measured_at DESC untuk mendapatkan pembacaan terakhir
 	sorted := make([]entity.HealthData, len(dayData))
 	copy(sorted, dayData)
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+		return sorted[i].MeasuredAt.After(sorted[j].MeasuredAt)
 	})
 
 	return &sorted[0]
@@ -620,24 +632,19 @@ func (s *HealthDataService) buildActivityTrendPoints(data []entity.HealthData) [
 		dateMap[dateStr] = append(dateMap[dateStr], d)
 	}
 
-	// Ambil 1 data terakhir per hari dan buat titik data
+	// Jumlahkan semua aktivitas per hari (hari tanpa aktivitas bernilai 0)
 	var points []response.ActivityTrendPoint
 	for dateStr, dayData := range dateMap {
-		latestData := s.getLatestDataPerDay(dayData)
-		if latestData != nil {
-			steps := 0
-			calories := 0.0
-			// Hanya hitung jika ada activity field yang tidak kosong
-			if latestData.Activity != nil && *latestData.Activity != "" {
-				steps = 1000
-				calories = 200.0
-			}
-			points = append(points, response.ActivityTrendPoint{
-				Date:     dateStr,
-				Steps:    steps,
-				Calories: math.Round(calories),
-			})
-		}
+		var totals activityTotals
+		for i := range dayData {
+			totals.add(&dayData[i])
+		}
+		points = append(points, response.ActivityTrendPoint{
+			Date:            dateStr,
+			Steps:           totals.steps,
+			Calories:        math.Round(totals.calories),
+			DurationMinutes: totals.minutes,
+		})
 	}
 
 	// Sort by date (terlama ke terbaru)
@@ -675,22 +682,19 @@ func (s *HealthDataService) buildActivityTrendPointsWeek(data []entity.HealthDat
 		// Ambil start_date dan end_date dari data pertama di minggu tersebut
 		_, startDate, endDate := s.getWeekRange(weekData[0].RecordDate, rangeStartDate)
 
-		// Hitung total steps dan calories
-		totalSteps := 0
-		totalCalories := 0.0
-		for _, d := range weekData {
-			if d.Activity != nil && *d.Activity != "" {
-				totalSteps += 1000
-				totalCalories += 200.0
-			}
+		// Hitung total langkah, kalori, dan durasi
+		var totals activityTotals
+		for i := range weekData {
+			totals.add(&weekData[i])
 		}
 
 		points = append(points, response.ActivityTrendPointWeek{
-			Week:      weekKey,
-			StartDate: startDate,
-			EndDate:   endDate,
-			Steps:     totalSteps,
-			Calories:  math.Round(totalCalories),
+			Week:            weekKey,
+			StartDate:       startDate,
+			EndDate:         endDate,
+			Steps:           totals.steps,
+			Calories:        math.Round(totals.calories),
+			DurationMinutes: totals.minutes,
 		})
 	}
 
@@ -722,20 +726,156 @@ func (s *HealthDataService) buildActivityTrendPointsMonth(data []entity.HealthDa
 			continue
 		}
 
-		// Hitung total steps dan calories
-		totalSteps := 0
-		totalCalories := 0.0
-		for _, d := range monthData {
-			if d.Activity != nil && *d.Activity != "" {
-				totalSteps += 1000
-				totalCalories += 200.0
-			}
+		// Hitung total langkah, kalori, dan durasi
+		var totals activityTotals
+		for i := range monthData {
+			totals.add(&monthData[i])
 		}
 
 		points = append(points, response.ActivityTrendPointMonth{
+			Month:           monthKey,
+			Steps:           totals.steps,
+			Calories:        math.Round(totals.calories),
+			DurationMinutes: totals.minutes,
+		})
+	}
+
+	// Sort by month (terlama ke terbaru)
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Month < points[j].Month
+	})
+
+	return points
+}
+
+// buildVitalSignTrend membangun data tren tanda vital (saturasi oksigen, suhu tubuh, laju napas) dengan filter waktu
+func (s *HealthDataService) buildVitalSignTrend(data []entity.HealthData, sign vitalSign) response.VitalSignTrendCharts {
+	charts := response.VitalSignTrendCharts{}
+
+	// Hanya data yang memiliki tanda vital ini
+	var signData []entity.HealthData
+	for _, d := range data {
+		if _, ok := sign.value(d); ok {
+			signData = append(signData, d)
+		}
+	}
+
+	// Filter dan build untuk 7Days (per hari)
+	data7Days := s.filterDataByTimeRange(signData, 7)
+	charts.Days7 = s.buildVitalSignTrendPoints(data7Days, sign)
+
+	// Filter dan build untuk 1Month (30 hari - per minggu)
+	data1Month := s.filterDataByTimeRange(signData, 30)
+	charts.Month1 = s.buildVitalSignTrendPointsWeek(data1Month, sign)
+
+	// Filter dan build untuk 3Months (90 hari - per bulan)
+	data3Months := s.filterDataByTimeRange(signData, 90)
+	charts.Months3 = s.buildVitalSignTrendPointsMonth(data3Months, sign)
+
+	return charts
+}
+
+// buildVitalSignTrendPoints membangun array titik data tren tanda vital per hari (7Days)
+// Data harus sudah difilter hanya yang memiliki tanda vital tersebut
+func (s *HealthDataService) buildVitalSignTrendPoints(data []entity.HealthData, sign vitalSign) []response.VitalSignTrendPoint {
+	// Group by record_date
+	dateMap := make(map[string][]entity.HealthData)
+	for _, d := range data {
+		dateStr := d.RecordDate.Format("2006-01-02")
+		dateMap[dateStr] = append(dateMap[dateStr], d)
+	}
+
+	// Ambil 1 data terakhir per hari dan buat titik data
+	points := []response.VitalSignTrendPoint{}
+	for dateStr, dayData := range dateMap {
+		latestData := s.getLatestDataPerDay(dayData)
+		if latestData == nil {
+			continue
+		}
+		if value, ok := sign.value(*latestData); ok {
+			points = append(points, response.VitalSignTrendPoint{
+				Date:     dateStr,
+				AvgValue: value,
+			})
+		}
+	}
+
+	// Sort by date (terlama ke terbaru)
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Date < points[j].Date
+	})
+
+	return points
+}
+
+// buildVitalSignTrendPointsWeek membangun array titik data tren tanda vital per minggu (1Month)
+// Data harus sudah difilter hanya yang memiliki tanda vital tersebut
+func (s *HealthDataService) buildVitalSignTrendPointsWeek(data []entity.HealthData, sign vitalSign) []response.VitalSignTrendPointWeek {
+	if len(data) == 0 {
+		return []response.VitalSignTrendPointWeek{}
+	}
+
+	// Cari tanggal terawal untuk menghitung week number
+	now := timezoneUtils.NowInJakarta()
+	rangeStartDate := timezoneUtils.DateInJakarta(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0).AddDate(0, 0, -29)
+
+	// Group by week
+	weekMap := make(map[string][]entity.HealthData)
+	for _, d := range data {
+		weekKey, _, _ := s.getWeekRange(d.RecordDate, rangeStartDate)
+		weekMap[weekKey] = append(weekMap[weekKey], d)
+	}
+
+	// Hitung rata-rata per minggu
+	var points []response.VitalSignTrendPointWeek
+	for weekKey, weekData := range weekMap {
+		avg, ok := averageVitalSign(weekData, sign)
+		if !ok {
+			continue
+		}
+
+		// Ambil start_date dan end_date dari data pertama di minggu tersebut
+		_, startDate, endDate := s.getWeekRange(weekData[0].RecordDate, rangeStartDate)
+		points = append(points, response.VitalSignTrendPointWeek{
+			Week:      weekKey,
+			StartDate: startDate,
+			EndDate:   endDate,
+			AvgValue:  avg,
+		})
+	}
+
+	// Sort by start_date (terlama ke terbaru)
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].StartDate < points[j].StartDate
+	})
+
+	return points
+}
+
+// buildVitalSignTrendPointsMonth membangun array titik data tren tanda vital per bulan (3Months)
+// Data harus sudah difilter hanya yang memiliki tanda vital tersebut
+func (s *HealthDataService) buildVitalSignTrendPointsMonth(data []entity.HealthData, sign vitalSign) []response.VitalSignTrendPointMonth {
+	if len(data) == 0 {
+		return []response.VitalSignTrendPointMonth{}
+	}
+
+	// Group by month
+	monthMap := make(map[string][]entity.HealthData)
+	for _, d := range data {
+		monthKey := s.getMonthKey(d.RecordDate)
+		monthMap[monthKey] = append(monthMap[monthKey], d)
+	}
+
+	// Hitung rata-rata per bulan
+	var points []response.VitalSignTrendPointMonth
+	for monthKey, monthData := range monthMap {
+		avg, ok := averageVitalSign(monthData, sign)
+		if !ok {
+			continue
+		}
+		points = append(points, response.VitalSignTrendPointMonth{
 			Month:    monthKey,
-			Steps:    totalSteps,
-			Calories: math.Round(totalCalories),
+			AvgValue: avg,
 		})
 	}
 
@@ -747,6 +887,22 @@ func (s *HealthDataService) buildActivityTrendPointsMonth(data []entity.HealthDa
 	return points
 }
 
+// averageVitalSign menghitung rata-rata tanda vital (dibulatkan 1 desimal), false jika tidak ada nilai
+func averageVitalSign(data []entity.HealthData, sign vitalSign) (float64, bool) {
+	var total float64
+	count := 0
+	for _, d := range data {
+		if value, ok := sign.value(d); ok {
+			total += value
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return roundTo1Decimal(total / float64(count)), true
+}
+
 // getWeekRange menghitung range minggu untuk tanggal tertentu
 // Minggu dimulai dari Senin (ISO 8601 week)
 // Mengembalikan: weekKey (format: "Week 1"), startDate, endDate
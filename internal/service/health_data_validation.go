@@ -2,8 +2,10 @@ package service
 
 import (
 	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/entity"
 	"BE-PeriksaKesehatan/pkg/utils"
 	"errors"
+	"strings"
 )
 
 // ValidateHealthData melakukan validasi range nilai data kesehatan dengan nullable-aware.
@@ -11,6 +13,7 @@ import (
 func (s *HealthDataService) ValidateHealthData(req *request.HealthDataRequest) error {
 	if err := utils.RequireAtLeastOneHealthMetric(
 		req.Systolic, req.Diastolic, req.BloodSugar, nil, req.HeartRate, req.Weight, req.Height,
+			req.OxygenSaturation, req.RespiratoryRate, req.Temperature,
 	); err != nil {
 		return err
 	}
@@ -32,6 +35,10 @@ func (s *HealthDataService) ValidateHealthData(req *request.HealthDataRequest) e
 		return err
 	}
 
+	if err := validateBloodSugarContext(req); err != nil {
+		return err
+	}
+
 	if err := utils.ValidateNullableFloat64(req.Weight, "weight", 20.0, 200.0); err != nil {
 		return err
 	}
@@ -44,6 +51,32 @@ func (s *HealthDataService) ValidateHealthData(req *request.HealthDataRequest) e
 		return err
 	}
 
+	if err := utils.ValidateNullableInt(req.OxygenSaturation, "oxygen_saturation", 50, 100); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateNullableFloat64(req.Temperature, "temperature", 30.0, 45.0); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateNullableInt(req.RespiratoryRate, "respiratory_rate", 5, 60); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// validateBloodSugarContext memvalidasi dan menormalisasi konteks pengukuran gula darah (jika dikirim)
+func validateBloodSugarContext(req *request.HealthDataRequest) error {
+	if req.BloodSugarContext == nil {
+		return nil
+	}
+
+	context := strings.ToLower(strings.TrimSpace(*req.BloodSugarContext))
+	if !entity.IsValidBloodSugarContext(entity.BloodSugarContext(context)) {
+		return errors.New("blood_sugar_context harus salah satu dari puasa, setelah_makan, sewaktu, sebelum_tidur")
+	}
+	req.BloodSugarContext = &context
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"math"
+)
+
+// vitalSign mendefinisikan tanda vital tambahan (saturasi oksigen, suhu tubuh, laju napas)
+// agar summary dan grafik tren ketiganya dihitung dengan logic yang sama
+type vitalSign struct {
+	metric      string                                    // Nama metrik pada filter metrics
+	value       func(d entity.HealthData) (float64, bool) // Nilai pembacaan, false jika tidak diisi
+	status      func(c *HealthClassifier, value float64) string
+	normalRange func(c *HealthClassifier) string
+}
+
+var (
+	oxygenSaturationVitalSign = vitalSign{
+		metric: "saturasi_oksigen",
+		value: func(d entity.HealthData) (float64, bool) {
+			if d.OxygenSaturation == nil {
+				return 0, false
+			}
+			return float64(*d.OxygenSaturation), true
+		},
+		status: func(c *HealthClassifier, value float64) string {
+			return c.OxygenSaturationStatus(int(math.Round(value)))
+		},
+		normalRange: (*HealthClassifier).OxygenSaturationNormalRange,
+	}
+
+	temperatureVitalSign = vitalSign{
+		metric: "suhu_tubuh",
+		value: func(d entity.HealthData) (float64, bool) {
+			if d.Temperature == nil {
+				return 0, false
+			}
+			return *d.Temperature, true
+		},
+		status: func(c *HealthClassifier, value float64) string {
+			return c.TemperatureStatus(roundTo1Decimal(value))
+		},
+		normalRange: (*HealthClassifier).TemperatureNormalRange,
+	}
+
+	respiratoryRateVitalSign = vitalSign{
+		metric: "laju_napas",
+		value: func(d entity.HealthData) (float64, bool) {
+			if d.RespiratoryRate == nil {
+				return 0, false
+			}
+			return float64(*d.RespiratoryRate), true
+		},
+		status: func(c *HealthClassifier, value float64) string {
+			return c.RespiratoryRateStatus(int(math.Round(value)))
+		},
+		normalRange: (*HealthClassifier).RespiratoryRateNormalRange,
+	}
+)
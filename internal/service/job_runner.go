@@ -0,0 +1,294 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+const (
+	defaultJobInterval = time.Hour
+
+	// jobStaleIntervals adalah jumlah interval tanpa eksekusi sukses sebelum job dianggap terlambat (stale)
+	jobStaleIntervals = 2
+)
+
+// Status job pada endpoint health
+const (
+	JobHealthOK      = "ok"      // Eksekusi terakhir sukses dan masih dalam jadwal
+	JobHealthRunning = "running" // Sedang berjalan dan eksekusi sukses terakhir masih dalam jadwal
+	JobHealthFailing = "failing" // Eksekusi terakhir gagal
+	JobHealthStale   = "stale"   // Tidak ada eksekusi sukses dalam jobStaleIntervals interval terakhir
+	JobHealthPending = "pending" // Belum pernah dijalankan
+)
+
+// Job adalah pekerjaan maintenance yang dijalankan berkala oleh JobRunner
+// Run menerima waktu eksekusi (Asia/Jakarta) dan mengembalikan jumlah baris yang diproses/dihapus.
+// Job harus idempoten karena setiap replika API menjalankan job-nya sendiri.
+type Job struct {
+	Name        string
+	Description string
+	Interval    time.Duration
+	Run         func(now time.Time) (int64, error)
+}
+
+// JobRunner menjalankan job maintenance berkala di dalam proses API dan mencatat setiap eksekusinya
+// di tabel job_runs. Setiap job berjalan di goroutine sendiri sehingga job yang lambat tidak menunda job lain,
+// dan satu job tidak pernah berjalan tumpang tindih dengan dirinya sendiri dalam satu proses.
+type JobRunner struct {
+	jobRunRepo *repository.JobRunRepository
+	jobs       []Job
+	instance   string
+}
+
+// NewJobRunner membuat instance baru dari JobRunner tanpa job terdaftar
+func NewJobRunner(jobRunRepo *repository.JobRunRepository) *JobRunner {
+	instance, err := os.Hostname()
+	if err != nil {
+		instance = "unknown"
+	}
+	return &JobRunner{
+		jobRunRepo: jobRunRepo,
+		instance:   instance,
+	}
+}
+
+// Register mendaftarkan job; interval kosong memakai default 1 jam
+// Dipanggil sebelum Start
+func (r *JobRunner) Register(job Job) {
+	if job.Interval <= 0 {
+		job.Interval = defaultJobInterval
+	}
+	r.jobs = append(r.jobs, job)
+}
+
+// Start menjalankan semua job terdaftar sampai context dibatalkan (blocking, jalankan dalam goroutine)
+// Setiap job langsung dijalankan sekali lalu diulang setiap interval. Setelah context dibatalkan,
+// Start baru kembali setelah eksekusi yang sedang berjalan selesai sehingga riwayatnya tetap tercatat.
+func (r *JobRunner) Start(ctx context.Context) {
+	log.Printf("Info: Job runner menjalankan %d job", len(r.jobs))
+
+	var wg sync.WaitGroup
+	for _, job := range r.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			r.schedule(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+
+	log.Println("Info: Job runner dihentikan")
+}
+
+// schedule menjalankan satu job setiap interval sampai context dibatalkan
+func (r *JobRunner) schedule(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	r.RunJob(job)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.RunJob(job)
+		}
+	}
+}
+
+// RunJob menjalankan satu job sekali dan mencatat hasilnya di job_runs
+// Kegagalan mencatat riwayat hanya ditulis ke log; job tetap dijalankan
+func (r *JobRunner) RunJob(job Job) {
+	startedAt := timezoneUtils.NowInJakarta()
+	run := &entity.JobRun{
+		JobName:   job.Name,
+		Status:    entity.JobRunStatusRunning,
+		Instance:  r.instance,
+		StartedAt: startedAt,
+	}
+	if err := r.jobRunRepo.CreateJobRun(run); err != nil {
+		log.Printf("[Job] Warning: Gagal mencatat awal job %s: %v", job.Name, err)
+	}
+
+	affected, err := executeJob(job, startedAt)
+
+	finishedAt := timezoneUtils.NowInJakarta()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = finishedAt.Sub(startedAt).Milliseconds()
+	run.AffectedRows = affected
+	if err != nil {
+		errMsg := err.Error()
+		run.Status = entity.JobRunStatusFailed
+		run.Error = &errMsg
+		log.Printf("[Job] Warning: Job %s gagal: %v", job.Name, err)
+	} else {
+		run.Status = entity.JobRunStatusSuccess
+		if affected > 0 {
+			log.Printf("[Job] Job %s selesai: %d baris diproses (%d ms)", job.Name, affected, run.DurationMs)
+		}
+	}
+
+	if run.ID == 0 {
+		return
+	}
+	if err := r.jobRunRepo.FinishJobRun(run); err != nil {
+		log.Printf("[Job] Warning: Gagal mencatat hasil job %s: %v", job.Name, err)
+	}
+}
+
+// executeJob menjalankan fungsi job dan mengubah panic menjadi error agar runner tetap berjalan
+func executeJob(job Job, now time.Time) (affected int64, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return job.Run(now)
+}
+
+// GetHealth mengambil status terakhir setiap job terdaftar untuk endpoint health
+// Riwayat dibaca dari database sehingga eksekusi oleh replika lain juga terhitung.
+// Mengembalikan error jika database tidak dapat diakses.
+func (r *JobRunner) GetHealth() (*response.HealthResponse, error) {
+	now := timezoneUtils.NowInJakarta()
+
+	latestRuns, err := r.jobRunRepo.GetLatestJobRuns()
+	if err != nil {
+		return nil, err
+	}
+	lastSuccesses, err := r.jobRunRepo.GetLastSuccessfulJobRuns()
+	if err != nil {
+		return nil, err
+	}
+
+	latestByJob := make(map[string]entity.JobRun, len(latestRuns))
+	for _, run := range latestRuns {
+		latestByJob[run.JobName] = run
+	}
+	lastSuccessByJob := make(map[string]time.Time, len(lastSuccesses))
+	for _, success := range lastSuccesses {
+		lastSuccessByJob[success.JobName] = success.FinishedAt
+	}
+
+	resp := &response.HealthResponse{
+		Status:    JobHealthOK,
+		Database:  "ok",
+		Jobs:      make([]response.JobStatusResponse, 0, len(r.jobs)),
+		CheckedAt: now,
+	}
+	for _, job := range r.jobs {
+		status := response.JobStatusResponse{
+			Name:        job.Name,
+			Description: job.Description,
+			Interval:    job.Interval.String(),
+			Status:      JobHealthPending,
+		}
+
+		if run, ok := latestByJob[job.Name]; ok {
+			startedAt := timezoneUtils.ToJakarta(run.StartedAt)
+			status.LastRunAt = &startedAt
+			status.LastRunStatus = run.Status
+			if run.FinishedAt != nil {
+				durationMs := run.DurationMs
+				status.LastDurationMs = &durationMs
+			}
+
+			lastSuccess, succeeded := lastSuccessByJob[job.Name]
+			if succeeded {
+				lastSuccessAt := timezoneUtils.ToJakarta(lastSuccess)
+				status.LastSuccessAt = &lastSuccessAt
+			}
+
+			// Job tanpa eksekusi sukses dihitung terlambat sejak eksekusi terakhirnya dimulai
+			reference := run.StartedAt
+			if succeeded {
+				reference = lastSuccess
+			}
+			stale := now.Sub(reference) > jobStaleIntervals*job.Interval
+
+			switch {
+			case run.Status == entity.JobRunStatusFailed:
+				status.Status = JobHealthFailing
+			case stale:
+				status.Status = JobHealthStale
+			case run.Status == entity.JobRunStatusRunning:
+				status.Status = JobHealthRunning
+			default:
+				status.Status = JobHealthOK
+			}
+		}
+
+		if status.Status == JobHealthFailing || status.Status == JobHealthStale {
+			resp.Status = "degraded"
+		}
+		resp.Jobs = append(resp.Jobs, status)
+	}
+
+	return resp, nil
+}
+
+// GetJobRuns mengambil riwayat eksekusi job dengan filter dan pagination (admin)
+func (r *JobRunner) GetJobRuns(req *request.AdminJobRunListRequest) (*response.JobRunListResponse, error) {
+	jobName := strings.TrimSpace(req.Job)
+	if jobName != "" && !r.hasJob(jobName) {
+		names := make([]string, 0, len(r.jobs))
+		for _, job := range r.jobs {
+			names = append(names, job.Name)
+		}
+		return nil, fmt.Errorf("job harus salah satu dari %s", strings.Join(names, ", "))
+	}
+
+	status := strings.ToLower(strings.TrimSpace(req.Status))
+	if status != "" && !entity.IsValidJobRunStatus(status) {
+		return nil, errors.New("status harus salah satu dari running, success, failed")
+	}
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	runs, total, err := r.jobRunRepo.GetJobRunsWithFilter(repository.JobRunFilter{JobName: jobName, Status: status}, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.JobRunResponse, 0, len(runs))
+	for _, run := range runs {
+		items = append(items, response.JobRunResponse{
+			ID:           run.ID,
+			JobName:      run.JobName,
+			Status:       run.Status,
+			Instance:     run.Instance,
+			StartedAt:    timezoneUtils.ToJakarta(run.StartedAt),
+			FinishedAt:   toJakartaPtr(run.FinishedAt),
+			DurationMs:   run.DurationMs,
+			AffectedRows: run.AffectedRows,
+			Error:        run.Error,
+		})
+	}
+
+	return &response.JobRunListResponse{
+		Runs:       items,
+		Pagination: newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// hasJob memeriksa apakah job dengan nama tertentu terdaftar
+func (r *JobRunner) hasJob(name string) bool {
+	for _, job := range r.jobs {
+		if job.Name == name {
+			return true
+		}
+	}
+	return false
+}
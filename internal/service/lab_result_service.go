@@ -0,0 +1,575 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+const (
+	defaultLabTrendMonths = 12
+)
+
+// labTestDefinition berisi satuan dan rentang nilai yang valid untuk satu jenis pemeriksaan laboratorium.
+// Rentang rujukan tidak ditulis di sini melainkan di clinical_thresholds (default global dan penyesuaian per pasien).
+type labTestDefinition struct {
+	testType entity.LabTestType
+	label    string
+	unit     string
+	minValue float64 // Nilai minimum yang masuk akal (validasi input, dalam satuan standar)
+	maxValue float64 // Nilai maksimum yang masuk akal (validasi input, dalam satuan standar)
+
+	// conversions memetakan satuan lain (huruf kecil) ke fungsi konversi ke satuan standar
+	conversions map[string]func(float64) float64
+}
+
+// labTestDefinitions adalah daftar jenis pemeriksaan yang didukung, dalam urutan tampilan
+var labTestDefinitions = []labTestDefinition{
+	{
+		testType: entity.LabTestHbA1c, label: "HbA1c", unit: "%",
+		minValue: 3, maxValue: 20,
+		conversions: map[string]func(float64) float64{
+			"mmol/mol": func(v float64) float64 { return v*0.09148 + 2.152 },
+		},
+	},
+	{
+		testType: entity.LabTestTotalCholesterol, label: "Kolesterol Total", unit: "mg/dL",
+		minValue: 50, maxValue: 600,
+		conversions: map[string]func(float64) float64{
+			"mmol/l": func(v float64) float64 { return v * 38.67 },
+		},
+	},
+	{
+		testType: entity.LabTestLDL, label: "Kolesterol LDL", unit: "mg/dL",
+		minValue: 10, maxValue: 400,
+		conversions: map[string]func(float64) float64{
+			"mmol/l": func(v float64) float64 { return v * 38.67 },
+		},
+	},
+	{
+		testType: entity.LabTestHDL, label: "Kolesterol HDL", unit: "mg/dL",
+		minValue: 5, maxValue: 150,
+		conversions: map[string]func(float64) float64{
+			"mmol/l": func(v float64) float64 { return v * 38.67 },
+		},
+	},
+	{
+		testType: entity.LabTestTriglycerides, label: "Trigliserida", unit: "mg/dL",
+		minValue: 10, maxValue: 2000,
+		conversions: map[string]func(float64) float64{
+			"mmol/l": func(v float64) float64 { return v * 88.57 },
+		},
+	},
+	{
+		testType: entity.LabTestUricAcid, label: "Asam Urat", unit: "mg/dL",
+		minValue: 0.5, maxValue: 20,
+		conversions: map[string]func(float64) float64{
+			"umol/l": func(v float64) float64 { return v / 59.48 },
+		},
+	},
+	{
+		testType: entity.LabTestCreatinine, label: "Kreatinin", unit: "mg/dL",
+		minValue: 0.1, maxValue: 20,
+		conversions: map[string]func(float64) float64{
+			"umol/l": func(v float64) float64 { return v / 88.4 },
+		},
+	},
+}
+
+// LabResultService menangani business logic untuk hasil pemeriksaan laboratorium
+type LabResultService struct {
+	labResultRepo            *repository.LabResultRepository
+	clinicalThresholdService *ClinicalThresholdService
+}
+
+// NewLabResultService membuat instance baru dari LabResultService
+func NewLabResultService(labResultRepo *repository.LabResultRepository, clinicalThresholdService *ClinicalThresholdService) *LabResultService {
+	return &LabResultService{
+		labResultRepo:            labResultRepo,
+		clinicalThresholdService: clinicalThresholdService,
+	}
+}
+
+// GetLabTestTypes mengembalikan daftar jenis pemeriksaan yang didukung beserta satuan dan rentang rujukan
+// yang berlaku untuk user (default global atau penyesuaian dari clinician)
+func (s *LabResultService) GetLabTestTypes(userID uint) ([]response.LabTestTypeResponse, error) {
+	classifier, err := s.clinicalThresholdService.GetClassifier(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]response.LabTestTypeResponse, 0, len(labTestDefinitions))
+	for _, def := range labTestDefinitions {
+		acceptedUnits := []string{def.unit}
+		for unit := range def.conversions {
+			acceptedUnits = append(acceptedUnits, displayLabUnit(unit))
+		}
+		referenceMin, referenceMax := classifier.LabReferenceRange(def.testType)
+		types = append(types, response.LabTestTypeResponse{
+			TestType:      string(def.testType),
+			Label:         def.label,
+			Unit:          def.unit,
+			AcceptedUnits: acceptedUnits,
+			ReferenceMin:  referenceMin,
+			ReferenceMax:  referenceMax,
+			NormalRange:   formatLabReferenceRange(referenceMin, referenceMax, def.unit),
+		})
+	}
+	return types, nil
+}
+
+// CreateLabResult mencatat hasil laboratorium baru milik user
+func (s *LabResultService) CreateLabResult(userID uint, req *request.CreateLabResultRequest) (*response.LabResultResponse, error) {
+	def, err := getLabTestDefinition(req.TestType)
+	if err != nil {
+		return nil, err
+	}
+
+	convert, err := def.converter(req.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	testedAt, err := resolveLabTestedAt(req.TestedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rentang rujukan yang berlaku saat ini disimpan bersama hasil agar status hasil lama tidak berubah
+	// ketika batas klinis diubah; rentang dari laboratorium (jika dikirim) lebih diutamakan
+	classifier, err := s.clinicalThresholdService.GetClassifier(userID)
+	if err != nil {
+		return nil, err
+	}
+	referenceMin, referenceMax := classifier.LabReferenceRange(def.testType)
+
+	labResult := &entity.LabResult{
+		UserID:       userID,
+		TestType:     def.testType,
+		Value:        roundTo2Decimals(convert(*req.Value)),
+		Unit:         def.unit,
+		ReferenceMin: referenceMin,
+		ReferenceMax: referenceMax,
+		TestedAt:     testedAt,
+		LabName:      trimOptionalString(req.LabName),
+		Notes:        trimOptionalString(req.Notes),
+	}
+	if req.ReferenceMin != nil {
+		labResult.ReferenceMin = convertLabReference(req.ReferenceMin, convert)
+	}
+	if req.ReferenceMax != nil {
+		labResult.ReferenceMax = convertLabReference(req.ReferenceMax, convert)
+	}
+
+	if err := def.validate(labResult); err != nil {
+		return nil, err
+	}
+
+	if err := s.labResultRepo.CreateLabResult(labResult); err != nil {
+		return nil, err
+	}
+
+	return s.mapLabResultToResponse(labResult), nil
+}
+
+// UpdateLabResult mengoreksi hasil laboratorium milik user (partial update)
+// Unit berlaku untuk value dan rentang rujukan yang dikirim pada request yang sama
+func (s *LabResultService) UpdateLabResult(userID, labResultID uint, req *request.UpdateLabResultRequest) (*response.LabResultResponse, error) {
+	labResult, err := s.labResultRepo.GetLabResultByIDAndUserID(labResultID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := getLabTestDefinition(string(labResult.TestType))
+	if err != nil {
+		return nil, err
+	}
+
+	convert, err := def.converter(req.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Value != nil {
+		labResult.Value = roundTo2Decimals(convert(*req.Value))
+	}
+	if req.ReferenceMin != nil {
+		labResult.ReferenceMin = convertLabReference(req.ReferenceMin, convert)
+	}
+	if req.ReferenceMax != nil {
+		labResult.ReferenceMax = convertLabReference(req.ReferenceMax, convert)
+	}
+	if req.TestedAt != nil {
+		testedAt, err := resolveLabTestedAt(req.TestedAt)
+		if err != nil {
+			return nil, err
+		}
+		labResult.TestedAt = testedAt
+	}
+	if req.LabName != nil {
+		labResult.LabName = trimOptionalString(req.LabName)
+	}
+	if req.Notes != nil {
+		labResult.Notes = trimOptionalString(req.Notes)
+	}
+
+	if err := def.validate(labResult); err != nil {
+		return nil, err
+	}
+
+	if err := s.labResultRepo.UpdateLabResult(labResult); err != nil {
+		return nil, err
+	}
+
+	return s.mapLabResultToResponse(labResult), nil
+}
+
+// DeleteLabResult menghapus hasil laboratorium milik user
+func (s *LabResultService) DeleteLabResult(userID, labResultID uint) error {
+	labResult, err := s.labResultRepo.GetLabResultByIDAndUserID(labResultID, userID)
+	if err != nil {
+		return err
+	}
+	return s.labResultRepo.DeleteLabResult(labResult.ID)
+}
+
+// GetLabResults mengambil riwayat hasil laboratorium dengan filter jenis, tanggal, dan pagination
+func (s *LabResultService) GetLabResults(userID uint, req *request.LabResultListRequest) (*response.LabResultListResponse, error) {
+	filter, err := buildLabResultFilter(req.TestType, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	labResults, total, err := s.labResultRepo.GetLabResultsWithFilter(userID, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.LabResultResponse, 0, len(labResults))
+	for i := range labResults {
+		items = append(items, *s.mapLabResultToResponse(&labResults[i]))
+	}
+
+	return &response.LabResultListResponse{
+		LabResults: items,
+		Pagination: newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// GetLabResultTrends mengambil grafik tren per jenis pemeriksaan (default 12 bulan terakhir)
+// Setiap pemeriksaan menjadi satu titik karena hasil lab tidak diukur setiap hari
+func (s *LabResultService) GetLabResultTrends(userID uint, req *request.LabResultTrendRequest) (*response.LabResultTrendsResponse, error) {
+	now := timezoneUtils.NowInJakarta()
+	startDate := req.StartDate
+	if startDate == nil {
+		defaultStart := now.AddDate(0, -defaultLabTrendMonths, 0)
+		startDate = &defaultStart
+	}
+	endDate := req.EndDate
+	if endDate == nil {
+		endDate = &now
+	}
+
+	filter, err := buildLabResultFilter(req.TestType, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	labResults, err := s.labResultRepo.GetLabResultsByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Kelompokkan per jenis pemeriksaan (data sudah terurut tested_at ASC)
+	grouped := make(map[entity.LabTestType][]entity.LabResult)
+	for _, labResult := range labResults {
+		grouped[labResult.TestType] = append(grouped[labResult.TestType], labResult)
+	}
+
+	trends := make([]response.LabResultTrendSeries, 0, len(grouped))
+	for _, def := range labTestDefinitions {
+		results, ok := grouped[def.testType]
+		if !ok {
+			continue
+		}
+		trends = append(trends, s.buildLabTrendSeries(def, results))
+	}
+
+	return &response.LabResultTrendsResponse{
+		StartDate: filter.StartDate.Format("2006-01-02"),
+		EndDate:   filter.EndDate.Format("2006-01-02"),
+		Trends:    trends,
+	}, nil
+}
+
+// GetLabResultsForPeriod mengambil semua hasil laboratorium user pada rentang waktu (untuk laporan)
+func (s *LabResultService) GetLabResultsForPeriod(userID uint, startDate, endDate time.Time) ([]response.LabResultResponse, error) {
+	labResults, err := s.labResultRepo.GetLabResultsByUserID(userID, repository.LabResultFilter{
+		StartDate: &startDate,
+		EndDate:   &endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.LabResultResponse, 0, len(labResults))
+	for i := range labResults {
+		items = append(items, *s.mapLabResultToResponse(&labResults[i]))
+	}
+	return items, nil
+}
+
+// buildLabTrendSeries membuat data tren untuk satu jenis pemeriksaan
+func (s *LabResultService) buildLabTrendSeries(def labTestDefinition, results []entity.LabResult) response.LabResultTrendSeries {
+	points := make([]response.LabResultTrendPoint, 0, len(results))
+	values := make([]float64, 0, len(results))
+	for _, labResult := range results {
+		testedAt := timezoneUtils.ToJakarta(labResult.TestedAt)
+		points = append(points, response.LabResultTrendPoint{
+			ID:       labResult.ID,
+			TestedAt: testedAt,
+			Date:     testedAt.Format("2006-01-02"),
+			Value:    labResult.Value,
+			Status:   labResultStatus(labResult.Value, labResult.ReferenceMin, labResult.ReferenceMax),
+		})
+		values = append(values, labResult.Value)
+	}
+
+	latest := results[len(results)-1]
+	series := response.LabResultTrendSeries{
+		TestType:    string(def.testType),
+		Label:       def.label,
+		Unit:        def.unit,
+		NormalRange: formatLabReferenceRange(latest.ReferenceMin, latest.ReferenceMax, def.unit),
+		Latest:      s.mapLabResultToResponse(&latest),
+		Trend:       "Stabil",
+		Points:      points,
+	}
+
+	if len(values) > 1 {
+		changePercent := roundTo2Decimals(calculatePeriodChangePercent(values))
+		series.ChangePercent = &changePercent
+		if changePercent > 1 {
+			series.Trend = "Naik"
+		} else if changePercent < -1 {
+			series.Trend = "Turun"
+		}
+	}
+
+	return series
+}
+
+// mapLabResultToResponse mengubah entity hasil laboratorium ke response
+func (s *LabResultService) mapLabResultToResponse(labResult *entity.LabResult) *response.LabResultResponse {
+	label := string(labResult.TestType)
+	if def, err := getLabTestDefinition(string(labResult.TestType)); err == nil {
+		label = def.label
+	}
+
+	return &response.LabResultResponse{
+		ID:             labResult.ID,
+		UserID:         labResult.UserID,
+		TestType:       string(labResult.TestType),
+		Label:          label,
+		Value:          labResult.Value,
+		Unit:           labResult.Unit,
+		ReferenceMin:   labResult.ReferenceMin,
+		ReferenceMax:   labResult.ReferenceMax,
+		NormalRange:    formatLabReferenceRange(labResult.ReferenceMin, labResult.ReferenceMax, labResult.Unit),
+		Status:         labResultStatus(labResult.Value, labResult.ReferenceMin, labResult.ReferenceMax),
+		Interpretation: labResultInterpretation(labResult.TestType, labResult.Value, labResult.ReferenceMin, labResult.ReferenceMax),
+		TestedAt:       timezoneUtils.ToJakarta(labResult.TestedAt),
+		LabName:        labResult.LabName,
+		Notes:          labResult.Notes,
+		CreatedAt:      timezoneUtils.ToJakarta(labResult.CreatedAt),
+	}
+}
+
+// getLabTestDefinition mencari definisi jenis pemeriksaan
+func getLabTestDefinition(testType string) (labTestDefinition, error) {
+	normalized := entity.LabTestType(strings.ToLower(strings.TrimSpace(testType)))
+	for _, def := range labTestDefinitions {
+		if def.testType == normalized {
+			return def, nil
+		}
+	}
+	return labTestDefinition{}, errors.New("test_type harus salah satu dari hba1c, total_cholesterol, ldl, hdl, triglycerides, uric_acid, creatinine")
+}
+
+// converter mengembalikan fungsi konversi dari satuan input ke satuan standar
+// Satuan kosong berarti nilai sudah dalam satuan standar
+func (d labTestDefinition) converter(unit string) (func(float64) float64, error) {
+	normalized := normalizeLabUnit(unit)
+	if normalized == "" || normalized == normalizeLabUnit(d.unit) {
+		return func(v float64) float64 { return v }, nil
+	}
+	if convert, ok := d.conversions[normalized]; ok {
+		return convert, nil
+	}
+
+	accepted := []string{d.unit}
+	for unit := range d.conversions {
+		accepted = append(accepted, displayLabUnit(unit))
+	}
+	return nil, fmt.Errorf("unit untuk %s harus salah satu dari %s", d.testType, strings.Join(accepted, ", "))
+}
+
+// validate memastikan nilai dan rentang rujukan (dalam satuan standar) masuk akal
+func (d labTestDefinition) validate(labResult *entity.LabResult) error {
+	if labResult.Value < d.minValue || labResult.Value > d.maxValue {
+		return fmt.Errorf("value %s harus berada dalam range %s - %s %s",
+			d.testType, formatLabValue(d.minValue), formatLabValue(d.maxValue), d.unit)
+	}
+	if labResult.ReferenceMin != nil && labResult.ReferenceMax != nil && *labResult.ReferenceMin >= *labResult.ReferenceMax {
+		return errors.New("reference_min harus lebih kecil dari reference_max")
+	}
+	return nil
+}
+
+// buildLabResultFilter memvalidasi filter jenis pemeriksaan dan tanggal
+// start_date dimulai pukul 00:00 dan end_date berakhir pukul 23:59:59 (Asia/Jakarta)
+func buildLabResultFilter(testType string, startDate, endDate *time.Time) (repository.LabResultFilter, error) {
+	filter := repository.LabResultFilter{}
+
+	if strings.TrimSpace(testType) != "" {
+		def, err := getLabTestDefinition(testType)
+		if err != nil {
+			return filter, err
+		}
+		filter.TestType = string(def.testType)
+	}
+
+	if startDate != nil {
+		t := timezoneUtils.ToJakarta(*startDate)
+		start := timezoneUtils.DateInJakarta(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0)
+		filter.StartDate = &start
+	}
+	if endDate != nil {
+		t := timezoneUtils.ToJakarta(*endDate)
+		end := timezoneUtils.DateInJakarta(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0)
+		filter.EndDate = &end
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return filter, errors.New("start_date tidak boleh setelah end_date")
+	}
+
+	return filter, nil
+}
+
+// resolveLabTestedAt menentukan waktu pemeriksaan: dari request atau sekarang (Asia/Jakarta)
+// Hasil lab lama boleh dicatat tanpa batas waktu ke belakang, tetapi tidak boleh di masa depan
+func resolveLabTestedAt(testedAt *time.Time) (time.Time, error) {
+	now := timezoneUtils.NowInJakarta()
+	if testedAt == nil {
+		return now, nil
+	}
+
+	t := timezoneUtils.ToJakarta(*testedAt)
+	if t.After(now.Add(measuredAtClockSkew)) {
+		return time.Time{}, errors.New("tested_at tidak boleh di masa depan")
+	}
+	return t, nil
+}
+
+// labResultStatus menentukan status hasil lab terhadap rentang rujukannya
+func labResultStatus(value float64, referenceMin, referenceMax *float64) string {
+	if referenceMin != nil && value < *referenceMin {
+		return StatusRendah
+	}
+	if referenceMax != nil && value > *referenceMax {
+		return StatusTinggi
+	}
+	return StatusNormal
+}
+
+// labResultInterpretation memberikan interpretasi klinis untuk pemeriksaan yang memiliki kategori diagnosis
+// HbA1c: < 5.7% normal, 5.7-6.4% prediabetes, ≥ 6.5% diabetes (ADA).
+// Kategori ADA hanya dipakai jika rentang rujukan hasil (yang menentukan status) sama dengan rujukan standar;
+// jika rentang disesuaikan (default global atau per pasien), interpretasi dikosongkan agar tidak bertentangan dengan status.
+func labResultInterpretation(testType entity.LabTestType, value float64, referenceMin, referenceMax *float64) *string {
+	var interpretation string
+	switch testType {
+	case entity.LabTestHbA1c:
+		standard := entity.DefaultClinicalThreshold()
+		if !equalFloatPointer(referenceMin, standard.HbA1cMin) || !equalFloatPointer(referenceMax, standard.HbA1cMax) {
+			return nil
+		}
+		switch {
+		case value >= 6.5:
+			interpretation = "Diabetes"
+		case value >= 5.7:
+			interpretation = "Prediabetes"
+		default:
+			interpretation = "Normal"
+		}
+	default:
+		return nil
+	}
+	return &interpretation
+}
+
+// formatLabReferenceRange mengubah rentang rujukan menjadi teks, mis. "3.4-7 mg/dL" atau "maks 99 mg/dL"
+func formatLabReferenceRange(referenceMin, referenceMax *float64, unit string) string {
+	switch {
+	case referenceMin != nil && referenceMax != nil:
+		return fmt.Sprintf("%s-%s %s", formatLabValue(*referenceMin), formatLabValue(*referenceMax), unit)
+	case referenceMax != nil:
+		return fmt.Sprintf("maks %s %s", formatLabValue(*referenceMax), unit)
+	case referenceMin != nil:
+		return fmt.Sprintf("min %s %s", formatLabValue(*referenceMin), unit)
+	default:
+		return "-"
+	}
+}
+
+// formatLabValue memformat nilai lab tanpa nol di belakang koma
+func formatLabValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// convertLabReference mengonversi batas rujukan ke satuan standar
+func convertLabReference(value *float64, convert func(float64) float64) *float64 {
+	converted := roundTo2Decimals(convert(*value))
+	return &converted
+}
+
+// normalizeLabUnit menyeragamkan penulisan satuan (huruf kecil, µ menjadi u)
+func normalizeLabUnit(unit string) string {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	unit = strings.ReplaceAll(unit, "µ", "u")
+	return strings.ReplaceAll(unit, "μ", "u")
+}
+
+// displayLabUnit mengubah satuan konversi ke bentuk tampilan
+func displayLabUnit(unit string) string {
+	switch unit {
+	case "mmol/l":
+		return "mmol/L"
+	case "umol/l":
+		return "umol/L"
+	default:
+		return unit
+	}
+}
+
+// trimOptionalString merapikan string opsional; string kosong menjadi nil
+func trimOptionalString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"testing"
+)
+
+func TestLabResultInterpretationHbA1c(t *testing.T) {
+	standardMax := floatPtr(5.6)
+	overrideMax := floatPtr(7)
+
+	tests := []struct {
+		name         string
+		value        float64
+		referenceMax *float64
+		wantStatus   string
+		want         *string
+	}{
+		{"rujukan standar normal", 5.5, standardMax, StatusNormal, stringPtr("Normal")},
+		{"rujukan standar prediabetes", 6.0, standardMax, StatusTinggi, stringPtr("Prediabetes")},
+		{"rujukan standar diabetes", 6.8, standardMax, StatusTinggi, stringPtr("Diabetes")},
+		{"rujukan disesuaikan tidak memakai kategori ADA", 6.8, overrideMax, StatusNormal, nil},
+		{"rujukan disesuaikan di atas batas", 7.2, overrideMax, StatusTinggi, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labResultStatus(tt.value, nil, tt.referenceMax); got != tt.wantStatus {
+				t.Errorf("labResultStatus(%v) = %s, want %s", tt.value, got, tt.wantStatus)
+			}
+			got := labResultInterpretation(entity.LabTestHbA1c, tt.value, nil, tt.referenceMax)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("labResultInterpretation(%v) = %v, want %v", tt.value, derefString(got), derefString(tt.want))
+			}
+		})
+	}
+
+	if got := labResultInterpretation(entity.LabTestLDL, 120, nil, floatPtr(99)); got != nil {
+		t.Errorf("labResultInterpretation(ldl) = %q, want nil", *got)
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func derefString(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
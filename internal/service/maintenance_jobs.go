@@ -0,0 +1,70 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/repository"
+	"time"
+)
+
+// Nama job maintenance (dipakai di job_runs dan filter riwayat job)
+const (
+	JobCleanupBlacklistedTokens       = "cleanup_blacklisted_tokens"
+	JobCleanupExpiredSessions         = "cleanup_expired_sessions"
+	JobCleanupPasswordResetTokens     = "cleanup_password_reset_tokens"
+	JobCleanupEmailVerificationTokens = "cleanup_email_verification_tokens"
+	JobCleanupStaleThrottles          = "cleanup_stale_throttles"
+	JobCleanupJobRuns                 = "cleanup_job_runs"
+)
+
+// MaintenanceJobs mengembalikan job pembersihan data kadaluarsa yang dijalankan setiap interval.
+// Riwayat job_runs yang dimulai lebih lama dari retention ikut dibersihkan.
+// Semua job hanya menghapus data yang sudah tidak berlaku sehingga aman dijalankan di beberapa replika.
+func MaintenanceJobs(authRepo *repository.AuthRepository, throttleService *ThrottleService, jobRunRepo *repository.JobRunRepository, interval, retention time.Duration) []Job {
+	return []Job{
+		{
+			Name:        JobCleanupBlacklistedTokens,
+			Description: "Menghapus access token blacklist yang sudah kadaluarsa",
+			Interval:    interval,
+			Run: func(now time.Time) (int64, error) {
+				return authRepo.CleanupExpiredTokens()
+			},
+		},
+		{
+			Name:        JobCleanupExpiredSessions,
+			Description: "Menghapus sesi login yang refresh token-nya sudah kadaluarsa",
+			Interval:    interval,
+			Run: func(now time.Time) (int64, error) {
+				return authRepo.CleanupExpiredSessions()
+			},
+		},
+		{
+			Name:        JobCleanupPasswordResetTokens,
+			Description: "Menghapus token reset password yang sudah kadaluarsa",
+			Interval:    interval,
+			Run: func(now time.Time) (int64, error) {
+				return authRepo.CleanupExpiredPasswordResetTokens()
+			},
+		},
+		{
+			Name:        JobCleanupEmailVerificationTokens,
+			Description: "Menghapus token verifikasi email yang sudah kadaluarsa",
+			Interval:    interval,
+			Run: func(now time.Time) (int64, error) {
+				return authRepo.CleanupExpiredEmailVerificationTokens()
+			},
+		},
+		{
+			Name:        JobCleanupStaleThrottles,
+			Description: "Menghapus hitungan percobaan autentikasi yang tidak terkunci dan sudah melewati window",
+			Interval:    interval,
+			Run:         throttleService.CleanupStaleThrottles,
+		},
+		{
+			Name:        JobCleanupJobRuns,
+			Description: "Menghapus riwayat eksekusi job yang melewati masa simpan",
+			Interval:    interval,
+			Run: func(now time.Time) (int64, error) {
+				return jobRunRepo.CleanupOldJobRuns(now.Add(-retention))
+			},
+		},
+	}
+}
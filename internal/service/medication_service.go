@@ -0,0 +1,625 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+const (
+	// Batas persentase kepatuhan minum obat
+	adherenceGoodPercent = 80.0 // >= 80% dianggap patuh (BAIK)
+	adherenceFairPercent = 50.0 // 50-79% CUKUP, di bawahnya KURANG
+)
+
+// Status kepatuhan minum obat
+const (
+	AdherenceStatusBaik         = "BAIK"
+	AdherenceStatusCukup        = "CUKUP"
+	AdherenceStatusKurang       = "KURANG"
+	AdherenceStatusBelumAdaData = "BELUM_ADA_JADWAL"
+)
+
+// Status jadwal minum obat yang belum dicatat
+const (
+	medicationSchedulePending = "pending" // Belum waktunya minum obat
+	medicationScheduleDue     = "due"     // Sudah waktunya, belum dicatat
+)
+
+// MedicationService menangani business logic untuk daftar obat, log minum obat, dan kepatuhan
+type MedicationService struct {
+	medicationRepo *repository.MedicationRepository
+}
+
+// NewMedicationService membuat instance baru dari MedicationService
+func NewMedicationService(medicationRepo *repository.MedicationRepository) *MedicationService {
+	return &MedicationService{
+		medicationRepo: medicationRepo,
+	}
+}
+
+// CreateMedication menambahkan obat baru milik user
+func (s *MedicationService) CreateMedication(userID uint, req *request.CreateMedicationRequest) (*response.MedicationResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("nama obat tidak boleh kosong")
+	}
+	dose := strings.TrimSpace(req.Dose)
+	if dose == "" {
+		return nil, errors.New("dosis obat tidak boleh kosong")
+	}
+
+	scheduleTimes, err := normalizeScheduleTimes(req.ScheduleTimes)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := recordDateOf(timezoneUtils.NowInJakarta())
+	if req.StartDate != nil {
+		startDate, err = parseMedicationDate(*req.StartDate, "start_date")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var endDate *time.Time
+	if req.EndDate != nil && strings.TrimSpace(*req.EndDate) != "" {
+		parsed, err := parseMedicationDate(*req.EndDate, "end_date")
+		if err != nil {
+			return nil, err
+		}
+		endDate = &parsed
+	}
+	if endDate != nil && endDate.Before(startDate) {
+		return nil, errors.New("end_date tidak boleh sebelum start_date")
+	}
+
+	medication := &entity.Medication{
+		UserID:        userID,
+		Name:          name,
+		Dose:          dose,
+		Instructions:  trimOptionalString(req.Instructions),
+		Purpose:       trimOptionalString(req.Purpose),
+		ScheduleTimes: scheduleTimes,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		IsActive:      true,
+	}
+
+	if err := s.medicationRepo.CreateMedication(medication); err != nil {
+		return nil, err
+	}
+
+	return mapMedicationToResponse(medication), nil
+}
+
+// UpdateMedication mengubah data obat milik user (partial update)
+// Menonaktifkan obat mengakhiri masa pakainya hari ini sehingga jadwal berikutnya tidak dihitung dalam kepatuhan
+func (s *MedicationService) UpdateMedication(userID, medicationID uint, req *request.UpdateMedicationRequest) (*response.MedicationResponse, error) {
+	medication, err := s.medicationRepo.GetMedicationByIDAndUserID(medicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("nama obat tidak boleh kosong")
+		}
+		medication.Name = name
+	}
+	if req.Dose != nil {
+		dose := strings.TrimSpace(*req.Dose)
+		if dose == "" {
+			return nil, errors.New("dosis obat tidak boleh kosong")
+		}
+		medication.Dose = dose
+	}
+	if req.ScheduleTimes != nil {
+		scheduleTimes, err := normalizeScheduleTimes(*req.ScheduleTimes)
+		if err != nil {
+			return nil, err
+		}
+		medication.ScheduleTimes = scheduleTimes
+	}
+	if req.StartDate != nil {
+		startDate, err := parseMedicationDate(*req.StartDate, "start_date")
+		if err != nil {
+			return nil, err
+		}
+		medication.StartDate = startDate
+	}
+	if req.EndDate != nil {
+		if strings.TrimSpace(*req.EndDate) == "" {
+			medication.EndDate = nil
+		} else {
+			endDate, err := parseMedicationDate(*req.EndDate, "end_date")
+			if err != nil {
+				return nil, err
+			}
+			medication.EndDate = &endDate
+		}
+	}
+	if req.Instructions != nil {
+		medication.Instructions = trimOptionalString(req.Instructions)
+	}
+	if req.Purpose != nil {
+		medication.Purpose = trimOptionalString(req.Purpose)
+	}
+
+	if req.IsActive != nil && *req.IsActive != medication.IsActive {
+		today := recordDateOf(timezoneUtils.NowInJakarta())
+		if *req.IsActive {
+			// Aktifkan kembali: hapus tanggal akhir yang sudah lewat (kecuali dikirim pada request yang sama)
+			if req.EndDate == nil && medication.EndDate != nil && recordDateOf(*medication.EndDate).Before(today) {
+				medication.EndDate = nil
+			}
+		} else if medication.EndDate == nil || recordDateOf(*medication.EndDate).After(today) {
+			medication.EndDate = &today
+		}
+		medication.IsActive = *req.IsActive
+	}
+
+	if medication.EndDate != nil && recordDateOf(*medication.EndDate).Before(recordDateOf(medication.StartDate)) {
+		return nil, errors.New("end_date tidak boleh sebelum start_date")
+	}
+
+	if err := s.medicationRepo.UpdateMedication(medication); err != nil {
+		return nil, err
+	}
+
+	return mapMedicationToResponse(medication), nil
+}
+
+// DeleteMedication menghapus obat milik user beserta seluruh log minum obatnya
+func (s *MedicationService) DeleteMedication(userID, medicationID uint) error {
+	medication, err := s.medicationRepo.GetMedicationByIDAndUserID(medicationID, userID)
+	if err != nil {
+		return err
+	}
+	return s.medicationRepo.DeleteMedication(medication.ID)
+}
+
+// GetMedications mengambil daftar obat milik user dengan filter status dan pagination
+func (s *MedicationService) GetMedications(userID uint, req *request.MedicationListRequest) (*response.MedicationListResponse, error) {
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	medications, total, err := s.medicationRepo.GetMedicationsWithFilter(userID, repository.MedicationFilter{IsActive: req.IsActive}, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.MedicationResponse, 0, len(medications))
+	for i := range medications {
+		items = append(items, *mapMedicationToResponse(&medications[i]))
+	}
+
+	return &response.MedicationListResponse{
+		Medications: items,
+		Pagination:  newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// LogDose mencatat satu jadwal minum obat sebagai diminum (taken) atau terlewat (missed)
+// Jadwal yang sudah pernah dicatat akan diperbarui statusnya.
+// Untuk obat bila perlu, scheduled_at adalah waktu minum obat dan status harus taken.
+func (s *MedicationService) LogDose(userID, medicationID uint, req *request.LogMedicationDoseRequest) (*response.MedicationLogResponse, error) {
+	medication, err := s.medicationRepo.GetMedicationByIDAndUserID(medicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := entity.MedicationLogStatus(req.Status)
+	if !entity.IsValidMedicationLogStatus(status) {
+		return nil, errors.New("status harus taken atau missed")
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	scheduledAt := timezoneUtils.ToJakarta(*req.ScheduledAt).Truncate(time.Minute)
+	scheduledDate := recordDateOf(scheduledAt)
+
+	schedule := medication.Schedule()
+	if len(schedule) == 0 {
+		if status != entity.MedicationLogTaken {
+			return nil, errors.New("status missed tidak boleh untuk obat bila perlu")
+		}
+		if scheduledAt.After(now.Add(measuredAtClockSkew)) {
+			return nil, errors.New("scheduled_at tidak boleh di masa depan")
+		}
+	} else {
+		if !containsString(schedule, scheduledAt.Format("15:04")) {
+			return nil, fmt.Errorf("scheduled_at harus sesuai jam jadwal obat (%s)", strings.Join(schedule, ", "))
+		}
+		// Obat boleh dicatat lebih awal pada hari yang sama, tetapi tidak untuk hari berikutnya
+		if scheduledDate.After(recordDateOf(now)) {
+			return nil, errors.New("scheduled_at tidak boleh di hari mendatang")
+		}
+	}
+	if !medicationActiveOn(medication, scheduledDate) {
+		return nil, errors.New("scheduled_at harus berada dalam masa pakai obat")
+	}
+
+	var takenAt *time.Time
+	if status == entity.MedicationLogTaken {
+		t := now
+		if req.TakenAt != nil {
+			t = timezoneUtils.ToJakarta(*req.TakenAt)
+			if t.After(now.Add(measuredAtClockSkew)) {
+				return nil, errors.New("taken_at tidak boleh di masa depan")
+			}
+		}
+		takenAt = &t
+	} else if req.TakenAt != nil {
+		return nil, errors.New("taken_at tidak boleh diisi untuk status missed")
+	}
+
+	log, err := s.medicationRepo.GetMedicationLogBySchedule(medication.ID, scheduledAt)
+	if err != nil {
+		return nil, err
+	}
+	if log == nil {
+		log = &entity.MedicationLog{
+			MedicationID: medication.ID,
+			UserID:       userID,
+			ScheduledAt:  scheduledAt,
+		}
+	}
+	log.Status = status
+	log.TakenAt = takenAt
+	log.Notes = trimOptionalString(req.Notes)
+
+	if err := s.medicationRepo.SaveMedicationLog(log); err != nil {
+		return nil, err
+	}
+
+	return mapMedicationLogToResponse(log), nil
+}
+
+// GetMedicationLogs mengambil riwayat minum satu obat dengan filter tanggal dan pagination
+func (s *MedicationService) GetMedicationLogs(userID, medicationID uint, req *request.MedicationLogListRequest) (*response.MedicationLogListResponse, error) {
+	medication, err := s.medicationRepo.GetMedicationByIDAndUserID(medicationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var startDate, endDate *time.Time
+	if req.StartDate != nil {
+		start := recordDateOf(*req.StartDate)
+		startDate = &start
+	}
+	if req.EndDate != nil {
+		end := recordDateOf(*req.EndDate).Add(24*time.Hour - time.Second)
+		endDate = &end
+	}
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return nil, errors.New("end_date tidak boleh sebelum start_date")
+	}
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	logs, total, err := s.medicationRepo.GetMedicationLogsWithFilter(medication.ID, startDate, endDate, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.MedicationLogResponse, 0, len(logs))
+	for i := range logs {
+		items = append(items, *mapMedicationLogToResponse(&logs[i]))
+	}
+
+	return &response.MedicationLogListResponse{
+		Medication: *mapMedicationToResponse(medication),
+		Logs:       items,
+		Pagination: newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// GetDailySchedule mengambil jadwal minum obat pada satu hari (default hari ini) beserta statusnya
+func (s *MedicationService) GetDailySchedule(userID uint, req *request.MedicationScheduleRequest) (*response.MedicationScheduleResponse, error) {
+	now := timezoneUtils.NowInJakarta()
+	day := recordDateOf(now)
+	if req.Date != nil {
+		day = recordDateOf(*req.Date)
+	}
+	dayEnd := day.Add(24*time.Hour - time.Second)
+
+	medications, err := s.medicationRepo.GetMedicationsInPeriod(userID, day, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.medicationRepo.GetMedicationLogsByUserID(userID, day, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	logIndex := indexMedicationLogs(logs)
+
+	items := []response.MedicationScheduleItem{}
+	for i := range medications {
+		medication := &medications[i]
+		for _, slot := range medicationSlotsOn(medication, day) {
+			item := response.MedicationScheduleItem{
+				MedicationID: medication.ID,
+				Name:         medication.Name,
+				Dose:         medication.Dose,
+				Instructions: medication.Instructions,
+				ScheduledAt:  slot,
+				Time:         slot.Format("15:04"),
+				Status:       medicationSchedulePending,
+			}
+			if log, ok := logIndex[medicationLogKey(medication.ID, slot)]; ok {
+				logID := log.ID
+				item.Status = string(log.Status)
+				item.TakenAt = log.TakenAt
+				item.LogID = &logID
+			} else if !slot.After(now) {
+				item.Status = medicationScheduleDue
+			}
+			items = append(items, item)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].ScheduledAt.Equal(items[j].ScheduledAt) {
+			return items[i].ScheduledAt.Before(items[j].ScheduledAt)
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	return &response.MedicationScheduleResponse{
+		Date:  day.Format("2006-01-02"),
+		Items: items,
+	}, nil
+}
+
+// GetAdherence menghitung kepatuhan minum obat pada rentang waktu yang sama dengan riwayat kesehatan
+// (7days, 30days, 3months, atau custom)
+func (s *MedicationService) GetAdherence(userID uint, req *request.MedicationAdherenceRequest) (*response.MedicationAdherenceResponse, error) {
+	startDate, endDate, err := parseTimeRange(&request.HealthHistoryRequest{
+		TimeRange: req.TimeRange,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end_date tidak boleh sebelum start_date")
+	}
+
+	return s.GetAdherenceForPeriod(userID, startDate, endDate)
+}
+
+// GetAdherenceForPeriod menghitung kepatuhan minum obat terjadwal pada rentang waktu
+// Kepatuhan = dosis diminum / jadwal yang sudah lewat. Jadwal yang tidak dicatat dihitung terlewat,
+// jadwal yang belum waktunya hanya dihitung jika sudah dicatat. Obat bila perlu tidak dihitung.
+func (s *MedicationService) GetAdherenceForPeriod(userID uint, startDate, endDate time.Time) (*response.MedicationAdherenceResponse, error) {
+	medications, err := s.medicationRepo.GetMedicationsInPeriod(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.medicationRepo.GetMedicationLogsByUserID(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	logIndex := indexMedicationLogs(logs)
+
+	now := timezoneUtils.NowInJakarta()
+	result := &response.MedicationAdherenceResponse{
+		StartDate:   startDate.Format("2006-01-02"),
+		EndDate:     endDate.Format("2006-01-02"),
+		Medications: []response.MedicationAdherenceItem{},
+		Daily:       []response.DailyAdherencePoint{},
+	}
+	dailyByDate := make(map[string]*response.DailyAdherencePoint)
+
+	for i := range medications {
+		medication := &medications[i]
+		if len(medication.Schedule()) == 0 {
+			continue
+		}
+
+		item := response.MedicationAdherenceItem{
+			MedicationID: medication.ID,
+			Name:         medication.Name,
+			Dose:         medication.Dose,
+		}
+		for day := recordDateOf(startDate); !day.After(endDate); day = day.AddDate(0, 0, 1) {
+			for _, slot := range medicationSlotsOn(medication, day) {
+				log, logged := logIndex[medicationLogKey(medication.ID, slot)]
+				if !logged && slot.After(now) {
+					continue
+				}
+
+				dateKey := day.Format("2006-01-02")
+				daily, ok := dailyByDate[dateKey]
+				if !ok {
+					daily = &response.DailyAdherencePoint{Date: dateKey}
+					dailyByDate[dateKey] = daily
+				}
+
+				item.ExpectedDoses++
+				daily.ExpectedDoses++
+				switch {
+				case !logged:
+					item.UnloggedDoses++
+				case log.Status == entity.MedicationLogTaken:
+					item.TakenDoses++
+					daily.TakenDoses++
+				default:
+					item.MissedDoses++
+				}
+			}
+		}
+
+		item.AdherencePercent = adherencePercent(item.TakenDoses, item.ExpectedDoses)
+		item.Status = adherenceStatus(item.AdherencePercent)
+		result.Medications = append(result.Medications, item)
+
+		result.ExpectedDoses += item.ExpectedDoses
+		result.TakenDoses += item.TakenDoses
+		result.MissedDoses += item.MissedDoses + item.UnloggedDoses
+	}
+
+	for _, daily := range dailyByDate {
+		daily.AdherencePercent = *adherencePercent(daily.TakenDoses, daily.ExpectedDoses)
+		result.Daily = append(result.Daily, *daily)
+	}
+	sort.Slice(result.Daily, func(i, j int) bool {
+		return result.Daily[i].Date < result.Daily[j].Date
+	})
+
+	result.AdherencePercent = adherencePercent(result.TakenDoses, result.ExpectedDoses)
+	result.Status = adherenceStatus(result.AdherencePercent)
+
+	return result, nil
+}
+
+// normalizeScheduleTimes memvalidasi jam jadwal (HH:MM), menghapus duplikat, dan mengurutkannya
+func normalizeScheduleTimes(times []string) (string, error) {
+	seen := make(map[string]bool, len(times))
+	normalized := make([]string, 0, len(times))
+	for _, value := range times {
+		t, err := time.Parse("15:04", strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("jam jadwal obat harus berformat HH:MM (%q)", value)
+		}
+		formatted := t.Format("15:04")
+		if seen[formatted] {
+			continue
+		}
+		seen[formatted] = true
+		normalized = append(normalized, formatted)
+	}
+	sort.Strings(normalized)
+	return strings.Join(normalized, ","), nil
+}
+
+// parseMedicationDate mengubah tanggal YYYY-MM-DD menjadi awal hari di zona waktu Asia/Jakarta
+func parseMedicationDate(value, field string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s harus berformat YYYY-MM-DD", field)
+	}
+	return timezoneUtils.DateInJakarta(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0), nil
+}
+
+// medicationActiveOn memeriksa apakah tanggal (awal hari Asia/Jakarta) berada dalam masa pakai obat
+func medicationActiveOn(medication *entity.Medication, day time.Time) bool {
+	if day.Before(recordDateOf(medication.StartDate)) {
+		return false
+	}
+	if medication.EndDate != nil && day.After(recordDateOf(*medication.EndDate)) {
+		return false
+	}
+	return true
+}
+
+// medicationSlotsOn mengembalikan waktu jadwal minum obat pada satu hari
+// Kosong jika hari tersebut di luar masa pakai obat
+func medicationSlotsOn(medication *entity.Medication, day time.Time) []time.Time {
+	if !medicationActiveOn(medication, day) {
+		return nil
+	}
+	schedule := medication.Schedule()
+	slots := make([]time.Time, 0, len(schedule))
+	for _, value := range schedule {
+		t, err := time.Parse("15:04", value)
+		if err != nil {
+			continue
+		}
+		slots = append(slots, timezoneUtils.DateInJakarta(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0))
+	}
+	return slots
+}
+
+// medicationLogKey membuat kunci pencarian log berdasarkan obat dan jadwalnya
+func medicationLogKey(medicationID uint, scheduledAt time.Time) string {
+	return fmt.Sprintf("%d:%d", medicationID, scheduledAt.Unix())
+}
+
+// indexMedicationLogs memetakan log minum obat berdasarkan obat dan jadwalnya
+func indexMedicationLogs(logs []entity.MedicationLog) map[string]entity.MedicationLog {
+	index := make(map[string]entity.MedicationLog, len(logs))
+	for _, log := range logs {
+		index[medicationLogKey(log.MedicationID, log.ScheduledAt)] = log
+	}
+	return index
+}
+
+// adherencePercent menghitung persentase kepatuhan, nil jika belum ada jadwal
+func adherencePercent(taken, expected int) *float64 {
+	if expected == 0 {
+		return nil
+	}
+	percent := roundTo1Decimal(float64(taken) / float64(expected) * 100)
+	return &percent
+}
+
+// adherenceStatus menentukan status kepatuhan dari persentasenya
+func adherenceStatus(percent *float64) string {
+	switch {
+	case percent == nil:
+		return AdherenceStatusBelumAdaData
+	case *percent >= adherenceGoodPercent:
+		return AdherenceStatusBaik
+	case *percent >= adherenceFairPercent:
+		return AdherenceStatusCukup
+	default:
+		return AdherenceStatusKurang
+	}
+}
+
+// containsString memeriksa apakah slice berisi nilai tertentu
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// mapMedicationToResponse mengubah entity obat ke response
+func mapMedicationToResponse(medication *entity.Medication) *response.MedicationResponse {
+	resp := &response.MedicationResponse{
+		ID:            medication.ID,
+		UserID:        medication.UserID,
+		Name:          medication.Name,
+		Dose:          medication.Dose,
+		ScheduleTimes: medication.Schedule(),
+		AsNeeded:      medication.ScheduleTimes == "",
+		StartDate:     timezoneUtils.ToJakarta(medication.StartDate).Format("2006-01-02"),
+		Instructions:  medication.Instructions,
+		Purpose:       medication.Purpose,
+		IsActive:      medication.IsActive,
+		CreatedAt:     medication.CreatedAt,
+		UpdatedAt:     medication.UpdatedAt,
+	}
+	if medication.EndDate != nil {
+		endDate := timezoneUtils.ToJakarta(*medication.EndDate).Format("2006-01-02")
+		resp.EndDate = &endDate
+	}
+	return resp
+}
+
+// mapMedicationLogToResponse mengubah entity log minum obat ke response
+func mapMedicationLogToResponse(log *entity.MedicationLog) *response.MedicationLogResponse {
+	return &response.MedicationLogResponse{
+		ID:           log.ID,
+		MedicationID: log.MedicationID,
+		ScheduledAt:  timezoneUtils.ToJakarta(log.ScheduledAt),
+		Status:       string(log.Status),
+		TakenAt:      log.TakenAt,
+		Notes:        log.Notes,
+		CreatedAt:    log.CreatedAt,
+		UpdatedAt:    log.UpdatedAt,
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"encoding/json"
+	"errors"
+	"log"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// NotificationService menangani inbox notifikasi in-app: publikasi notifikasi dari alert, pengingat,
+// dan pencapaian target, serta daftar, status baca, dan jumlah notifikasi yang belum dibaca.
+// NotificationService juga memenuhi interface Notifier sehingga dapat dipakai langsung oleh scheduler.
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+	userRepo         *repository.UserRepository
+	notifier         Notifier
+}
+
+// NewNotificationService membuat instance baru dari NotificationService
+// notifier adalah pengirim akhir notifikasi (inbox in-app atau log)
+func NewNotificationService(notificationRepo *repository.NotificationRepository, userRepo *repository.UserRepository, notifier Notifier) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		notifier:         notifier,
+	}
+}
+
+// Notify mengirim notifikasi ke user sesuai pengaturannya
+// Notifikasi tidak dikirim (false, nil) jika user menonaktifkan notifikasi.
+// Judul dan isi disusun dari template sesuai bahasa user.
+func (s *NotificationService) Notify(msg NotificationMessage) (bool, error) {
+	user, err := s.userRepo.GetUserByID(msg.UserID)
+	if err != nil {
+		return false, err
+	}
+	if user.NotificationEnabled != nil && !*user.NotificationEnabled {
+		return false, nil
+	}
+
+	if title, message, ok := renderNotification(msg.Type, msg.Params, resolveNotificationLanguage(user.Language)); ok {
+		msg.Title = title
+		msg.Message = message
+		// Params kosong tetap disimpan agar notifikasi dapat ditampilkan ulang dalam bahasa lain
+		if msg.Params == nil {
+			msg.Params = map[string]string{}
+		}
+	}
+	if msg.Title == "" || msg.Message == "" {
+		return false, errors.New("judul dan isi notifikasi tidak boleh kosong")
+	}
+
+	return s.notifier.Notify(msg)
+}
+
+// publish mengirim notifikasi dari proses lain (alert, target) tanpa menggagalkan proses tersebut
+// Error hanya dicatat ke log karena notifikasi bersifat pelengkap
+func (s *NotificationService) publish(msg NotificationMessage) {
+	if s == nil {
+		return
+	}
+	if _, err := s.Notify(msg); err != nil {
+		log.Printf("Warning: Gagal mengirim notifikasi %s ke user %d: %v", msg.Type, msg.UserID, err)
+	}
+}
+
+// GetNotifications mengambil inbox notifikasi milik user dengan filter dan pagination
+// Notifikasi ditampilkan sesuai bahasa user saat ini
+func (s *NotificationService) GetNotifications(userID uint, req *request.NotificationListRequest) (*response.NotificationListResponse, error) {
+	notificationType := entity.NotificationType(req.Type)
+	if notificationType != "" && !entity.IsValidNotificationType(notificationType) {
+		return nil, errors.New("type harus salah satu dari medication_reminder, measurement_reminder, missed_day_nudge, health_alert, target_achieved")
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	language := resolveNotificationLanguage(user.Language)
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	filter := repository.NotificationFilter{
+		UnreadOnly: req.UnreadOnly,
+		Type:       notificationType,
+	}
+	notifications, total, err := s.notificationRepo.GetNotificationsWithFilter(userID, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCount, err := s.notificationRepo.CountUnreadNotifications(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.NotificationResponse, 0, len(notifications))
+	for i := range notifications {
+		items = append(items, *mapNotificationToResponse(&notifications[i], language))
+	}
+
+	return &response.NotificationListResponse{
+		Notifications: items,
+		UnreadCount:   unreadCount,
+		Pagination:    newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// GetUnreadNotificationCount mengambil jumlah notifikasi yang belum dibaca (untuk badge)
+func (s *NotificationService) GetUnreadNotificationCount(userID uint) (*response.NotificationUnreadCountResponse, error) {
+	count, err := s.notificationRepo.CountUnreadNotifications(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.NotificationUnreadCountResponse{
+		UnreadCount: count,
+	}, nil
+}
+
+// MarkNotificationRead menandai satu notifikasi milik user sebagai sudah dibaca
+// Notifikasi yang sudah dibaca tidak diubah waktu bacanya
+func (s *NotificationService) MarkNotificationRead(userID, notificationID uint) (*response.NotificationResponse, error) {
+	notification, err := s.notificationRepo.GetNotificationByIDAndUserID(notificationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !notification.IsRead() {
+		if err := s.notificationRepo.MarkNotificationRead(notification, timezoneUtils.NowInJakarta()); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapNotificationToResponse(notification, resolveNotificationLanguage(user.Language)), nil
+}
+
+// MarkAllNotificationsRead menandai semua notifikasi yang belum dibaca milik user sebagai sudah dibaca
+func (s *NotificationService) MarkAllNotificationsRead(userID uint) (*response.MarkAllNotificationsReadResponse, error) {
+	updated, err := s.notificationRepo.MarkAllNotificationsRead(userID, timezoneUtils.NowInJakarta())
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.MarkAllNotificationsReadResponse{
+		UpdatedCount: updated,
+	}, nil
+}
+
+// DeleteNotification menghapus notifikasi milik user dari inbox
+func (s *NotificationService) DeleteNotification(userID, notificationID uint) error {
+	notification, err := s.notificationRepo.GetNotificationByIDAndUserID(notificationID, userID)
+	if err != nil {
+		return err
+	}
+	return s.notificationRepo.DeleteNotification(notification.ID)
+}
+
+// mapNotificationToResponse mengubah entity notifikasi ke response sesuai bahasa user
+// Jika Params tersedia, judul dan isi disusun ulang dari template; jika tidak, dipakai teks tersimpan
+func mapNotificationToResponse(notification *entity.Notification, language string) *response.NotificationResponse {
+	title, message := notification.Title, notification.Message
+	if notification.Params != "" {
+		var params map[string]string
+		if err := json.Unmarshal([]byte(notification.Params), &params); err == nil {
+			if renderedTitle, renderedMessage, ok := renderNotification(notification.Type, params, language); ok {
+				title, message = renderedTitle, renderedMessage
+			}
+		}
+	}
+
+	return &response.NotificationResponse{
+		ID:          notification.ID,
+		Type:        string(notification.Type),
+		Title:       title,
+		Message:     message,
+		ReferenceID: notification.ReferenceID,
+		IsRead:      notification.IsRead(),
+		ReadAt:      notification.ReadAt,
+		CreatedAt:   notification.CreatedAt,
+	}
+}
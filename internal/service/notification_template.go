@@ -0,0 +1,144 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"strings"
+)
+
+// Bahasa notifikasi yang didukung (sesuai pengaturan language user)
+const (
+	NotificationLanguageID = "id"
+	NotificationLanguageEN = "en"
+)
+
+// notificationTemplate berisi judul dan isi notifikasi per bahasa
+// Placeholder ditulis sebagai {nama_param} dan diganti dengan nilai Params
+type notificationTemplate struct {
+	title   map[string]string
+	message map[string]string
+}
+
+// notificationTemplates adalah template untuk setiap jenis notifikasi
+var notificationTemplates = map[entity.NotificationType]notificationTemplate{
+	entity.NotificationMedicationReminder: {
+		title: map[string]string{
+			NotificationLanguageID: "Waktunya minum obat",
+			NotificationLanguageEN: "Time to take your medication",
+		},
+		message: map[string]string{
+			NotificationLanguageID: "Saatnya minum {medication} {dose} (jadwal {time}).{instructions}",
+			NotificationLanguageEN: "Time to take {medication} {dose} (scheduled at {time}).{instructions}",
+		},
+	},
+	entity.NotificationMeasurementReminder: {
+		title: map[string]string{
+			NotificationLanguageID: "Waktunya mengukur tekanan darah",
+			NotificationLanguageEN: "Time to check your blood pressure",
+		},
+		message: map[string]string{
+			NotificationLanguageID: "Anda belum mencatat tekanan darah hari ini. Ukur dalam posisi duduk setelah istirahat 5 menit, lalu catat hasilnya.",
+			NotificationLanguageEN: "You haven't recorded your blood pressure today. Measure it while seated after resting for 5 minutes, then record the result.",
+		},
+	},
+	entity.NotificationMissedDayNudge: {
+		title: map[string]string{
+			NotificationLanguageID: "Jangan lupa catat data kesehatan",
+			NotificationLanguageEN: "Don't forget to log your health data",
+		},
+		message: map[string]string{
+			NotificationLanguageID: "Anda belum mencatat data kesehatan sejak {last_date} ({days} hari lalu). Catat pemeriksaan hari ini agar riwayat kesehatan tetap lengkap.",
+			NotificationLanguageEN: "You haven't logged any health data since {last_date} ({days} days ago). Log a reading today to keep your health history complete.",
+		},
+	},
+	entity.NotificationHealthAlert: {
+		title: map[string]string{
+			NotificationLanguageID: "Peringatan kesehatan: {category}",
+			NotificationLanguageEN: "Health alert: {category}",
+		},
+		message: map[string]string{
+			NotificationLanguageID: "Hasil {category} Anda {status} ({value}). Buka riwayat alert untuk melihat rekomendasi.",
+			NotificationLanguageEN: "Your {category} reading is {status} ({value}). Open your alert history to see recommendations.",
+		},
+	},
+	entity.NotificationTargetAchieved: {
+		title: map[string]string{
+			NotificationLanguageID: "Target {metric} tercapai",
+			NotificationLanguageEN: "Target reached: {metric}",
+		},
+		message: map[string]string{
+			NotificationLanguageID: "Selamat! Hasil {metric} Anda {value} sudah memenuhi target {target}.",
+			NotificationLanguageEN: "Great job! Your {metric} reading of {value} meets your target of {target}.",
+		},
+	},
+}
+
+// notificationParamLabels menerjemahkan nilai param berupa kode (kategori, status, metrik) per bahasa
+var notificationParamLabels = map[string]map[string]map[string]string{
+	NotificationLanguageID: {
+		"category": {
+			CategoryDiabetes:   "gula darah",
+			CategoryHipertensi: "tekanan darah",
+			CategoryJantung:    "detak jantung",
+			CategoryBeratBadan: "berat badan",
+			CategoryPernapasan: "pernapasan",
+			CategorySuhuTubuh:  "suhu tubuh",
+		},
+		"status": {
+			StatusRendah: "rendah",
+			StatusTinggi: "tinggi",
+		},
+		"metric": {
+			"tekanan_darah": "tekanan darah",
+			"gula_darah":    "gula darah",
+			"berat_badan":   "berat badan",
+		},
+	},
+	NotificationLanguageEN: {
+		"category": {
+			CategoryDiabetes:   "blood sugar",
+			CategoryHipertensi: "blood pressure",
+			CategoryJantung:    "heart rate",
+			CategoryBeratBadan: "weight",
+			CategoryPernapasan: "breathing",
+			CategorySuhuTubuh:  "body temperature",
+		},
+		"status": {
+			StatusRendah: "low",
+			StatusTinggi: "high",
+		},
+		"metric": {
+			"tekanan_darah": "blood pressure",
+			"gula_darah":    "blood sugar",
+			"berat_badan":   "weight",
+		},
+	},
+}
+
+// resolveNotificationLanguage menentukan bahasa notifikasi dari pengaturan user (default Indonesia)
+func resolveNotificationLanguage(language *string) string {
+	if language != nil && strings.EqualFold(strings.TrimSpace(*language), NotificationLanguageEN) {
+		return NotificationLanguageEN
+	}
+	return NotificationLanguageID
+}
+
+// renderNotification menyusun judul dan isi notifikasi sesuai bahasa
+// ok bernilai false jika jenis notifikasi tidak memiliki template
+func renderNotification(notificationType entity.NotificationType, params map[string]string, language string) (title, message string, ok bool) {
+	tmpl, found := notificationTemplates[notificationType]
+	if !found {
+		return "", "", false
+	}
+
+	labels := notificationParamLabels[language]
+	replacements := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		if label, translated := labels[key][value]; translated {
+			value = label
+		}
+		replacements = append(replacements, "{"+key+"}", value)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	return replacer.Replace(tmpl.title[language]), replacer.Replace(tmpl.message[language]), true
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Driver pengiriman notifikasi yang didukung
+const (
+	NotifierDriverInbox = "inbox"
+	NotifierDriverLog   = "log"
+)
+
+// NotificationMessage adalah notifikasi yang akan dikirim ke user
+// Title dan Message disusun oleh NotificationService dari template Type dan Params sesuai bahasa user
+type NotificationMessage struct {
+	UserID      uint
+	Type        entity.NotificationType
+	Params      map[string]string
+	Title       string
+	Message     string
+	ReferenceID *uint
+
+	// DedupKey (opsional) mencegah notifikasi yang sama dikirim lebih dari sekali ke user yang sama
+	DedupKey string
+}
+
+// Notifier adalah interface pengirim notifikasi.
+// Implementasi dapat diganti (inbox in-app, log untuk development, atau push notification di kemudian hari).
+// Notify mengembalikan false tanpa error jika notifikasi dengan DedupKey yang sama sudah pernah dikirim.
+type Notifier interface {
+	Notify(msg NotificationMessage) (bool, error)
+}
+
+// NewNotifier membuat Notifier berdasarkan driver (default inbox)
+func NewNotifier(driver string, notificationRepo *repository.NotificationRepository) (Notifier, error) {
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case "", NotifierDriverInbox:
+		return NewInboxNotifier(notificationRepo), nil
+	case NotifierDriverLog:
+		return NewLogNotifier(), nil
+	default:
+		return nil, fmt.Errorf("notifier driver %q tidak dikenal (gunakan inbox atau log)", driver)
+	}
+}
+
+// InboxNotifier menyimpan notifikasi ke inbox in-app (tabel notifications)
+type InboxNotifier struct {
+	notificationRepo *repository.NotificationRepository
+}
+
+// NewInboxNotifier membuat instance baru dari InboxNotifier
+func NewInboxNotifier(notificationRepo *repository.NotificationRepository) *InboxNotifier {
+	return &InboxNotifier{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Notify menyimpan notifikasi ke inbox user
+func (n *InboxNotifier) Notify(msg NotificationMessage) (bool, error) {
+	notification := &entity.Notification{
+		UserID:      msg.UserID,
+		Type:        msg.Type,
+		Title:       msg.Title,
+		Message:     msg.Message,
+		ReferenceID: msg.ReferenceID,
+	}
+	if msg.Params != nil {
+		params, err := json.Marshal(msg.Params)
+		if err != nil {
+			return false, err
+		}
+		notification.Params = string(params)
+	}
+	if msg.DedupKey != "" {
+		dedupKey := msg.DedupKey
+		notification.DedupKey = &dedupKey
+	}
+	return n.notificationRepo.CreateNotification(notification)
+}
+
+// LogNotifier menulis notifikasi ke log aplikasi (untuk development)
+// Tidak melakukan deduplikasi sehingga setiap pemanggilan selalu dianggap terkirim
+type LogNotifier struct{}
+
+// NewLogNotifier membuat instance baru dari LogNotifier
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify menulis isi notifikasi ke log
+func (n *LogNotifier) Notify(msg NotificationMessage) (bool, error) {
+	log.Printf("[Notifier] User: %d | Type: %s | Title: %s\n%s", msg.UserID, msg.Type, msg.Title, msg.Message)
+	return true, nil
+}
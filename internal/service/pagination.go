@@ -0,0 +1,33 @@
+package service
+
+import "BE-PeriksaKesehatan/internal/model/dto/response"
+
+// Batas pagination yang sama untuk semua endpoint daftar
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// normalizePagination menerapkan default dan batas parameter page dan limit
+func normalizePagination(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	return page, limit
+}
+
+// newPaginationResponse menyusun metadata pagination dari total data
+func newPaginationResponse(page, limit int, total int64) response.PaginationResponse {
+	return response.PaginationResponse{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+}
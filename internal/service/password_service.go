@@ -0,0 +1,193 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"BE-PeriksaKesehatan/pkg/mailer"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordService menangani ganti password dan alur lupa/reset password
+type PasswordService struct {
+	userRepo         *repository.UserRepository
+	authRepo         *repository.AuthRepository
+	throttleService  *ThrottleService
+	mailSender       mailer.Sender
+	passwordResetTTL time.Duration
+	passwordResetURL string
+}
+
+// NewPasswordService membuat instance baru dari PasswordService
+func NewPasswordService(userRepo *repository.UserRepository, authRepo *repository.AuthRepository, throttleService *ThrottleService, mailSender mailer.Sender, passwordResetTTL time.Duration, passwordResetURL string) *PasswordService {
+	return &PasswordService{
+		userRepo:         userRepo,
+		authRepo:         authRepo,
+		throttleService:  throttleService,
+		mailSender:       mailSender,
+		passwordResetTTL: passwordResetTTL,
+		passwordResetURL: passwordResetURL,
+	}
+}
+
+// ChangePassword mengganti password user yang sedang login setelah memverifikasi password saat ini.
+// Semua sesi lain dicabut; sesi yang sedang dipakai (currentSessionID) tetap aktif.
+func (s *PasswordService) ChangePassword(userID, currentSessionID uint, req *request.ChangePasswordRequest) error {
+	if req.NewPassword != req.ConfirmPassword {
+		return errors.New("password baru dan konfirmasi password tidak sama")
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return errors.New("password saat ini salah")
+	}
+	if req.CurrentPassword == req.NewPassword {
+		return errors.New("password baru tidak boleh sama dengan password saat ini")
+	}
+
+	if err := s.updatePassword(user.ID, req.NewPassword); err != nil {
+		return err
+	}
+
+	if _, err := s.authRepo.RevokeOtherSessions(user.ID, currentSessionID); err != nil {
+		return fmt.Errorf("gagal mencabut sesi lain: %w", err)
+	}
+	return nil
+}
+
+// ForgotPassword membuat token reset password dan mengirimkannya ke email user.
+// Selalu mengembalikan sukses untuk email yang tidak terdaftar agar daftar email user tidak bocor.
+func (s *PasswordService) ForgotPassword(req *request.ForgotPasswordRequest) error {
+	email := strings.TrimSpace(req.Email)
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		if err.Error() == "user tidak ditemukan" {
+			return nil
+		}
+		return err
+	}
+
+	// Hanya token terbaru yang berlaku
+	if err := s.authRepo.InvalidatePasswordResetTokens(user.ID); err != nil {
+		return err
+	}
+
+	token, err := generateSecureToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := timezoneUtils.NowInJakarta().Add(s.passwordResetTTL)
+	if err := s.authRepo.CreatePasswordResetToken(&entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.mailSender.Send(s.buildPasswordResetMessage(user, token, expiresAt)); err != nil {
+		// Tidak dikembalikan ke client agar respons tetap seragam; user bisa meminta ulang
+		log.Printf("[Password] Warning: gagal mengirim email reset password ke user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword mengganti password menggunakan token reset password.
+// Token hanya bisa dipakai sekali dan semua sesi user dicabut setelah password diganti.
+func (s *PasswordService) ResetPassword(req *request.ResetPasswordRequest) error {
+	if req.NewPassword != req.ConfirmPassword {
+		return errors.New("password baru dan konfirmasi password tidak sama")
+	}
+
+	resetToken, err := s.authRepo.GetPasswordResetTokenByHash(hashToken(strings.TrimSpace(req.Token)))
+	if err != nil {
+		if err.Error() == "token reset password tidak ditemukan" {
+			return errors.New("token reset password tidak valid")
+		}
+		return err
+	}
+
+	if resetToken.UsedAt != nil || !resetToken.ExpiresAt.After(timezoneUtils.NowInJakarta()) {
+		return errors.New("token reset password tidak valid")
+	}
+
+	marked, err := s.authRepo.MarkPasswordResetTokenUsed(resetToken.ID)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return errors.New("token reset password tidak valid")
+	}
+
+	if err := s.updatePassword(resetToken.UserID, req.NewPassword); err != nil {
+		return err
+	}
+
+	if _, err := s.authRepo.RevokeAllSessions(resetToken.UserID); err != nil {
+		return fmt.Errorf("gagal mencabut sesi: %w", err)
+	}
+
+	// Reset password membuktikan kepemilikan email, sehingga penguncian login akun dibuka
+	if err := s.throttleService.UnlockUserAccount(resetToken.UserID, "dibuka melalui reset password"); err != nil {
+		log.Printf("[Password] Warning: gagal membuka penguncian login user %d: %v", resetToken.UserID, err)
+	}
+	return nil
+}
+
+// updatePassword meng-hash password baru, menyimpannya, dan membatalkan token reset yang tersisa
+func (s *PasswordService) updatePassword(userID uint, newPassword string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateUserPassword(userID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	return s.authRepo.InvalidatePasswordResetTokens(userID)
+}
+
+// buildPasswordResetMessage menyusun email berisi link/token reset password
+func (s *PasswordService) buildPasswordResetMessage(user *entity.User, token string, expiresAt time.Time) mailer.Message {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Halo %s,\n\n", user.Nama)
+	body.WriteString("Kami menerima permintaan untuk mengatur ulang password akun Periksa Kesehatan Anda.\n\n")
+
+	if s.passwordResetURL != "" {
+		fmt.Fprintf(&body, "Buka link berikut untuk membuat password baru:\n%s\n\n", appendTokenToURL(s.passwordResetURL, token))
+	} else {
+		fmt.Fprintf(&body, "Gunakan token berikut untuk membuat password baru:\n%s\n\n", token)
+	}
+
+	fmt.Fprintf(&body, "Link/token ini hanya bisa dipakai sekali dan berlaku sampai %s WIB.\n", timezoneUtils.ToJakarta(expiresAt).Format("02-01-2006 15:04"))
+	body.WriteString("Jika Anda tidak meminta reset password, abaikan email ini.\n")
+
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "Reset Password Periksa Kesehatan",
+		Body:    body.String(),
+	}
+}
+
+// appendTokenToURL menambahkan token sebagai query parameter ?token= pada URL halaman aplikasi
+func appendTokenToURL(baseURL, token string) string {
+	separator := "?"
+	if strings.Contains(baseURL, "?") {
+		separator = "&"
+	}
+	return baseURL + separator + "token=" + url.QueryEscape(token)
+}
@@ -39,7 +39,7 @@ func (s *ProfileService) GetProfile(userID uint) (*response.ProfileResponse, err
 		return nil, err
 	}
 
-	latestHealthData, err := s.healthDataRepo.GetLatestHealthDataByUserID(userID)
+	latestHealthData, err := s.healthDataRepo.GetLatestHealthSnapshotByUserID(userID)
 	if err != nil {
 		return nil, err
 	}
@@ -370,7 +370,7 @@ func (s *ProfileService) GetHealthTargets(userID uint) (*response.HealthTargetsR
 		return nil, err
 	}
 
-	latestHealthData, err := s.healthDataRepo.GetLatestHealthDataByUserID(userID)
+	latestHealthData, err := s.healthDataRepo.GetLatestHealthSnapshotByUserID(userID)
 	if err != nil {
 		return nil, err
 	}
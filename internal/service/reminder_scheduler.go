@@ -0,0 +1,265 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+const (
+	// medicationReminderWindow adalah batas keterlambatan pengingat minum obat.
+	// Jadwal yang lewat lebih lama dari ini (mis. saat server mati) tidak diingatkan lagi.
+	medicationReminderWindow = 30 * time.Minute
+
+	// dailyReminderWindow adalah rentang waktu setelah jam pengingat harian di mana pengingat masih dikirim
+	dailyReminderWindow = 2 * time.Hour
+
+	// reminderActivityDays adalah batas hari ke belakang untuk menganggap user masih aktif mencatat data.
+	// User yang sudah lebih lama tidak mencatat tidak diingatkan lagi.
+	reminderActivityDays = 30
+
+	defaultReminderInterval        = time.Minute
+	defaultMeasurementReminderTime = "08:00"
+	defaultMissedDayNudgeTime      = "19:00"
+)
+
+// missedDayNudgeDays adalah jumlah hari sejak pencatatan terakhir yang memicu ajakan mencatat data
+// (tidak dikirim setiap hari agar tidak mengganggu)
+var missedDayNudgeDays = map[int]bool{2: true, 3: true, 7: true, 14: true, 30: true}
+
+// ReminderScheduler menjalankan pengingat berkala di dalam proses API:
+// pengingat minum obat sesuai jadwal, pengingat mengukur tekanan darah, dan ajakan mencatat data
+// setelah beberapa hari tidak mencatat. Hanya user dengan notification_enabled yang diingatkan,
+// dan setiap pengingat memakai dedup key sehingga aman dijalankan ulang.
+// Pengingat dikirim sebagai template dan params; notifier (NotificationService) menyusun teks sesuai bahasa user.
+type ReminderScheduler struct {
+	medicationRepo  *repository.MedicationRepository
+	healthDataRepo  *repository.HealthDataRepository
+	notifier        Notifier
+	interval        time.Duration
+	measurementTime time.Duration // Jam pengingat ukur tekanan darah (offset dari awal hari, Asia/Jakarta)
+	nudgeTime       time.Duration // Jam ajakan mencatat data (offset dari awal hari, Asia/Jakarta)
+}
+
+// NewReminderScheduler membuat instance baru dari ReminderScheduler
+// measurementTime dan nudgeTime berformat HH:MM (Asia/Jakarta); nilai tidak valid memakai default
+func NewReminderScheduler(medicationRepo *repository.MedicationRepository, healthDataRepo *repository.HealthDataRepository, notifier Notifier, interval time.Duration, measurementTime, nudgeTime string) *ReminderScheduler {
+	if interval <= 0 {
+		interval = defaultReminderInterval
+	}
+	return &ReminderScheduler{
+		medicationRepo:  medicationRepo,
+		healthDataRepo:  healthDataRepo,
+		notifier:        notifier,
+		interval:        interval,
+		measurementTime: parseReminderTime("REMINDER_MEASUREMENT_TIME", measurementTime, defaultMeasurementReminderTime),
+		nudgeTime:       parseReminderTime("REMINDER_NUDGE_TIME", nudgeTime, defaultMissedDayNudgeTime),
+	}
+}
+
+// Start menjalankan scheduler sampai context dibatalkan (blocking, jalankan dalam goroutine)
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	log.Printf("Info: Reminder scheduler berjalan setiap %s", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.RunOnce(timezoneUtils.NowInJakarta())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Info: Reminder scheduler dihentikan")
+			return
+		case <-ticker.C:
+			s.RunOnce(timezoneUtils.NowInJakarta())
+		}
+	}
+}
+
+// RunOnce memproses semua jenis pengingat untuk waktu now
+// Error pada satu jenis pengingat dicatat ke log tanpa menghentikan jenis pengingat lainnya
+func (s *ReminderScheduler) RunOnce(now time.Time) {
+	now = timezoneUtils.ToJakarta(now)
+
+	if err := s.sendMedicationReminders(now); err != nil {
+		log.Printf("Warning: Gagal mengirim pengingat minum obat: %v", err)
+	}
+	if err := s.sendMeasurementReminders(now); err != nil {
+		log.Printf("Warning: Gagal mengirim pengingat ukur tekanan darah: %v", err)
+	}
+	if err := s.sendMissedDayNudges(now); err != nil {
+		log.Printf("Warning: Gagal mengirim ajakan mencatat data: %v", err)
+	}
+}
+
+// sendMedicationReminders mengingatkan jadwal minum obat yang jatuh tempo dalam medicationReminderWindow
+// dan belum dicatat (diminum maupun terlewat)
+func (s *ReminderScheduler) sendMedicationReminders(now time.Time) error {
+	windowStart := now.Add(-medicationReminderWindow)
+
+	logs, err := s.medicationRepo.GetMedicationLogsScheduledBetween(windowStart, now)
+	if err != nil {
+		return err
+	}
+	logIndex := indexMedicationLogs(logs)
+
+	// Rentang pengingat bisa melewati tengah malam, jadi periksa jadwal di kedua hari
+	days := []time.Time{recordDateOf(windowStart)}
+	if today := recordDateOf(now); !today.Equal(days[0]) {
+		days = append(days, today)
+	}
+
+	sent := 0
+	for _, day := range days {
+		medications, err := s.medicationRepo.GetScheduledMedicationsForReminder(day)
+		if err != nil {
+			return err
+		}
+		for i := range medications {
+			medication := &medications[i]
+			for _, slot := range medicationSlotsOn(medication, day) {
+				if slot.Before(windowStart) || slot.After(now) {
+					continue
+				}
+				if _, logged := logIndex[medicationLogKey(medication.ID, slot)]; logged {
+					continue
+				}
+
+				instructions := ""
+				if medication.Instructions != nil {
+					instructions = " " + strings.TrimSuffix(*medication.Instructions, ".") + "."
+				}
+				medicationID := medication.ID
+				ok, err := s.notifier.Notify(NotificationMessage{
+					UserID: medication.UserID,
+					Type:   entity.NotificationMedicationReminder,
+					Params: map[string]string{
+						"medication":   medication.Name,
+						"dose":         medication.Dose,
+						"time":         slot.Format("15:04"),
+						"instructions": instructions,
+					},
+					ReferenceID: &medicationID,
+					DedupKey:    fmt.Sprintf("medication:%d:%d", medication.ID, slot.Unix()),
+				})
+				if err != nil {
+					return err
+				}
+				if ok {
+					sent++
+				}
+			}
+		}
+	}
+
+	if sent > 0 {
+		log.Printf("Info: %d pengingat minum obat dikirim", sent)
+	}
+	return nil
+}
+
+// sendMeasurementReminders mengingatkan user yang rutin mengukur tekanan darah
+// tetapi belum mengukur hari ini, sekali sehari pada jam pengingat
+func (s *ReminderScheduler) sendMeasurementReminders(now time.Time) error {
+	today := recordDateOf(now)
+	if !withinDailyReminderWindow(now, today.Add(s.measurementTime)) {
+		return nil
+	}
+
+	candidates, err := s.healthDataRepo.GetBloodPressureReminderCandidates(today.AddDate(0, 0, -reminderActivityDays), today)
+	if err != nil {
+		return err
+	}
+
+	sent := 0
+	for _, candidate := range candidates {
+		ok, err := s.notifier.Notify(NotificationMessage{
+			UserID:   candidate.UserID,
+			Type:     entity.NotificationMeasurementReminder,
+			DedupKey: fmt.Sprintf("measure_bp:%s", today.Format("2006-01-02")),
+		})
+		if err != nil {
+			return err
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		log.Printf("Info: %d pengingat ukur tekanan darah dikirim", sent)
+	}
+	return nil
+}
+
+// sendMissedDayNudges mengajak user yang berhenti mencatat data kesehatan untuk mencatat kembali
+// Dikirim pada jam ajakan saat jumlah hari sejak pencatatan terakhir termasuk missedDayNudgeDays
+func (s *ReminderScheduler) sendMissedDayNudges(now time.Time) error {
+	today := recordDateOf(now)
+	if !withinDailyReminderWindow(now, today.Add(s.nudgeTime)) {
+		return nil
+	}
+
+	// Pencatatan terakhir sebelum kemarin berarti minimal satu hari penuh terlewat
+	candidates, err := s.healthDataRepo.GetInactiveReminderCandidates(today.AddDate(0, 0, -reminderActivityDays), today.AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+
+	sent := 0
+	for _, candidate := range candidates {
+		lastRecordDate := recordDateOf(candidate.LastRecordDate)
+		daysSince := int(today.Sub(lastRecordDate).Hours()/24 + 0.5)
+		if !missedDayNudgeDays[daysSince] {
+			continue
+		}
+
+		ok, err := s.notifier.Notify(NotificationMessage{
+			UserID: candidate.UserID,
+			Type:   entity.NotificationMissedDayNudge,
+			Params: map[string]string{
+				"last_date": lastRecordDate.Format("02/01/2006"),
+				"days":      strconv.Itoa(daysSince),
+			},
+			DedupKey: fmt.Sprintf("missed_day:%s", today.Format("2006-01-02")),
+		})
+		if err != nil {
+			return err
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		log.Printf("Info: %d ajakan mencatat data kesehatan dikirim", sent)
+	}
+	return nil
+}
+
+// withinDailyReminderWindow memeriksa apakah now berada pada [reminderAt, reminderAt + dailyReminderWindow)
+func withinDailyReminderWindow(now, reminderAt time.Time) bool {
+	return !now.Before(reminderAt) && now.Before(reminderAt.Add(dailyReminderWindow))
+}
+
+// parseReminderTime mengubah jam HH:MM menjadi offset dari awal hari
+// Nilai kosong atau tidak valid memakai default (dengan peringatan di log)
+func parseReminderTime(key, value, defaultValue string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		value = defaultValue
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		log.Printf("Warning: %s tidak valid (%s), menggunakan default %s", key, value, defaultValue)
+		t, _ = time.Parse("15:04", defaultValue)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
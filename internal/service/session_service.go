@@ -0,0 +1,246 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// secureTokenBytes adalah panjang token acak (refresh token, reset password) sebelum di-encode hex
+	secureTokenBytes = 32
+	// maxUserAgentLength menyesuaikan panjang kolom user_agent
+	maxUserAgentLength = 255
+)
+
+// SessionService menangani pembuatan token, rotasi refresh token, dan manajemen sesi login
+type SessionService struct {
+	authRepo        *repository.AuthRepository
+	userRepo        *repository.UserRepository
+	jwtSecret       string
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+// NewSessionService membuat instance baru dari SessionService
+func NewSessionService(authRepo *repository.AuthRepository, userRepo *repository.UserRepository, jwtSecret string, accessTokenTTL, refreshTokenTTL time.Duration) *SessionService {
+	return &SessionService{
+		authRepo:        authRepo,
+		userRepo:        userRepo,
+		jwtSecret:       jwtSecret,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+// CreateSession membuat sesi baru untuk user yang berhasil login
+// dan mengembalikan access token beserta refresh token
+func (s *SessionService) CreateSession(user *entity.User, userAgent, ipAddress string) (*response.TokenResponse, error) {
+	refreshToken, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	session := &entity.UserSession{
+		UserID:           user.ID,
+		RefreshTokenHash: hashToken(refreshToken),
+		UserAgent:        truncateUserAgent(userAgent),
+		IPAddress:        ipAddress,
+		ExpiresAt:        now.Add(s.refreshTokenTTL),
+		LastUsedAt:       now,
+	}
+	if err := s.authRepo.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	return s.buildTokenResponse(user, session, refreshToken)
+}
+
+// RefreshSession menukar refresh token dengan pasangan token baru (rotasi).
+// Refresh token lama langsung tidak berlaku. Jika refresh token yang sudah dirotasi
+// dipakai lagi, termasuk oleh refresh bersamaan yang kalah saat rotasi, sesi dianggap bocor dan dicabut.
+func (s *SessionService) RefreshSession(refreshToken, userAgent, ipAddress string) (*response.TokenResponse, error) {
+	tokenHash := hashToken(refreshToken)
+
+	session, err := s.authRepo.GetSessionByRefreshTokenHash(tokenHash)
+	if err != nil {
+		if err.Error() != "sesi tidak ditemukan" {
+			return nil, err
+		}
+
+		if err := s.revokeReusedSession(tokenHash); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	if session.RevokedAt != nil || !session.ExpiresAt.After(now) {
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	// Ambil ulang user agar perubahan data (misalnya role) ikut masuk ke access token baru
+	user, err := s.userRepo.GetUserByID(session.UserID)
+	if err != nil {
+		if err.Error() == "user tidak ditemukan" {
+			return nil, errors.New("refresh token tidak valid")
+		}
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	newRefreshToken, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	previousHash := session.RefreshTokenHash
+	session.PreviousTokenHash = &previousHash
+	session.RefreshTokenHash = hashToken(newRefreshToken)
+	session.ExpiresAt = now.Add(s.refreshTokenTTL)
+	session.LastUsedAt = now
+	if userAgent != "" {
+		session.UserAgent = truncateUserAgent(userAgent)
+	}
+	if ipAddress != "" {
+		session.IPAddress = ipAddress
+	}
+	if err := s.authRepo.RotateSessionToken(session, previousHash); err != nil {
+		if err.Error() != "sesi tidak ditemukan" {
+			return nil, err
+		}
+
+		// Refresh lain dengan token yang sama sudah lebih dulu merotasi sesi: perlakukan sebagai reuse
+		if err := s.revokeReusedSession(tokenHash); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	return s.buildTokenResponse(user, session, newRefreshToken)
+}
+
+// revokeReusedSession mendeteksi reuse: token lama yang sudah dirotasi dipakai kembali.
+// Jika ditemukan, sesi pemilik token dicabut agar token hasil rotasi yang mungkin dicuri ikut tidak berlaku.
+func (s *SessionService) revokeReusedSession(tokenHash string) error {
+	reused, err := s.authRepo.GetSessionByPreviousTokenHash(tokenHash)
+	if err != nil {
+		return err
+	}
+	if reused == nil || reused.RevokedAt != nil {
+		return nil
+	}
+
+	if err := s.authRepo.RevokeSession(reused.ID, reused.UserID); err != nil && err.Error() != "sesi tidak ditemukan" {
+		return err
+	}
+	log.Printf("[Auth] Refresh token lama dipakai ulang, sesi %d milik user %d dicabut", reused.ID, reused.UserID)
+	return nil
+}
+
+// GetActiveSessions mengambil daftar sesi aktif user
+// currentSessionID dipakai untuk menandai sesi yang sedang digunakan (0 jika tidak diketahui)
+func (s *SessionService) GetActiveSessions(userID, currentSessionID uint) ([]response.SessionResponse, error) {
+	sessions, err := s.authRepo.GetActiveSessionsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, response.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  timezoneUtils.ToJakarta(session.CreatedAt),
+			LastUsedAt: timezoneUtils.ToJakarta(session.LastUsedAt),
+			ExpiresAt:  timezoneUtils.ToJakarta(session.ExpiresAt),
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return result, nil
+}
+
+// RevokeSession mencabut satu sesi milik user
+// Access token dan refresh token sesi tersebut langsung tidak berlaku
+func (s *SessionService) RevokeSession(userID, sessionID uint) error {
+	return s.authRepo.RevokeSession(sessionID, userID)
+}
+
+// RevokeAllSessions mencabut semua sesi aktif milik user (logout dari semua perangkat)
+func (s *SessionService) RevokeAllSessions(userID uint) (*response.RevokeAllSessionsResponse, error) {
+	revoked, err := s.authRepo.RevokeAllSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.RevokeAllSessionsResponse{
+		RevokedCount: revoked,
+	}, nil
+}
+
+// buildTokenResponse membuat access token untuk sesi dan menyusun response token
+func (s *SessionService) buildTokenResponse(user *entity.User, session *entity.UserSession, refreshToken string) (*response.TokenResponse, error) {
+	accessToken, err := s.generateAccessToken(user, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.TokenResponse{
+		Token:            accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresIn:        int64(s.accessTokenTTL.Seconds()),
+		RefreshExpiresAt: timezoneUtils.ToJakarta(session.ExpiresAt),
+	}, nil
+}
+
+// generateAccessToken membuat JWT access token berumur pendek yang terikat ke sesi (claim sid)
+func (s *SessionService) generateAccessToken(user *entity.User, sessionID uint) (string, error) {
+	now := timezoneUtils.NowInJakarta()
+	claims := jwt.MapClaims{
+		"sub":   user.ID,
+		"sid":   sessionID,
+		"email": user.Email,
+		"name":  user.Nama,
+		"role":  string(user.Role),
+		"exp":   now.Add(s.accessTokenTTL).Unix(),
+		"iat":   now.Unix(),
+	}
+
+	tokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return tokenObj.SignedString([]byte(s.jwtSecret))
+}
+
+// generateSecureToken membuat token acak (hex) untuk refresh token, token reset password, dan token verifikasi email
+func generateSecureToken() (string, error) {
+	b := make([]byte, secureTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken menghitung hash SHA-256 token (refresh, reset password, verifikasi email) untuk disimpan di database
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// truncateUserAgent memotong user agent agar muat di kolom database
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) > maxUserAgentLength {
+		return userAgent[:maxUserAgentLength]
+	}
+	return userAgent
+}
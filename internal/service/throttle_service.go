@@ -0,0 +1,346 @@
+package service
+
+import (
+	"BE-PeriksaKesehatan/internal/model/dto/request"
+	"BE-PeriksaKesehatan/internal/model/dto/response"
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	timezoneUtils "BE-PeriksaKesehatan/pkg/utils"
+)
+
+// Aksi autentikasi yang dibatasi percobaannya
+const (
+	ThrottleActionLogin         = "login"
+	ThrottleActionRegister      = "register"
+	ThrottleActionPasswordReset = "password_reset"
+)
+
+// RateLimitError menandakan permintaan ditolak karena terlalu sering.
+// RetryAfter berisi waktu tunggu sebelum permintaan boleh diulang.
+type RateLimitError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return e.Message
+}
+
+// ThrottlePolicy mengatur batas percobaan untuk satu aksi dan scope
+type ThrottlePolicy struct {
+	FreeAttempts     int           // Jumlah percobaan gagal tanpa jeda
+	BaseDelay        time.Duration // Jeda setelah melewati FreeAttempts, dikali dua setiap kegagalan berikutnya
+	MaxDelay         time.Duration // Batas atas jeda bertahap
+	MaxAttempts      int           // Jumlah percobaan gagal sebelum dikunci sementara
+	LockoutDuration  time.Duration // Lama penguncian
+	Window           time.Duration // Hitungan direset jika tidak ada kegagalan selama durasi ini
+	CountAllRequests bool          // true: setiap request dihitung (bukan hanya yang gagal), misalnya register dan lupa password
+}
+
+// defaultThrottlePolicies berisi kebijakan per aksi dan per scope
+var defaultThrottlePolicies = map[string]map[string]ThrottlePolicy{
+	ThrottleActionLogin: {
+		entity.ThrottleScopeAccount: {FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second, MaxAttempts: 10, LockoutDuration: 15 * time.Minute, Window: time.Hour},
+		entity.ThrottleScopeIP:      {FreeAttempts: 10, BaseDelay: time.Second, MaxDelay: 30 * time.Second, MaxAttempts: 50, LockoutDuration: 15 * time.Minute, Window: time.Hour},
+	},
+	ThrottleActionRegister: {
+		entity.ThrottleScopeIP: {FreeAttempts: 5, BaseDelay: 5 * time.Second, MaxDelay: time.Minute, MaxAttempts: 20, LockoutDuration: time.Hour, Window: time.Hour, CountAllRequests: true},
+	},
+	ThrottleActionPasswordReset: {
+		entity.ThrottleScopeAccount: {FreeAttempts: 3, BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute, MaxAttempts: 10, LockoutDuration: time.Hour, Window: time.Hour, CountAllRequests: true},
+		entity.ThrottleScopeIP:      {FreeAttempts: 5, BaseDelay: 5 * time.Second, MaxDelay: time.Minute, MaxAttempts: 20, LockoutDuration: time.Hour, Window: time.Hour, CountAllRequests: true},
+	},
+}
+
+// ThrottleService menangani pembatasan percobaan autentikasi (progressive delay dan penguncian sementara)
+// per akun dan per IP, beserta audit penguncian
+type ThrottleService struct {
+	throttleRepo *repository.ThrottleRepository
+	policies     map[string]map[string]ThrottlePolicy
+}
+
+// NewThrottleService membuat instance baru dari ThrottleService dengan kebijakan default
+func NewThrottleService(throttleRepo *repository.ThrottleRepository) *ThrottleService {
+	return &ThrottleService{
+		throttleRepo: throttleRepo,
+		policies:     defaultThrottlePolicies,
+	}
+}
+
+// UserThrottleKey membentuk key scope account untuk user yang dikenal
+func UserThrottleKey(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// IdentifierThrottleKey membentuk key scope account dari identifier (email/username) yang dicoba
+func IdentifierThrottleKey(identifier string) string {
+	return "identifier:" + strings.ToLower(strings.TrimSpace(identifier))
+}
+
+// policy mengambil kebijakan untuk aksi dan scope
+func (s *ThrottleService) policy(action, scope string) (ThrottlePolicy, bool) {
+	policy, ok := s.policies[action][scope]
+	return policy, ok
+}
+
+// ShouldRecord menentukan apakah request dengan status HTTP tertentu dihitung sebagai percobaan
+// Request yang ditolak karena rate limit (429) tidak dihitung ulang
+func (s *ThrottleService) ShouldRecord(action, scope string, status int) bool {
+	policy, ok := s.policy(action, scope)
+	if !ok || status == 429 {
+		return false
+	}
+	return policy.CountAllRequests || (status >= 400 && status < 500)
+}
+
+// Check memeriksa apakah percobaan baru diizinkan.
+// Mengembalikan *RateLimitError jika sedang dikunci atau masih dalam jeda bertahap.
+func (s *ThrottleService) Check(action, scope, key string) error {
+	if _, ok := s.policy(action, scope); !ok {
+		return nil
+	}
+
+	throttle, err := s.throttleRepo.GetThrottle(action, scope, key)
+	if err != nil {
+		return err
+	}
+	if throttle == nil {
+		return nil
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	if throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+		return &RateLimitError{
+			Message:    "terlalu banyak percobaan gagal, akses dikunci sementara",
+			RetryAfter: throttle.LockedUntil.Sub(now),
+		}
+	}
+	if throttle.NextAttemptAt != nil && throttle.NextAttemptAt.After(now) {
+		return &RateLimitError{
+			Message:    "terlalu banyak percobaan, coba lagi dalam beberapa saat",
+			RetryAfter: throttle.NextAttemptAt.Sub(now),
+		}
+	}
+	return nil
+}
+
+// RecordFailure mencatat satu percobaan (gagal) dan menerapkan jeda bertahap atau penguncian.
+// userID dan ipAddress bersifat opsional dan hanya dipakai untuk audit saat penguncian terjadi.
+func (s *ThrottleService) RecordFailure(action, scope, key string, userID *uint, ipAddress string) error {
+	policy, ok := s.policy(action, scope)
+	if !ok {
+		return nil
+	}
+
+	now := timezoneUtils.NowInJakarta()
+	var lockedUntil *time.Time
+	var failureCount int
+
+	err := s.throttleRepo.UpdateThrottleLocked(action, scope, key, func(throttle *entity.AuthThrottle) error {
+		// Hitungan dimulai ulang setelah penguncian berakhir atau jika kegagalan terakhir sudah lama
+		lockExpired := throttle.LockedUntil != nil && !throttle.LockedUntil.After(now)
+		windowExpired := throttle.LastFailureAt != nil && now.Sub(*throttle.LastFailureAt) > policy.Window
+		if lockExpired || windowExpired {
+			throttle.FailureCount = 0
+			throttle.LockedUntil = nil
+			throttle.NextAttemptAt = nil
+		}
+
+		throttle.FailureCount++
+		throttle.LastFailureAt = &now
+		failureCount = throttle.FailureCount
+
+		if throttle.FailureCount >= policy.MaxAttempts {
+			until := now.Add(policy.LockoutDuration)
+			throttle.LockedUntil = &until
+			throttle.NextAttemptAt = nil
+			lockedUntil = &until
+			return nil
+		}
+
+		if throttle.FailureCount > policy.FreeAttempts {
+			next := now.Add(progressiveDelay(policy, throttle.FailureCount-policy.FreeAttempts))
+			throttle.NextAttemptAt = &next
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if lockedUntil != nil {
+		s.writeAuditLog(&entity.AuthAuditLog{
+			Event:     entity.AuthAuditEventLockout,
+			Action:    action,
+			Scope:     scope,
+			Key:       key,
+			UserID:    userID,
+			IPAddress: ipAddress,
+			Details:   fmt.Sprintf("%d percobaan gagal, dikunci sampai %s", failureCount, timezoneUtils.ToJakarta(*lockedUntil).Format(time.RFC3339)),
+		})
+	}
+	return nil
+}
+
+// Reset menghapus hitungan percobaan (misalnya setelah login berhasil)
+func (s *ThrottleService) Reset(action, scope, key string) error {
+	return s.throttleRepo.DeleteThrottle(action, scope, key)
+}
+
+// UnlockUserAccount membuka penguncian login akun user, misalnya setelah reset password berhasil.
+// Audit hanya dicatat jika akun memang sedang dikunci.
+func (s *ThrottleService) UnlockUserAccount(userID uint, reason string) error {
+	key := UserThrottleKey(userID)
+	throttle, err := s.throttleRepo.GetThrottle(ThrottleActionLogin, entity.ThrottleScopeAccount, key)
+	if err != nil {
+		return err
+	}
+	if throttle == nil {
+		return nil
+	}
+
+	if err := s.throttleRepo.DeleteThrottle(ThrottleActionLogin, entity.ThrottleScopeAccount, key); err != nil {
+		return err
+	}
+
+	if throttle.LockedUntil != nil && throttle.LockedUntil.After(timezoneUtils.NowInJakarta()) {
+		s.writeAuditLog(&entity.AuthAuditLog{
+			Event:   entity.AuthAuditEventUnlock,
+			Action:  ThrottleActionLogin,
+			Scope:   entity.ThrottleScopeAccount,
+			Key:     key,
+			UserID:  &userID,
+			Details: reason,
+		})
+	}
+	return nil
+}
+
+// UnlockByID membuka penguncian berdasarkan ID oleh admin dan mencatat audit
+func (s *ThrottleService) UnlockByID(throttleID, adminID uint) error {
+	throttle, err := s.throttleRepo.GetThrottleByID(throttleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.throttleRepo.DeleteThrottle(throttle.Action, throttle.Scope, throttle.Key); err != nil {
+		return err
+	}
+
+	s.writeAuditLog(&entity.AuthAuditLog{
+		Event:   entity.AuthAuditEventUnlock,
+		Action:  throttle.Action,
+		Scope:   throttle.Scope,
+		Key:     throttle.Key,
+		ActorID: &adminID,
+		Details: "dibuka oleh admin",
+	})
+	return nil
+}
+
+// CleanupStaleThrottles menghapus data percobaan yang sudah tidak berpengaruh pada waktu now:
+// tidak sedang dikunci dan tidak ada kegagalan selama Window terpanjang dari semua kebijakan
+// (hitungannya akan direset pada percobaan berikutnya). Mengembalikan jumlah data yang dihapus.
+func (s *ThrottleService) CleanupStaleThrottles(now time.Time) (int64, error) {
+	var longestWindow time.Duration
+	for _, scopes := range s.policies {
+		for _, policy := range scopes {
+			longestWindow = max(longestWindow, policy.Window)
+		}
+	}
+	return s.throttleRepo.CleanupStaleThrottles(now, now.Add(-longestWindow))
+}
+
+// GetActiveLockouts mengambil daftar penguncian yang masih berlaku (admin)
+func (s *ThrottleService) GetActiveLockouts(req *request.AdminLockoutListRequest) (*response.AdminLockoutListResponse, error) {
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	throttles, total, err := s.throttleRepo.GetActiveLockouts(timezoneUtils.NowInJakarta(), (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.AdminLockoutResponse, 0, len(throttles))
+	for _, throttle := range throttles {
+		items = append(items, response.AdminLockoutResponse{
+			ID:            throttle.ID,
+			Action:        throttle.Action,
+			Scope:         throttle.Scope,
+			Key:           throttle.Key,
+			FailureCount:  throttle.FailureCount,
+			LastFailureAt: toJakartaPtr(throttle.LastFailureAt),
+			LockedUntil:   toJakartaPtr(throttle.LockedUntil),
+		})
+	}
+
+	return &response.AdminLockoutListResponse{
+		Lockouts:   items,
+		Pagination: newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// GetAuditLogs mengambil catatan audit autentikasi (admin)
+func (s *ThrottleService) GetAuditLogs(req *request.AdminAuthAuditLogListRequest) (*response.AdminAuthAuditLogListResponse, error) {
+	event := strings.ToLower(strings.TrimSpace(req.Event))
+	if event != "" && event != entity.AuthAuditEventLockout && event != entity.AuthAuditEventUnlock {
+		return nil, errors.New("event harus salah satu dari lockout, unlock")
+	}
+
+	page, limit := normalizePagination(req.Page, req.Limit)
+
+	logs, total, err := s.throttleRepo.GetAuditLogs(event, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.AdminAuthAuditLogResponse, 0, len(logs))
+	for _, auditLog := range logs {
+		items = append(items, response.AdminAuthAuditLogResponse{
+			ID:        auditLog.ID,
+			Event:     auditLog.Event,
+			Action:    auditLog.Action,
+			Scope:     auditLog.Scope,
+			Key:       auditLog.Key,
+			UserID:    auditLog.UserID,
+			ActorID:   auditLog.ActorID,
+			IPAddress: auditLog.IPAddress,
+			Details:   auditLog.Details,
+			CreatedAt: timezoneUtils.ToJakarta(auditLog.CreatedAt),
+		})
+	}
+
+	return &response.AdminAuthAuditLogListResponse{
+		AuditLogs:  items,
+		Pagination: newPaginationResponse(page, limit, total),
+	}, nil
+}
+
+// writeAuditLog menyimpan audit; kegagalan hanya dicatat di log agar tidak menggagalkan request
+func (s *ThrottleService) writeAuditLog(auditLog *entity.AuthAuditLog) {
+	if err := s.throttleRepo.CreateAuditLog(auditLog); err != nil {
+		log.Printf("[Auth] Warning: gagal menyimpan audit %s untuk %s/%s/%s: %v", auditLog.Event, auditLog.Action, auditLog.Scope, auditLog.Key, err)
+		return
+	}
+	log.Printf("[Auth] Audit %s: %s/%s/%s (%s)", auditLog.Event, auditLog.Action, auditLog.Scope, auditLog.Key, auditLog.Details)
+}
+
+// progressiveDelay menghitung jeda untuk kegagalan ke-n setelah FreeAttempts (BaseDelay * 2^(n-1), maksimal MaxDelay)
+func progressiveDelay(policy ThrottlePolicy, n int) time.Duration {
+	delay := policy.BaseDelay
+	for i := 1; i < n; i++ {
+		delay *= 2
+		if delay >= policy.MaxDelay {
+			return policy.MaxDelay
+		}
+	}
+	if delay > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressiveDelay(t *testing.T) {
+	policy := ThrottlePolicy{BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+	tests := []struct {
+		name   string
+		policy ThrottlePolicy
+		n      int
+		want   time.Duration
+	}{
+		{"kegagalan pertama memakai jeda dasar", policy, 1, time.Second},
+		{"kegagalan kedua dikali dua", policy, 2, 2 * time.Second},
+		{"kegagalan kelima", policy, 5, 16 * time.Second},
+		{"dibatasi MaxDelay", policy, 6, 30 * time.Second},
+		{"n besar tetap MaxDelay", policy, 1000, 30 * time.Second},
+		{"n nol memakai jeda dasar", policy, 0, time.Second},
+		{"jeda dasar melebihi MaxDelay", ThrottlePolicy{BaseDelay: time.Minute, MaxDelay: 30 * time.Second}, 1, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := progressiveDelay(tt.policy, tt.n); got != tt.want {
+				t.Errorf("progressiveDelay(n=%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
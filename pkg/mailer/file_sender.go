@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// unsafeFilenameChars berisi karakter yang diganti saat membentuk nama file dari alamat email
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// FileSender menyimpan setiap email sebagai file .eml di direktori lokal (untuk development)
+type FileSender struct {
+	from string
+	dir  string
+}
+
+// NewFileSender membuat instance baru dari FileSender dan memastikan direktori tujuan tersedia
+func NewFileSender(from, dir string) (*FileSender, error) {
+	if dir == "" {
+		return nil, errors.New("direktori mail file tidak boleh kosong")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("gagal membuat direktori mail: %w", err)
+	}
+
+	return &FileSender{
+		from: from,
+		dir:  dir,
+	}, nil
+}
+
+// Send menulis email ke file <timestamp>_<penerima>.eml
+func (s *FileSender) Send(msg Message) error {
+	now := time.Now()
+	filename := fmt.Sprintf("%s_%s.eml", now.Format("20060102T150405.000000000"), unsafeFilenameChars.ReplaceAllString(msg.To, "_"))
+
+	content := buildMessage(s.from, msg, now)
+	return os.WriteFile(filepath.Join(s.dir, filename), content, 0o644)
+}
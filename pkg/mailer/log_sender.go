@@ -0,0 +1,21 @@
+package mailer
+
+import "log"
+
+// LogSender menulis email ke log aplikasi (untuk development)
+type LogSender struct {
+	from string
+}
+
+// NewLogSender membuat instance baru dari LogSender
+func NewLogSender(from string) *LogSender {
+	return &LogSender{
+		from: from,
+	}
+}
+
+// Send menulis isi email ke log
+func (s *LogSender) Send(msg Message) error {
+	log.Printf("[Mailer] From: %s | To: %s | Subject: %s\n%s", s.from, msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Driver pengiriman email yang didukung
+const (
+	DriverLog  = "log"
+	DriverFile = "file"
+	DriverSMTP = "smtp"
+)
+
+// Message adalah email yang akan dikirim
+type Message struct {
+	To      string
+	Subject string
+	Body    string // Isi email dalam format plain text
+}
+
+// Sender adalah interface pengirim email.
+// Implementasi dapat diganti sesuai environment (log/file untuk development, SMTP untuk production).
+type Sender interface {
+	Send(msg Message) error
+}
+
+// Config menampung konfigurasi pengirim email
+type Config struct {
+	Driver string // log, file, atau smtp (default log)
+	From   string // Alamat pengirim
+
+	// Driver file: direktori tujuan file email
+	FileDir string
+
+	// Driver smtp
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+}
+
+// New membuat Sender berdasarkan driver pada konfigurasi
+func New(cfg Config) (Sender, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Driver)) {
+	case "", DriverLog:
+		return NewLogSender(cfg.From), nil
+	case DriverFile:
+		return NewFileSender(cfg.From, cfg.FileDir)
+	case DriverSMTP:
+		return NewSMTPSender(cfg.From, cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword)
+	default:
+		return nil, fmt.Errorf("mail driver %q tidak dikenal (gunakan log, file, atau smtp)", cfg.Driver)
+	}
+}
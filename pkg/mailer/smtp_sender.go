@@ -0,0 +1,65 @@
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPSender mengirim email melalui server SMTP
+type SMTPSender struct {
+	from string
+	addr string
+	auth smtp.Auth
+}
+
+// NewSMTPSender membuat instance baru dari SMTPSender
+// Autentikasi PLAIN dipakai jika username diisi
+func NewSMTPSender(from, host, port, username, password string) (*SMTPSender, error) {
+	if host == "" {
+		return nil, errors.New("SMTP host tidak boleh kosong")
+	}
+	if from == "" {
+		return nil, errors.New("alamat pengirim email tidak boleh kosong")
+	}
+	if port == "" {
+		port = "587"
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPSender{
+		from: from,
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+	}, nil
+}
+
+// Send mengirim email melalui SMTP
+func (s *SMTPSender) Send(msg Message) error {
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, buildMessage(s.from, msg, time.Now())); err != nil {
+		return fmt.Errorf("gagal mengirim email ke %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// buildMessage menyusun email plain text lengkap dengan header
+func buildMessage(from string, msg Message, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return buf.Bytes()
+}
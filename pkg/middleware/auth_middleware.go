@@ -12,6 +12,9 @@ import (
 
 const UserIDKey = "userID"
 
+// SessionIDKey adalah key context untuk ID sesi login (claim sid) dari access token
+const SessionIDKey = "sessionID"
+
 // AuthMiddleware membuat middleware untuk validasi JWT token
 func AuthMiddleware(authRepo *repository.AuthRepository, jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -106,6 +109,37 @@ func AuthMiddleware(authRepo *repository.AuthRepository, jwtSecret string) gin.H
 			return
 		}
 
+		// Access token yang terikat ke sesi (claim sid) hanya berlaku selama sesinya aktif,
+		// sehingga pencabutan sesi (termasuk saat akun dinonaktifkan) langsung berlaku.
+		// Token lama tanpa sid tidak bisa dicabut lewat sesi, jadi status akunnya diperiksa langsung.
+		if sid, ok := claims["sid"].(float64); ok {
+			sessionID := uint(sid)
+			isActive, err := authRepo.IsSessionActive(sessionID, userID)
+			if err != nil {
+				utils.InternalServerError(c, "Gagal memeriksa status sesi", err.Error())
+				c.Abort()
+				return
+			}
+			if !isActive {
+				utils.Unauthorized(c, "Sesi sudah berakhir, silakan login kembali")
+				c.Abort()
+				return
+			}
+			c.Set(SessionIDKey, sessionID)
+		} else {
+			isActive, err := authRepo.IsUserActive(userID)
+			if err != nil {
+				utils.InternalServerError(c, "Gagal memeriksa status akun", err.Error())
+				c.Abort()
+				return
+			}
+			if !isActive {
+				utils.Unauthorized(c, "Akun dinonaktifkan atau tidak ditemukan")
+				c.Abort()
+				return
+			}
+		}
+
 		// Set userID ke context untuk digunakan di handler
 		c.Set(UserIDKey, userID)
 		c.Next()
@@ -128,3 +162,18 @@ func GetUserIDFromContext(c *gin.Context) (uint, bool) {
 	return id, true
 }
 
+// GetSessionIDFromContext mengambil ID sesi dari gin context
+// Mengembalikan false jika access token tidak terikat ke sesi (token lama)
+func GetSessionIDFromContext(c *gin.Context) (uint, bool) {
+	sessionID, exists := c.Get(SessionIDKey)
+	if !exists {
+		return 0, false
+	}
+
+	id, ok := sessionID.(uint)
+	if !ok {
+		return 0, false
+	}
+
+	return id, true
+}
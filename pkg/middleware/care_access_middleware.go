@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TargetUserIDKey adalah key context untuk ID pasien pemilik data yang sedang diakses
+const TargetUserIDKey = "targetUserID"
+
+// PatientIDQueryParam adalah query parameter untuk mengakses data pasien lain sebagai caregiver
+const PatientIDQueryParam = "patient_id"
+
+// CareAccess membuat middleware untuk akses data pasien oleh caregiver/keluarga.
+// Jika query parameter patient_id diisi, akses diperiksa terhadap care grant yang aktif:
+// request GET cukup dengan scope view, sedangkan request yang mengubah data butuh scope record.
+// allowRecord bernilai false untuk grup route yang hanya boleh dibaca oleh caregiver.
+// Harus dipasang setelah AuthMiddleware.
+func CareAccess(careService *service.CareService, allowRecord bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := GetUserIDFromContext(c)
+		if !ok {
+			utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+			c.Abort()
+			return
+		}
+
+		patientIDStr := strings.TrimSpace(c.Query(PatientIDQueryParam))
+		if patientIDStr == "" {
+			c.Set(TargetUserIDKey, userID)
+			c.Next()
+			return
+		}
+
+		patientID, err := strconv.ParseUint(patientIDStr, 10, 32)
+		if err != nil || patientID == 0 {
+			utils.BadRequest(c, "patient_id tidak valid", nil)
+			c.Abort()
+			return
+		}
+
+		isWrite := c.Request.Method != http.MethodGet
+		if isWrite && !allowRecord && uint(patientID) != userID {
+			utils.Forbidden(c, "Caregiver hanya dapat melihat data ini")
+			c.Abort()
+			return
+		}
+
+		if err := careService.CheckAccess(userID, uint(patientID), isWrite); err != nil {
+			if strings.Contains(err.Error(), "akses") {
+				utils.Forbidden(c, err.Error())
+				c.Abort()
+				return
+			}
+			utils.InternalServerError(c, "Gagal memeriksa akses caregiver", err.Error())
+			c.Abort()
+			return
+		}
+
+		// Set ID pasien ke context untuk digunakan di handler
+		c.Set(TargetUserIDKey, uint(patientID))
+		c.Next()
+	}
+}
+
+// GetTargetUserIDFromContext mengambil ID pasien pemilik data dari gin context.
+// Jika CareAccess tidak dipasang, mengembalikan user ID dari token.
+func GetTargetUserIDFromContext(c *gin.Context) (uint, bool) {
+	targetUserID, exists := c.Get(TargetUserIDKey)
+	if !exists {
+		return GetUserIDFromContext(c)
+	}
+
+	id, ok := targetUserID.(uint)
+	if !ok {
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"BE-PeriksaKesehatan/internal/repository"
+	"BE-PeriksaKesehatan/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireVerifiedEmail membuat middleware untuk memblokir user yang emailnya belum diverifikasi.
+// Harus dipasang setelah AuthMiddleware. Jika enabled bernilai false, middleware tidak melakukan apa-apa
+// sehingga verifikasi email bisa diaktifkan bertahap lewat konfigurasi.
+func RequireVerifiedEmail(userRepo *repository.UserRepository, enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled {
+			c.Next()
+			return
+		}
+
+		userID, ok := GetUserIDFromContext(c)
+		if !ok {
+			utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+			c.Abort()
+			return
+		}
+
+		user, err := userRepo.GetUserByID(userID)
+		if err != nil {
+			if err.Error() == "user tidak ditemukan" {
+				utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+				c.Abort()
+				return
+			}
+			utils.InternalServerError(c, "Gagal memeriksa status verifikasi email", err.Error())
+			c.Abort()
+			return
+		}
+
+		if !user.IsEmailVerified() {
+			utils.Forbidden(c, "Email belum diverifikasi, silakan verifikasi email terlebih dahulu")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
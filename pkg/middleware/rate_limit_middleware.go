@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/service"
+	"BE-PeriksaKesehatan/pkg/utils"
+	"errors"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit membuat middleware pembatasan percobaan per IP untuk aksi autentikasi tertentu
+// (login, register, reset password). Request ditolak dengan 429 jika IP sedang dalam jeda
+// atau dikunci. Setelah handler selesai, request dihitung sesuai kebijakan aksi
+// (hanya yang gagal untuk login, setiap request untuk register dan reset password).
+func RateLimit(throttleService *service.ThrottleService, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+
+		if err := throttleService.Check(action, entity.ThrottleScopeIP, ip); err != nil {
+			var rateLimitErr *service.RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				utils.TooManyRequests(c, rateLimitErr.Message, rateLimitErr.RetryAfter)
+				c.Abort()
+				return
+			}
+			utils.InternalServerError(c, "Gagal memeriksa batas percobaan", err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		if throttleService.ShouldRecord(action, entity.ThrottleScopeIP, c.Writer.Status()) {
+			if err := throttleService.RecordFailure(action, entity.ThrottleScopeIP, ip, nil, ip); err != nil {
+				log.Printf("[Auth] Warning: gagal mencatat percobaan %s dari IP %s: %v", action, ip, err)
+			}
+		}
+	}
+}
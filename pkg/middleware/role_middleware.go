@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"BE-PeriksaKesehatan/internal/model/entity"
+	"BE-PeriksaKesehatan/internal/repository"
+	"BE-PeriksaKesehatan/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const UserRoleKey = "userRole"
+
+// RequireRoles membuat middleware untuk membatasi akses route berdasarkan role user.
+// Harus dipasang setelah AuthMiddleware. Role dibaca dari database (bukan dari token)
+// sehingga perubahan role langsung berlaku tanpa perlu login ulang.
+func RequireRoles(userRepo *repository.UserRepository, roles ...entity.UserRole) gin.HandlerFunc {
+	allowed := make(map[entity.UserRole]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(c *gin.Context) {
+		userID, ok := GetUserIDFromContext(c)
+		if !ok {
+			utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+			c.Abort()
+			return
+		}
+
+		user, err := userRepo.GetUserByID(userID)
+		if err != nil {
+			if err.Error() == "user tidak ditemukan" {
+				utils.Unauthorized(c, "Token tidak valid atau tidak ditemukan")
+				c.Abort()
+				return
+			}
+			utils.InternalServerError(c, "Gagal memeriksa hak akses", err.Error())
+			c.Abort()
+			return
+		}
+
+		if !allowed[user.Role] {
+			utils.Forbidden(c, "Anda tidak memiliki akses ke resource ini")
+			c.Abort()
+			return
+		}
+
+		// Set role ke context untuk digunakan di handler
+		c.Set(UserRoleKey, user.Role)
+		c.Next()
+	}
+}
+
+// GetUserRoleFromContext mengambil role user dari gin context
+// Harus dipanggil setelah RequireRoles
+func GetUserRoleFromContext(c *gin.Context) (entity.UserRole, bool) {
+	role, exists := c.Get(UserRoleKey)
+	if !exists {
+		return "", false
+	}
+
+	userRole, ok := role.(entity.UserRole)
+	if !ok {
+		return "", false
+	}
+
+	return userRole, true
+}
@@ -62,6 +62,8 @@ func RequireAtLeastOneHealthMetric(
 	systolic, diastolic, bloodSugar, weight, heartRate *int,
 	weightFloat *float64,
 	height *int,
+	oxygenSaturation, respiratoryRate *int,
+	temperature *float64,
 ) error {
 	hasAtLeastOne := false
 
@@ -80,9 +82,12 @@ func RequireAtLeastOneHealthMetric(
 	if heartRate != nil {
 		hasAtLeastOne = true
 	}
+	if oxygenSaturation != nil || respiratoryRate != nil || temperature != nil {
+		hasAtLeastOne = true
+	}
 
 	if !hasAtLeastOne {
-		return errors.New("minimal satu metrik kesehatan harus diisi (systolic/diastolic, blood_sugar, weight, height, atau heart_rate)")
+		return errors.New("minimal satu metrik kesehatan harus diisi (systolic/diastolic, blood_sugar, weight, height, heart_rate, oxygen_saturation, temperature, atau respiratory_rate)")
 	}
 
 	return nil
@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -42,11 +45,26 @@ func Unauthorized(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusUnauthorized, message, nil)
 }
 
+// Forbidden mengirim response 403 Forbidden
+func Forbidden(c *gin.Context, message string) {
+	ErrorResponse(c, http.StatusForbidden, message, nil)
+}
+
 // NotFound mengirim response 404 Not Found
 func NotFound(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusNotFound, message, nil)
 }
 
+// TooManyRequests mengirim response 429 Too Many Requests beserta header Retry-After (detik)
+func TooManyRequests(c *gin.Context, message string, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	ErrorResponse(c, http.StatusTooManyRequests, message, gin.H{"retry_after": seconds})
+}
+
 // InternalServerError mengirim response 500 Internal Server Error
 func InternalServerError(c *gin.Context, message string, err interface{}) {
 	ErrorResponse(c, http.StatusInternalServerError, message, err)